@@ -4,7 +4,36 @@ import (
 	"errors"
 	"fmt"
 	"github.com/jfrog/jfrog-cli-core/artifactory/commands/yarn"
+	buildinfocommands "github.com/jfrog/jfrog-cli/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/buildtools"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/cargo"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/conan"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/depsbundle"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/dotnetdeps"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/goworkspace"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/helm"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/linuxpkg"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/mvndeploy"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/npmlock"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/oci"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/pnpm"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/policy"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectsetup"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/proxy"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/python"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/terraform"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/debupload"
+	depsbundledoc "github.com/jfrog/jfrog-cli/docs/artifactory/depsbundle"
 	mvndoc "github.com/jfrog/jfrog-cli/docs/artifactory/mvn"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/mvndeployfile"
+	pnpmdocs "github.com/jfrog/jfrog-cli/docs/artifactory/pnpm"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/pnpmconfig"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/proxyserve"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/rpmupload"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/setup"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/terraformconfig"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/terraformpublish"
 	yarndocs "github.com/jfrog/jfrog-cli/docs/artifactory/yarn"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/yarnconfig"
 	"io/ioutil"
@@ -24,6 +53,7 @@ import (
 	"github.com/jfrog/jfrog-cli-core/utils/ioutils"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/accesstokencreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddockercreate"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildocicreate"
 	dotnetdocs "github.com/jfrog/jfrog-cli/docs/artifactory/dotnet"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/dotnetconfig"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/groupaddusers"
@@ -52,7 +82,6 @@ import (
 	"github.com/jfrog/jfrog-cli-core/artifactory/commands/gradle"
 	"github.com/jfrog/jfrog-cli-core/artifactory/commands/mvn"
 	"github.com/jfrog/jfrog-cli-core/artifactory/commands/npm"
-	"github.com/jfrog/jfrog-cli-core/artifactory/commands/pip"
 	"github.com/jfrog/jfrog-cli-core/artifactory/commands/replication"
 	"github.com/jfrog/jfrog-cli-core/artifactory/commands/repository"
 	commandUtils "github.com/jfrog/jfrog-cli-core/artifactory/commands/utils"
@@ -70,9 +99,15 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildcollectenv"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddiscard"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/builddistribute"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildgraph"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpromote"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildpublish"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/buildscan"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/buildtrace"
+	cargodocs "github.com/jfrog/jfrog-cli/docs/artifactory/cargo"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/cargoconfig"
+	conandocs "github.com/jfrog/jfrog-cli/docs/artifactory/conan"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/conanconfig"
 	configdocs "github.com/jfrog/jfrog-cli/docs/artifactory/config"
 	copydocs "github.com/jfrog/jfrog-cli/docs/artifactory/copy"
 	curldocs "github.com/jfrog/jfrog-cli/docs/artifactory/curl"
@@ -88,6 +123,8 @@ import (
 	"github.com/jfrog/jfrog-cli/docs/artifactory/gopublish"
 	gradledoc "github.com/jfrog/jfrog-cli/docs/artifactory/gradle"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/gradleconfig"
+	helmdocs "github.com/jfrog/jfrog-cli/docs/artifactory/helm"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/helmconfig"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/move"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/mvnconfig"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/npmci"
@@ -97,9 +134,13 @@ import (
 	nugetdocs "github.com/jfrog/jfrog-cli/docs/artifactory/nuget"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/nugetconfig"
 	nugettree "github.com/jfrog/jfrog-cli/docs/artifactory/nugetdepstree"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/ocipush"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/ping"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/pipconfig"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/pipinstall"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/pippublish"
+	poetrydocs "github.com/jfrog/jfrog-cli/docs/artifactory/poetry"
+	"github.com/jfrog/jfrog-cli/docs/artifactory/poetryconfig"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/releasebundlecreate"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/releasebundledelete"
 	"github.com/jfrog/jfrog-cli/docs/artifactory/releasebundledistribute"
@@ -296,6 +337,19 @@ func GetCommands() []cli.Command {
 				return buildAppendCmd(c)
 			},
 		},
+		{
+			Name:         "build-graph",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildGraph),
+			Aliases:      []string{"bg"},
+			Description:  buildgraph.Description,
+			HelpName:     corecommon.CreateUsage("rt build-graph", buildgraph.Description, buildgraph.Usage),
+			UsageText:    buildgraph.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildGraphCmd(c)
+			},
+		},
 		{
 			Name:         "build-add-dependencies",
 			Flags:        cliutils.GetCommandFlags(cliutils.BuildAddDependencies),
@@ -309,6 +363,19 @@ func GetCommands() []cli.Command {
 				return buildAddDependenciesCmd(c)
 			},
 		},
+		{
+			Name:         "build-trace",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildTrace),
+			Aliases:      []string{"bt"},
+			Description:  buildtrace.Description,
+			HelpName:     corecommon.CreateUsage("rt build-trace", buildtrace.Description, buildtrace.Usage),
+			UsageText:    buildtrace.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildTraceCmd(c)
+			},
+		},
 		{
 			Name:         "build-add-git",
 			Flags:        cliutils.GetCommandFlags(cliutils.BuildAddGit),
@@ -399,6 +466,17 @@ func GetCommands() []cli.Command {
 				return gitLfsCleanCmd(c)
 			},
 		},
+		{
+			Name:         "setup",
+			Flags:        cliutils.GetCommandFlags(cliutils.Setup),
+			Description:  setup.Description,
+			HelpName:     corecommon.CreateUsage("rt setup", setup.Description, setup.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return setupCmd(c)
+			},
+		},
 		{
 			Name:         "mvn-config",
 			Aliases:      []string{"mvnc"},
@@ -424,6 +502,19 @@ func GetCommands() []cli.Command {
 				return mvnCmd(c)
 			},
 		},
+		{
+			Name:         "mvn-deploy-file",
+			Flags:        cliutils.GetCommandFlags(cliutils.MvnDeployFile),
+			Aliases:      []string{"mvndf"},
+			Description:  mvndeployfile.Description,
+			HelpName:     corecommon.CreateUsage("rt mvn-deploy-file", mvndeployfile.Description, mvndeployfile.Usage),
+			UsageText:    mvndeployfile.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return mvnDeployFileCmd(c)
+			},
+		},
 		{
 			Name:         "gradle-config",
 			Aliases:      []string{"gradlec"},
@@ -527,6 +618,32 @@ func GetCommands() []cli.Command {
 				return BuildDockerCreateCmd(c)
 			},
 		},
+		{
+			Name:         "oci-push",
+			Flags:        cliutils.GetCommandFlags(cliutils.OciPush),
+			Aliases:      []string{"op"},
+			Description:  ocipush.Description,
+			HelpName:     corecommon.CreateUsage("rt oci-push", ocipush.Description, ocipush.Usage),
+			UsageText:    ocipush.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return ociPushCmd(c)
+			},
+		},
+		{
+			Name:         "build-oci-create",
+			Flags:        cliutils.GetCommandFlags(cliutils.BuildOciCreate),
+			Aliases:      []string{"boc"},
+			Description:  buildocicreate.Description,
+			HelpName:     corecommon.CreateUsage("rt build-oci-create", buildocicreate.Description, buildocicreate.Usage),
+			UsageText:    buildocicreate.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return buildOciCreateCmd(c)
+			},
+		},
 		{
 			Name:         "npm-config",
 			Flags:        cliutils.GetCommandFlags(cliutils.NpmConfig),
@@ -604,6 +721,31 @@ func GetCommands() []cli.Command {
 				return yarnCmd(c)
 			},
 		},
+		{
+			Name:         "pnpm-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.PnpmConfig),
+			Aliases:      []string{"pnpmc"},
+			Description:  pnpmconfig.Description,
+			HelpName:     corecommon.CreateUsage("rt pnpm-config", pnpmconfig.Description, pnpmconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return createPnpmConfigCmd(c)
+			},
+		},
+		{
+			Name:            "pnpm",
+			Flags:           cliutils.GetCommandFlags(cliutils.Pnpm),
+			Description:     pnpmdocs.Description,
+			HelpName:        corecommon.CreateUsage("rt pnpm", pnpmdocs.Description, pnpmdocs.Usage),
+			UsageText:       pnpmdocs.Arguments,
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return pnpmCmd(c)
+			},
+		},
 		{
 			Name:         "nuget-config",
 			Flags:        cliutils.GetCommandFlags(cliutils.NugetConfig),
@@ -716,6 +858,18 @@ func GetCommands() []cli.Command {
 				return pingCmd(c)
 			},
 		},
+		{
+			Name:         "proxy",
+			Flags:        cliutils.GetCommandFlags(cliutils.ProxyServe),
+			Description:  proxyserve.Description,
+			HelpName:     corecommon.CreateUsage("rt proxy serve", proxyserve.Description, proxyserve.Usage),
+			UsageText:    proxyserve.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc("serve"),
+			Action: func(c *cli.Context) error {
+				return proxyServeCmd(c)
+			},
+		},
 		{
 			Name:            "curl",
 			Flags:           cliutils.GetCommandFlags(cliutils.RtCurl),
@@ -756,6 +910,180 @@ func GetCommands() []cli.Command {
 				return pipInstallCmd(c)
 			},
 		},
+		{
+			Name:         "pip-publish",
+			Flags:        cliutils.GetCommandFlags(cliutils.PipPublish),
+			Aliases:      []string{"pipp"},
+			Description:  pippublish.Description,
+			HelpName:     corecommon.CreateUsage("rt pipp", pippublish.Description, pippublish.Usage),
+			UsageText:    pippublish.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return pipPublishCmd(c)
+			},
+		},
+		{
+			Name:         "poetry-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.PoetryConfig),
+			Aliases:      []string{"poetryc"},
+			Description:  poetryconfig.Description,
+			HelpName:     corecommon.CreateUsage("rt poetry-config", poetryconfig.Description, poetryconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return createPoetryConfigCmd(c)
+			},
+		},
+		{
+			Name:            "poetry",
+			Flags:           cliutils.GetCommandFlags(cliutils.Poetry),
+			Description:     poetrydocs.Description,
+			HelpName:        corecommon.CreateUsage("rt poetry", poetrydocs.Description, poetrydocs.Usage),
+			UsageText:       poetrydocs.Arguments,
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return poetryCmd(c)
+			},
+		},
+		{
+			Name:         "helm-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.HelmConfig),
+			Aliases:      []string{"helmc"},
+			Description:  helmconfig.Description,
+			HelpName:     corecommon.CreateUsage("rt helm-config", helmconfig.Description, helmconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return createHelmConfigCmd(c)
+			},
+		},
+		{
+			Name:            "helm",
+			Flags:           cliutils.GetCommandFlags(cliutils.Helm),
+			Description:     helmdocs.Description,
+			HelpName:        corecommon.CreateUsage("rt helm", helmdocs.Description, helmdocs.Usage),
+			UsageText:       helmdocs.Arguments,
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return helmCmd(c)
+			},
+		},
+		{
+			Name:         "cargo-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.CargoConfig),
+			Aliases:      []string{"cargoc"},
+			Description:  cargoconfig.Description,
+			HelpName:     corecommon.CreateUsage("rt cargo-config", cargoconfig.Description, cargoconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return createCargoConfigCmd(c)
+			},
+		},
+		{
+			Name:            "cargo",
+			Flags:           cliutils.GetCommandFlags(cliutils.Cargo),
+			Description:     cargodocs.Description,
+			HelpName:        corecommon.CreateUsage("rt cargo", cargodocs.Description, cargodocs.Usage),
+			UsageText:       cargodocs.Arguments,
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return cargoCmd(c)
+			},
+		},
+		{
+			Name:         "conan-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.ConanConfig),
+			Aliases:      []string{"conanc"},
+			Description:  conanconfig.Description,
+			HelpName:     corecommon.CreateUsage("rt conan-config", conanconfig.Description, conanconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return createConanConfigCmd(c)
+			},
+		},
+		{
+			Name:            "conan",
+			Flags:           cliutils.GetCommandFlags(cliutils.Conan),
+			Description:     conandocs.Description,
+			HelpName:        corecommon.CreateUsage("rt conan", conandocs.Description, conandocs.Usage),
+			UsageText:       conandocs.Arguments,
+			ArgsUsage:       common.CreateEnvVars(),
+			SkipFlagParsing: true,
+			BashComplete:    corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return conanCmd(c)
+			},
+		},
+		{
+			Name:         "terraform-config",
+			Flags:        cliutils.GetCommandFlags(cliutils.TerraformConfig),
+			Aliases:      []string{"tfc"},
+			Description:  terraformconfig.Description,
+			HelpName:     corecommon.CreateUsage("rt terraform-config", terraformconfig.Description, terraformconfig.Usage),
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return createTerraformConfigCmd(c)
+			},
+		},
+		{
+			Name:         "terraform",
+			Flags:        cliutils.GetCommandFlags(cliutils.TerraformPublish),
+			Aliases:      []string{"tf"},
+			Description:  terraformpublish.Description,
+			HelpName:     corecommon.CreateUsage("rt terraform publish", terraformpublish.Description, terraformpublish.Usage),
+			UsageText:    terraformpublish.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc("publish"),
+			Action: func(c *cli.Context) error {
+				return terraformPublishCmd(c)
+			},
+		},
+		{
+			Name:         "deb-upload",
+			Flags:        cliutils.GetCommandFlags(cliutils.DebUpload),
+			Description:  debupload.Description,
+			HelpName:     corecommon.CreateUsage("rt deb-upload", debupload.Description, debupload.Usage),
+			UsageText:    debupload.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return linuxPackageUploadCmd(c, linuxpkg.NewDebUploadCommand())
+			},
+		},
+		{
+			Name:         "rpm-upload",
+			Flags:        cliutils.GetCommandFlags(cliutils.RpmUpload),
+			Description:  rpmupload.Description,
+			HelpName:     corecommon.CreateUsage("rt rpm-upload", rpmupload.Description, rpmupload.Usage),
+			UsageText:    rpmupload.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc(),
+			Action: func(c *cli.Context) error {
+				return linuxPackageUploadCmd(c, linuxpkg.NewRpmUploadCommand())
+			},
+		},
+		{
+			Name:         "deps-bundle",
+			Flags:        cliutils.GetCommandFlags(cliutils.DepsBundle),
+			Description:  depsbundledoc.Description,
+			HelpName:     corecommon.CreateUsage("rt deps-bundle", depsbundledoc.Description, depsbundledoc.Usage),
+			UsageText:    depsbundledoc.Arguments,
+			ArgsUsage:    common.CreateEnvVars(),
+			BashComplete: corecommon.CreateBashCompletionFunc("import"),
+			Action: func(c *cli.Context) error {
+				return depsBundleCmd(c)
+			},
+		},
 		{
 			Name:         "release-bundle-create",
 			Flags:        cliutils.GetCommandFlags(cliutils.ReleaseBundleCreate),
@@ -1268,6 +1596,10 @@ func mvnCmd(c *cli.Context) error {
 		if err != nil {
 			return err
 		}
+		filteredMavenArgs, policyCmd, err := extractPolicyFlag(filteredMavenArgs)
+		if err != nil {
+			return err
+		}
 		filteredMavenArgs, threads, err := extractThreadsFlag(filteredMavenArgs)
 		if err != nil {
 			return err
@@ -1276,8 +1608,18 @@ func mvnCmd(c *cli.Context) error {
 		if err != nil {
 			return err
 		}
+		modulesCmd := buildtools.NewModulesCommand(nil).SetConfiguration(buildConfiguration).SetConfigPath(configFilePath)
+		filteredMavenArgs, err = extractModulesFlags(filteredMavenArgs, modulesCmd)
+		if err != nil {
+			return err
+		}
 		mvnCmd := mvn.NewMvnCommand().SetConfiguration(buildConfiguration).SetConfigPath(configFilePath).SetGoals(filteredMavenArgs).SetThreads(threads).SetInsecureTls(insecureTls).SetDetailedSummary(detailedSummary)
-		err = commands.Exec(mvnCmd)
+		modulesCmd.Command = mvnCmd
+		if policyCmd != nil {
+			policyCmd.SetResolveCommand(mvn.NewMvnCommand().SetConfiguration(policyCmd.TempBuildConfiguration()).SetConfigPath(configFilePath).
+				SetGoals(policy.MavenResolveGoals(filteredMavenArgs)).SetInsecureTls(insecureTls))
+		}
+		err = execWithPolicy(modulesCmd, policyCmd)
 		if err != nil {
 			return err
 		}
@@ -1321,8 +1663,14 @@ func gradleCmd(c *cli.Context) error {
 		if err != nil {
 			return err
 		}
+		modulesCmd := buildtools.NewModulesCommand(nil).SetConfiguration(buildConfiguration).SetConfigPath(configFilePath)
+		filteredGradleArgs, err = extractModulesFlags(filteredGradleArgs, modulesCmd)
+		if err != nil {
+			return err
+		}
 		gradleCmd := gradle.NewGradleCommand().SetConfiguration(buildConfiguration).SetTasks(strings.Join(filteredGradleArgs, " ")).SetConfigPath(configFilePath).SetThreads(threads).SetDetailedSummary(detailedSummary)
-		err = commands.Exec(gradleCmd)
+		modulesCmd.Command = gradleCmd
+		err = commands.Exec(modulesCmd)
 		if err != nil {
 			return err
 		}
@@ -1376,7 +1724,7 @@ func dockerPromoteCmd(c *cli.Context) error {
 	params.SourceTag = c.String("source-tag")
 	params.TargetTag = c.String("target-tag")
 	params.Copy = c.Bool("copy")
-	dockerPromoteCommand := container.NewDockerPromoteCommand()
+	dockerPromoteCommand := oci.NewDockerPromoteCommand()
 	dockerPromoteCommand.SetParams(params).SetServerDetails(artDetails)
 
 	return commands.Exec(dockerPromoteCommand)
@@ -1454,7 +1802,7 @@ func BuildDockerCreateCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	buildDockerCreateCommand := container.NewBuildDockerCreateCommand()
+	buildDockerCreateCommand := oci.NewBuildDockerCreateCommand()
 	if err := buildDockerCreateCommand.SetImageNameWithDigest(imageNameWithDigestFile); err != nil {
 		return err
 	}
@@ -1462,6 +1810,77 @@ func BuildDockerCreateCmd(c *cli.Context) error {
 	return commands.Exec(buildDockerCreateCommand)
 }
 
+func proxyServeCmd(c *cli.Context) error {
+	if c.NArg() != 1 || c.Args().Get(0) != "serve" {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	port := 8888
+	if c.String("port") != "" {
+		var err error
+		if port, err = strconv.Atoi(c.String("port")); err != nil {
+			return cliutils.PrintHelpAndReturnError("The '--port' option should have a numeric value.", c)
+		}
+	}
+	artDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := createBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	proxyServeCommand := proxy.NewProxyServeCommand()
+	proxyServeCommand.SetServerDetails(artDetails).SetPort(port).SetBuildConfiguration(buildConfiguration).
+		SetRepo(proxy.Go, c.String("go-repo")).SetRepo(proxy.Npm, c.String("npm-repo")).
+		SetRepo(proxy.Pypi, c.String("pypi-repo")).SetRepo(proxy.Maven, c.String("maven-repo"))
+	return commands.Exec(proxyServeCommand)
+}
+
+func ociPushCmd(c *cli.Context) error {
+	if c.NArg() != 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("image") == "" {
+		return cliutils.PrintHelpAndReturnError("The '--image' command option was not provided.", c)
+	}
+	artDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := createBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	ociPushCommand := oci.NewOciPushCommand()
+	ociPushCommand.SetLayoutDir(c.Args().Get(0)).SetRepo(c.Args().Get(1)).SetImage(c.String("image")).
+		SetServerDetails(artDetails).SetBuildConfiguration(buildConfiguration)
+	return commands.Exec(ociPushCommand)
+}
+
+func buildOciCreateCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("oci-layout") == "" {
+		return cliutils.PrintHelpAndReturnError("The '--oci-layout' command option was not provided.", c)
+	}
+	if c.String("image") == "" {
+		return cliutils.PrintHelpAndReturnError("The '--image' command option was not provided.", c)
+	}
+	artDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := createBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	buildOciCreateCommand := oci.NewBuildOciCreateCommand()
+	buildOciCreateCommand.SetLayoutDir(c.String("oci-layout")).SetRepo(c.Args().Get(0)).SetImage(c.String("image")).
+		SetServerDetails(artDetails).SetBuildConfiguration(buildConfiguration)
+	return commands.Exec(buildOciCreateCommand)
+}
+
 func nugetCmd(c *cli.Context) error {
 	if show, err := showCmdHelpIfNeeded(c); show || err != nil {
 		return err
@@ -1549,7 +1968,7 @@ func nugetDepsTreeCmd(c *cli.Context) error {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
 
-	return dotnet.DependencyTreeCmd()
+	return dotnetdeps.DependencyTreeCmd()
 }
 
 func dotnetCmd(c *cli.Context) error {
@@ -1582,17 +2001,32 @@ func dotnetCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	filteredDotnetArgs, policyCmd, err := extractPolicyFlag(filteredDotnetArgs)
+	if err != nil {
+		return err
+	}
 
-	// Run command.
-	dotnetCmd := dotnet.NewDotnetCoreCliCommand()
-	dotnetCmd.SetServerDetails(rtDetails).SetRepoName(targetRepo).SetBuildConfiguration(buildConfiguration).
+	// Run command. The build-info is collected by the dotnetdeps command, rather than by the command of jfrog-cli-core.
+	dotnetCoreCmd := dotnet.NewDotnetCoreCliCommand()
+	dotnetCoreCmd.SetServerDetails(rtDetails).SetRepoName(targetRepo).SetBuildConfiguration(&utils.BuildConfiguration{}).
 		SetBasicCommand(filteredDotnetArgs[0]).SetUseNugetV2(useNugetV2)
+	dotnetCmd := dotnetdeps.NewDotnetCommand(dotnetCoreCmd).SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration)
 	// Since we are using the values of the command's arguments and flags along the buildInfo collection process,
 	// we want to separate the actual .NET basic command (restore/build...) from the arguments and flags
 	if len(filteredDotnetArgs) > 1 {
+		dotnetCoreCmd.SetArgAndFlags(filteredDotnetArgs[1:])
 		dotnetCmd.SetArgAndFlags(filteredDotnetArgs[1:])
 	}
-	return commands.Exec(dotnetCmd)
+	if policyCmd != nil {
+		// The packages are restored before the command runs, as 'dotnet restore' does.
+		restoreArgs := policy.DotnetRestoreArgs(filteredDotnetArgs[0], filteredDotnetArgs[1:])
+		dotnetRestoreCmd := dotnet.NewDotnetCoreCliCommand()
+		dotnetRestoreCmd.SetServerDetails(rtDetails).SetRepoName(targetRepo).SetBuildConfiguration(&utils.BuildConfiguration{}).
+			SetBasicCommand("restore").SetUseNugetV2(useNugetV2).SetArgAndFlags(restoreArgs)
+		policyCmd.SetResolveCommand(dotnetdeps.NewDotnetCommand(dotnetRestoreCmd).SetServerDetails(rtDetails).
+			SetBuildConfiguration(policyCmd.TempBuildConfiguration()).SetArgAndFlags(restoreArgs))
+	}
+	return execWithPolicy(dotnetCmd, policyCmd)
 }
 
 func getNugetAndDotnetConfigFields(configFilePath string) (rtDetails *coreConfig.ServerDetails, targetRepo string, useNugetV2 bool, err error) {
@@ -1658,8 +2092,34 @@ func npmInstallOrCiCmd(c *cli.Context, npmCmd *npm.NpmInstallOrCiCommand, npmLeg
 		if err := validateCommand(args, cliutils.GetLegacyNpmFlags()); err != nil {
 			return err
 		}
+		// With the --from-lockfile option, the build-info is collected from the lockfile rather than by running 'npm ls'.
+		flagIndex, fromLockfile, err := coreutils.FindBooleanFlag(npmlock.FromLockfileFlag, args)
+		if err != nil {
+			return err
+		}
+		coreutils.RemoveFlagFromCommand(&args, flagIndex, flagIndex)
+		args, policyCmd, err := extractPolicyFlag(args)
+		if err != nil {
+			return err
+		}
+		if policyCmd != nil {
+			// The packages are installed without running their scripts before the command runs,
+			// and the dependencies are read from the lockfile.
+			resolveArgs, err := policyCmd.ResolveArgs(append(args, "--ignore-scripts"))
+			if err != nil {
+				return err
+			}
+			resolveNpmCmd := npm.NewNpmInstallCommand()
+			if npmCmd.CommandName() == npm.NewNpmCiCommand().CommandName() {
+				resolveNpmCmd = npm.NewNpmCiCommand()
+			}
+			policyCmd.SetResolveCommand(npmlock.NewNpmLockfileCommand(resolveNpmCmd).SetConfigFilePath(configFilePath).SetArgs(resolveArgs))
+		}
+		if fromLockfile {
+			return execWithPolicy(npmlock.NewNpmLockfileCommand(npmCmd).SetConfigFilePath(configFilePath).SetArgs(args), policyCmd)
+		}
 		npmCmd.SetConfigFilePath(configFilePath).SetArgs(args)
-		return commands.Exec(npmCmd)
+		return execWithPolicy(npmCmd, policyCmd)
 	}
 	// If config file not found, use Npm legacy command
 	return npmLegacyCommand(c)
@@ -1761,6 +2221,42 @@ func yarnCmd(c *cli.Context) error {
 	return commands.Exec(yarnCmd)
 }
 
+func createPnpmConfigCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	return projectutils.CreateBuildConfig(c, projectutils.Pnpm, true)
+}
+
+func pnpmCmd(c *cli.Context) error {
+	if show, err := showCmdHelpIfNeeded(c); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	resolver, deployer, err := projectutils.GetResolverAndDeployerConfig(projectutils.Pnpm)
+	if err != nil {
+		return err
+	}
+	pnpmCmd := pnpm.NewPnpmCommand().SetArgs(cliutils.ExtractCommand(c))
+	if resolver != nil {
+		rtDetails, err := resolver.ServerDetails()
+		if err != nil {
+			return err
+		}
+		pnpmCmd.SetResolver(rtDetails, resolver.TargetRepo())
+	}
+	if deployer != nil {
+		rtDetails, err := deployer.ServerDetails()
+		if err != nil {
+			return err
+		}
+		pnpmCmd.SetDeployer(rtDetails, deployer.TargetRepo())
+	}
+	return commands.Exec(pnpmCmd)
+}
+
 // This function checks whether the command received --help as a single option.
 // If it did, the command's help is shown and true is returned.
 // This function should be uesd iff the SkipFlagParsing option is used.
@@ -1906,6 +2402,15 @@ func goPublishCmd(c *cli.Context, configFilePath string) error {
 		return err
 	}
 	version := c.Args().Get(0)
+	if c.Bool("workspace") {
+		if c.IsSet("deps") || c.IsSet("module") {
+			return cliutils.PrintHelpAndReturnError("The --deps and --module options can't be used with --workspace.", c)
+		}
+		workspacePublishCmd := goworkspace.NewGoWorkspacePublishCommand().SetConfigFilePath(configFilePath).SetBuildConfiguration(buildConfiguration).SetVersion(version).SetDetailedSummary(c.Bool("detailed-summary"))
+		err = commands.Exec(workspacePublishCmd)
+		result := workspacePublishCmd.Result()
+		return cliutils.PrintDetailedSummaryReport(result.SuccessCount(), result.FailCount(), result.Reader(), true, err)
+	}
 	goPublishCmd := golang.NewGoPublishCommand()
 	goPublishCmd.SetConfigFilePath(configFilePath).SetBuildConfiguration(buildConfiguration).SetVersion(version).SetDependencies(c.String("deps")).SetDetailedSummary(c.Bool("detailed-summary"))
 	err = commands.Exec(goPublishCmd)
@@ -1953,24 +2458,165 @@ func goNativeCmd(c *cli.Context, configFilePath string) error {
 	if err := validateCommand(args, cliutils.GetLegacyGoFlags()); err != nil {
 		return err
 	}
+	args, policyCmd, err := extractPolicyFlag(args)
+	if err != nil {
+		return err
+	}
+	goWorkPath, err := goworkspace.GetGoWorkPath()
+	if err != nil {
+		return err
+	}
+	// The modules are downloaded by 'go mod download' before the command runs.
+	var resolveArgs []string
+	if policyCmd != nil {
+		if resolveArgs, err = policyCmd.ResolveArgs([]string{"mod", "download"}); err != nil {
+			return err
+		}
+	}
+	if goWorkPath != "" {
+		log.Debug("Running in the Go workspace of", goWorkPath)
+		goWorkspaceCmd := goworkspace.NewGoWorkspaceCommand().SetConfigFilePath(configFilePath).SetGoWorkPath(goWorkPath).SetArgs(args)
+		if policyCmd != nil {
+			policyCmd.SetResolveCommand(goworkspace.NewGoWorkspaceCommand().SetConfigFilePath(configFilePath).SetGoWorkPath(goWorkPath).SetArgs(resolveArgs))
+		}
+		return execWithPolicy(goWorkspaceCmd, policyCmd)
+	}
 	goNative := golang.NewGoNativeCommand()
 	goNative.SetConfigFilePath(configFilePath).SetGoArg(args)
-	return commands.Exec(goNative)
+	if policyCmd != nil {
+		goResolve := golang.NewGoNativeCommand()
+		goResolve.SetConfigFilePath(configFilePath).SetGoArg(resolveArgs)
+		policyCmd.SetResolveCommand(goResolve)
+	}
+	return execWithPolicy(goNative, policyCmd)
 }
 
 func createGradleConfigCmd(c *cli.Context) error {
 	if c.NArg() != 0 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
-	return commandUtils.CreateBuildConfig(c, utils.Gradle)
+	if c.Bool("export-init-script") && !isBuildConfigFlagSet(c) {
+		return exportBuildToolConfig(c, utils.Gradle)
+	}
+	if err := commandUtils.CreateBuildConfig(c, utils.Gradle); err != nil {
+		return err
+	}
+	if err := writeModulesConfig(c, utils.Gradle); err != nil {
+		return err
+	}
+	if c.Bool("export-init-script") {
+		return exportBuildToolConfig(c, utils.Gradle)
+	}
+	return nil
 }
 
 func createMvnConfigCmd(c *cli.Context) error {
 	if c.NArg() != 0 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
-	return commandUtils.CreateBuildConfig(c, utils.Maven)
-}
+	if c.Bool("export-settings") && !isBuildConfigFlagSet(c) {
+		return exportBuildToolConfig(c, utils.Maven)
+	}
+	if err := commandUtils.CreateBuildConfig(c, utils.Maven); err != nil {
+		return err
+	}
+	if err := writeModulesConfig(c, utils.Maven); err != nil {
+		return err
+	}
+	if c.Bool("export-settings") {
+		return exportBuildToolConfig(c, utils.Maven)
+	}
+	return nil
+}
+
+// Returns true if any of the resolver and deployer options of the config command is set.
+// Otherwise, the export options of the mvn-config and gradle-config commands export the existing configuration.
+func isBuildConfigFlagSet(c *cli.Context) bool {
+	for _, flagName := range []string{commandUtils.ResolutionServerId, commandUtils.DeploymentServerId, commandUtils.ResolutionRepo, commandUtils.DeploymentRepo,
+		commandUtils.ResolutionReleasesRepo, commandUtils.ResolutionSnapshotsRepo, commandUtils.DeploymentReleasesRepo, commandUtils.DeploymentSnapshotsRepo} {
+		if c.IsSet(flagName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Exports the Maven configuration to a settings.xml, or the Gradle configuration to an init script.
+func exportBuildToolConfig(c *cli.Context, projectType utils.ProjectType) error {
+	configFilePath, exists, err := utils.GetProjectConfFilePath(projectType)
+	if err != nil {
+		return err
+	}
+	configCommand, exportPath := "gradle-config", buildtools.DefaultInitScriptPath
+	if projectType == utils.Maven {
+		configCommand, exportPath = "mvn-config", buildtools.DefaultSettingsPath
+	}
+	if !exists {
+		return errorutils.CheckError(fmt.Errorf("the %s configuration file was not found. Run 'jfrog rt %s' to create it", projectType.String(), configCommand))
+	}
+	if c.String("export-path") != "" {
+		exportPath = c.String("export-path")
+	}
+	if projectType == utils.Maven {
+		return buildtools.ExportMavenSettings(configFilePath, exportPath, c.Bool("include-credentials"))
+	}
+	return buildtools.ExportGradleInitScript(configFilePath, exportPath, c.Bool("include-credentials"))
+}
+
+// Adds the modules section to the Maven or Gradle configuration file, if any of the modules options of the config command is set.
+func writeModulesConfig(c *cli.Context, projectType utils.ProjectType) error {
+	if !c.IsSet("include-modules") && !c.IsSet("exclude-modules") && !c.IsSet("module-props-file") {
+		return nil
+	}
+	projectDir, err := utils.GetProjectDir(c.Bool(commandUtils.Global))
+	if err != nil {
+		return err
+	}
+	configFilePath := filepath.Join(projectDir, projectType.String()+".yaml")
+	return buildtools.WriteModulesConfig(configFilePath, buildtools.NewModulesConfig(c.String("include-modules"), c.String("exclude-modules"), c.String("module-props-file")))
+}
+
+// Removes the --include-modules and --exclude-modules options of the mvn and gradle commands from the arguments,
+// and sets them on the modules command.
+func extractModulesFlags(args []string, modulesCmd *buildtools.ModulesCommand) (cleanArgs []string, err error) {
+	cleanArgs = append([]string(nil), args...)
+	for flag, setter := range map[string]func(string) *buildtools.ModulesCommand{
+		buildtools.IncludeModulesFlag: modulesCmd.SetIncludeModules,
+		buildtools.ExcludeModulesFlag: modulesCmd.SetExcludeModules,
+	} {
+		flagIndex, valueIndex, value, err := coreutils.FindFlag(flag, cleanArgs)
+		if err != nil {
+			return nil, err
+		}
+		if flagIndex < 0 {
+			continue
+		}
+		coreutils.RemoveFlagFromCommand(&cleanArgs, flagIndex, valueIndex)
+		setter(value)
+	}
+	return
+}
+
+// Removes the --policy option of the native commands from the arguments. If it is set, returns the policy command,
+// which checks the dependencies of the command against the policy file before the command runs. The command,
+// which resolves the dependencies, should be set on the policy command.
+func extractPolicyFlag(args []string) (cleanArgs []string, policyCmd *policy.PolicyCommand, err error) {
+	cleanArgs, policyPath, err := policy.ExtractPolicyFromArgs(args)
+	if err != nil || policyPath == "" {
+		return
+	}
+	policyCmd = policy.NewPolicyCommand(nil).SetPolicyPath(policyPath)
+	return
+}
+
+// Runs the command. If the policy command is set, the dependencies of the command are checked against the policy file first.
+func execWithPolicy(command commands.Command, policyCmd *policy.PolicyCommand) error {
+	if policyCmd == nil {
+		return commands.Exec(command)
+	}
+	policyCmd.Command = command
+	return commands.Exec(policyCmd)
+}
 
 func createGoConfigCmd(c *cli.Context) error {
 	if c.NArg() != 0 {
@@ -2011,7 +2657,7 @@ func createPipConfigCmd(c *cli.Context) error {
 	if c.NArg() != 0 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
 	}
-	return commandUtils.CreateBuildConfig(c, utils.Pip)
+	return projectutils.CreateBuildConfig(c, utils.Pip.String(), true)
 }
 
 func pingCmd(c *cli.Context) error {
@@ -2455,6 +3101,9 @@ func buildPublishCmd(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.String("schema") != "" || c.String("hooks") != "" {
+		return validatedBuildPublishCmd(c, rtDetails, buildConfiguration, buildInfoConfiguration)
+	}
 	buildPublishCmd := buildinfo.NewBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).SetDetailedSummary(c.Bool("detailed-summary"))
 
 	err = commands.Exec(buildPublishCmd)
@@ -2466,6 +3115,23 @@ func buildPublishCmd(c *cli.Context) error {
 	return err
 }
 
+func validatedBuildPublishCmd(c *cli.Context, rtDetails *coreConfig.ServerDetails, buildConfiguration *utils.BuildConfiguration, buildInfoConfiguration *buildinfocmd.Configuration) error {
+	var hooks []string
+	if c.String("hooks") != "" {
+		hooks = strings.Split(c.String("hooks"), ";")
+	}
+	buildPublishCmd := buildinfocommands.NewValidatedBuildPublishCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).SetConfig(buildInfoConfiguration).
+		SetSchemaPath(c.String("schema")).SetHooks(hooks).SetDetailedSummary(c.Bool("detailed-summary"))
+
+	err := commands.Exec(buildPublishCmd)
+	if buildPublishCmd.IsDetailedSummary() {
+		if summary := buildPublishCmd.GetSummary(); summary != nil {
+			return cliutils.PrintBuildInfoSummaryReport(summary.IsSucceeded(), summary.GetSha256(), err)
+		}
+	}
+	return err
+}
+
 func buildAppendCmd(c *cli.Context) error {
 	if c.NArg() != 4 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -2483,6 +3149,25 @@ func buildAppendCmd(c *cli.Context) error {
 	return commands.Exec(buildAppendCmd)
 }
 
+func buildGraphCmd(c *cli.Context) error {
+	if c.NArg() > 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	buildConfiguration := createBuildConfiguration(c)
+	if err := validateBuildConfiguration(c, buildConfiguration); err != nil {
+		return err
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	buildGraphCmd := buildinfocommands.NewBuildGraphCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration)
+	if err = buildGraphCmd.SetFormat(c.String("format")); err != nil {
+		return err
+	}
+	return commands.Exec(buildGraphCmd)
+}
+
 func buildAddDependenciesCmd(c *cli.Context) error {
 	if c.NArg() > 2 && c.IsSet("spec") {
 		return cliutils.PrintHelpAndReturnError("Only path or spec is allowed, not both.", c)
@@ -2530,6 +3215,25 @@ func buildAddDependenciesCmd(c *cli.Context) error {
 	return cliutils.GetCliError(err, result.SuccessCount(), result.FailCount(), isFailNoOp(c))
 }
 
+func buildTraceCmd(c *cli.Context) error {
+	if c.NArg() < 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("input-dirs") == "" && c.String("output-dirs") == "" {
+		return cliutils.PrintHelpAndReturnError("At least one of the --input-dirs and --output-dirs options is expected.", c)
+	}
+	buildConfiguration, err := createBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	if err := validateBuildConfiguration(c, buildConfiguration); err != nil {
+		return err
+	}
+	buildTraceCmd := buildinfocommands.NewBuildTraceCommand().SetBuildConfiguration(buildConfiguration).SetArgs(c.Args()).
+		SetInputDirs(strings.Split(c.String("input-dirs"), ";")).SetOutputDirs(strings.Split(c.String("output-dirs"), ";"))
+	return commands.Exec(buildTraceCmd)
+}
+
 func buildCollectEnvCmd(c *cli.Context) error {
 	if c.NArg() > 2 {
 		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
@@ -2552,7 +3256,7 @@ func buildAddGitCmd(c *cli.Context) error {
 		return err
 	}
 
-	buildAddGitConfigurationCmd := buildinfo.NewBuildAddGitCommand().SetBuildConfiguration(buildConfiguration).SetConfigFilePath(c.String("config")).SetServerId(c.String("server-id"))
+	buildAddGitConfigurationCmd := buildinfocommands.NewBuildAddGitCommand().SetBuildConfiguration(buildConfiguration).SetConfigFilePath(c.String("config")).SetServerId(c.String("server-id"))
 	if c.NArg() == 3 {
 		buildAddGitConfigurationCmd.SetDotGitPath(c.Args().Get(2))
 	} else if c.NArg() == 1 {
@@ -2646,12 +3350,12 @@ func buildDiscardCmd(c *cli.Context) error {
 	if configuration.BuildName == "" {
 		return cliutils.PrintHelpAndReturnError("Build name is expected as a command argument or environment variable.", c)
 	}
-	buildDiscardCmd := buildinfo.NewBuildDiscardCommand()
+	buildDiscardCmd := buildinfocommands.NewBuildDiscardCommand()
 	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
 	if err != nil {
 		return err
 	}
-	buildDiscardCmd.SetServerDetails(rtDetails).SetDiscardBuildsParams(configuration)
+	buildDiscardCmd.SetServerDetails(rtDetails).SetDiscardBuildsParams(configuration).SetDryRun(c.Bool("dry-run")).SetReportPath(c.String("report"))
 
 	return commands.Exec(buildDiscardCmd)
 }
@@ -2893,10 +3597,370 @@ func pipInstallCmd(c *cli.Context) error {
 		return err
 	}
 
+	args, policyCmd, err := extractPolicyFlag(cliutils.ExtractCommand(c))
+	if err != nil {
+		return err
+	}
+	if policyCmd != nil {
+		// The distributions are resolved by a dry run of pip install before the command runs.
+		resolveArgs, err := policyCmd.ResolveArgs(args)
+		if err != nil {
+			return err
+		}
+		policyCmd.SetResolveCommand(python.NewPipInstallCommand().SetServerDetails(rtDetails).SetRepo(pipConfig.TargetRepo()).SetArgs(resolveArgs).SetDryRun(true))
+	}
+
 	// Run command.
-	pipCmd := pip.NewPipInstallCommand()
-	pipCmd.SetServerDetails(rtDetails).SetRepo(pipConfig.TargetRepo()).SetArgs(cliutils.ExtractCommand(c))
-	return commands.Exec(pipCmd)
+	pipCmd := python.NewPipInstallCommand().SetServerDetails(rtDetails).SetRepo(pipConfig.TargetRepo()).SetArgs(args)
+	return execWithPolicy(pipCmd, policyCmd)
+}
+
+func pipPublishCmd(c *cli.Context) error {
+	if c.NArg() > 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	buildConfiguration, err := createBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	deployerConfig, err := projectutils.GetDeployerConfig(utils.Pip.String())
+	if err != nil {
+		return errors.New(fmt.Sprintf("Error occurred while attempting to read pip-configuration file: %s\n"+
+			"Please run 'jfrog rt pip-config' command with the deployer options prior to running 'jfrog rt %s'.", err.Error(), "pip-publish"))
+	}
+	rtDetails, err := deployerConfig.ServerDetails()
+	if err != nil {
+		return err
+	}
+	pipPublishCmd := python.NewPipPublishCommand().SetServerDetails(rtDetails).SetRepo(deployerConfig.TargetRepo()).
+		SetDistDir(c.Args().Get(0)).SetBuildConfiguration(buildConfiguration)
+	return commands.Exec(pipPublishCmd)
+}
+
+func createPoetryConfigCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	return projectutils.CreateBuildConfig(c, projectutils.Poetry, false)
+}
+
+func poetryCmd(c *cli.Context) error {
+	if show, err := showCmdHelpIfNeeded(c); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	poetryConfig, err := projectutils.GetResolverConfig(projectutils.Poetry)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := poetryConfig.ServerDetails()
+	if err != nil {
+		return err
+	}
+	poetryCmd := python.NewPoetryCommand().SetServerDetails(rtDetails).SetRepo(poetryConfig.TargetRepo()).SetArgs(cliutils.ExtractCommand(c))
+	return commands.Exec(poetryCmd)
+}
+
+func createHelmConfigCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	return projectutils.CreateBuildConfig(c, projectutils.Helm, true)
+}
+
+func helmCmd(c *cli.Context) error {
+	if show, err := showCmdHelpIfNeeded(c); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	resolver, deployer, err := projectutils.GetResolverAndDeployerConfig(projectutils.Helm)
+	if err != nil {
+		return err
+	}
+	helmCmd := helm.NewHelmCommand().SetArgs(cliutils.ExtractCommand(c))
+	if resolver != nil {
+		rtDetails, err := resolver.ServerDetails()
+		if err != nil {
+			return err
+		}
+		helmCmd.SetResolver(rtDetails, resolver.TargetRepo())
+	}
+	if deployer != nil {
+		rtDetails, err := deployer.ServerDetails()
+		if err != nil {
+			return err
+		}
+		helmCmd.SetDeployer(rtDetails, deployer.TargetRepo())
+	}
+	return commands.Exec(helmCmd)
+}
+
+func createCargoConfigCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if !c.Bool("export-cargo-config") || isBuildConfigFlagSet(c) {
+		if err := projectutils.CreateBuildConfig(c, projectutils.Cargo, true); err != nil {
+			return err
+		}
+	}
+	if !c.Bool("export-cargo-config") {
+		return nil
+	}
+	cargoCmd, err := createCargoCommand()
+	if err != nil {
+		return err
+	}
+	exportPath := cargo.DefaultConfigPath
+	if c.String("export-path") != "" {
+		exportPath = c.String("export-path")
+	}
+	return cargoCmd.ExportConfig(exportPath)
+}
+
+func cargoCmd(c *cli.Context) error {
+	if show, err := showCmdHelpIfNeeded(c); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	cargoCmd, err := createCargoCommand()
+	if err != nil {
+		return err
+	}
+	return commands.Exec(cargoCmd.SetArgs(cliutils.ExtractCommand(c)))
+}
+
+// Returns a cargo command with the resolver and deployer of the Cargo configuration.
+func createCargoCommand() (*cargo.CargoCommand, error) {
+	resolver, deployer, err := projectutils.GetResolverAndDeployerConfig(projectutils.Cargo)
+	if err != nil {
+		return nil, err
+	}
+	cargoCmd := cargo.NewCargoCommand()
+	if resolver != nil {
+		rtDetails, err := resolver.ServerDetails()
+		if err != nil {
+			return nil, err
+		}
+		cargoCmd.SetResolver(rtDetails, resolver.TargetRepo())
+	}
+	if deployer != nil {
+		rtDetails, err := deployer.ServerDetails()
+		if err != nil {
+			return nil, err
+		}
+		cargoCmd.SetDeployer(rtDetails, deployer.TargetRepo())
+	}
+	return cargoCmd, nil
+}
+
+func createConanConfigCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	return projectutils.CreateBuildConfig(c, projectutils.Conan, true)
+}
+
+func conanCmd(c *cli.Context) error {
+	if show, err := showCmdHelpIfNeeded(c); show || err != nil {
+		return err
+	}
+	if c.NArg() < 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	resolver, deployer, err := projectutils.GetResolverAndDeployerConfig(projectutils.Conan)
+	if err != nil {
+		return err
+	}
+	conanCmd := conan.NewConanCommand().SetArgs(cliutils.ExtractCommand(c))
+	if resolver != nil {
+		rtDetails, err := resolver.ServerDetails()
+		if err != nil {
+			return err
+		}
+		conanCmd.SetResolver(rtDetails, resolver.TargetRepo())
+	}
+	if deployer != nil {
+		rtDetails, err := deployer.ServerDetails()
+		if err != nil {
+			return err
+		}
+		conanCmd.SetDeployer(rtDetails, deployer.TargetRepo())
+	}
+	return commands.Exec(conanCmd)
+}
+
+func createTerraformConfigCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if err := projectutils.CreateBuildConfig(c, projectutils.Terraform, true); err != nil {
+		return err
+	}
+	if c.Bool("no-terraformrc") {
+		return nil
+	}
+	// The registry is the resolution repository, or the deployment repository if no resolution repository is configured.
+	projectDir, err := utils.GetProjectDir(c.Bool("global"))
+	if err != nil {
+		return err
+	}
+	configFilePath := filepath.Join(projectDir, projectutils.Terraform+".yaml")
+	vConfig, err := utils.ReadConfigFile(configFilePath, utils.YAML)
+	if err != nil {
+		return err
+	}
+	prefix := utils.ProjectConfigResolverPrefix
+	if !vConfig.IsSet(prefix) {
+		prefix = utils.ProjectConfigDeployerPrefix
+	}
+	if !vConfig.IsSet(prefix) {
+		return nil
+	}
+	repoConfig, err := utils.GetRepoConfigByPrefix(configFilePath, prefix, vConfig)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := repoConfig.ServerDetails()
+	if err != nil {
+		return err
+	}
+	terraformrcPath := c.String("terraformrc")
+	if terraformrcPath == "" {
+		if terraformrcPath, err = terraform.GetTerraformrcPath(); err != nil {
+			return err
+		}
+	}
+	host, err := terraform.WriteTerraformrc(terraformrcPath, rtDetails)
+	if err != nil {
+		return err
+	}
+	log.Info("The Artifactory registry was written to " + terraformrcPath + ". Modules are referenced as " + host + "/" + repoConfig.TargetRepo() + "__<namespace>/<name>/<provider>.")
+	return nil
+}
+
+func terraformPublishCmd(c *cli.Context) error {
+	if c.NArg() < 1 || c.NArg() > 2 || c.Args().Get(0) != "publish" {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	for _, flag := range []string{"namespace", "provider", "tag"} {
+		if c.String(flag) == "" {
+			return cliutils.PrintHelpAndReturnError("The '--"+flag+"' option is mandatory.", c)
+		}
+	}
+	deployer, err := projectutils.GetDeployerConfig(projectutils.Terraform)
+	if err != nil {
+		return err
+	}
+	rtDetails, err := deployer.ServerDetails()
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := createBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	terraformCmd := terraform.NewTerraformPublishCommand().SetServerDetails(rtDetails).SetRepo(deployer.TargetRepo()).
+		SetModuleDir(c.Args().Get(1)).SetNamespace(c.String("namespace")).SetProvider(c.String("provider")).SetTag(c.String("tag")).
+		SetExclusions(cliutils.GetStringsArrFlagValue(c, "exclusions")).SetBuildConfiguration(buildConfiguration)
+	return commands.Exec(terraformCmd)
+}
+
+func setupCmd(c *cli.Context) error {
+	if c.NArg() != 0 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("server-id") == "" {
+		return cliutils.PrintHelpAndReturnError("The '--server-id' option is mandatory.", c)
+	}
+	setupCmd := projectsetup.NewSetupCommand().SetServerId(c.String("server-id")).SetMappingPath(c.String("mapping")).
+		SetOverwrite(c.Bool("overwrite")).SetDryRun(c.Bool("dry-run"))
+	return commands.Exec(setupCmd)
+}
+
+func depsBundleCmd(c *cli.Context) error {
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	if c.Args().Get(0) == "import" {
+		if c.NArg() != 2 {
+			return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+		}
+		if c.String("repos") == "" {
+			return cliutils.PrintHelpAndReturnError("The '--repos' option is mandatory.", c)
+		}
+		repos, err := depsbundle.ParseRepos(c.String("repos"))
+		if err != nil {
+			return err
+		}
+		importCmd := depsbundle.NewDepsBundleImportCommand().SetServerDetails(rtDetails).SetBundlePath(c.Args().Get(1)).
+			SetRepos(repos).SetDryRun(c.Bool("dry-run"))
+		return commands.Exec(importCmd)
+	}
+	if c.NArg() > 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	buildConfiguration, err := createBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	if buildConfiguration.BuildName == "" || buildConfiguration.BuildNumber == "" {
+		return cliutils.PrintHelpAndReturnError("The '--build-name' and '--build-number' options are mandatory.", c)
+	}
+	bundleCmd := depsbundle.NewDepsBundleCommand().SetServerDetails(rtDetails).SetBuildConfiguration(buildConfiguration).
+		SetBundlePath(c.Args().Get(0)).SetLocal(c.Bool("local"))
+	return commands.Exec(bundleCmd)
+}
+
+func mvnDeployFileCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if c.String("file") == "" {
+		return cliutils.PrintHelpAndReturnError("The '--file' option is mandatory.", c)
+	}
+	if c.String("gav") == "" && c.String("pom") == "" {
+		return cliutils.PrintHelpAndReturnError("Either the '--gav' or the '--pom' option is mandatory.", c)
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := createBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	deployCmd := mvndeploy.NewMvnDeployFileCommand().SetServerDetails(rtDetails).SetRepo(c.Args().Get(0)).
+		SetFilePath(c.String("file")).SetGav(c.String("gav")).SetPomPath(c.String("pom")).SetBuildConfiguration(buildConfiguration)
+	return commands.Exec(deployCmd)
+}
+
+func linuxPackageUploadCmd(c *cli.Context, uploadCmd *linuxpkg.PackageUploadCommand) error {
+	if c.NArg() != 2 {
+		return cliutils.PrintHelpAndReturnError("Wrong number of arguments.", c)
+	}
+	if uploadCmd.CommandName() == "rt_deb_upload" && c.String("distribution") == "" {
+		return cliutils.PrintHelpAndReturnError("The '--distribution' option is mandatory.", c)
+	}
+	rtDetails, err := createArtifactoryDetailsByFlags(c, false)
+	if err != nil {
+		return err
+	}
+	buildConfiguration, err := createBuildConfigurationWithModule(c)
+	if err != nil {
+		return err
+	}
+	uploadCmd.SetServerDetails(rtDetails).SetPackagePath(c.Args().Get(0)).SetTarget(c.Args().Get(1)).
+		SetDistribution(c.String("distribution")).SetComponent(c.String("component")).SetArchitecture(c.String("architecture")).
+		SetDryRun(c.Bool("dry-run")).SetBuildConfiguration(buildConfiguration)
+	return commands.Exec(uploadCmd)
 }
 
 func repoTemplateCmd(c *cli.Context) error {
@@ -3508,6 +4572,7 @@ func createBuildDiscardConfiguration(c *cli.Context) services.DiscardBuildsParam
 	discardParamsImpl.ExcludeBuilds = c.String("exclude-builds")
 	discardParamsImpl.Async = c.Bool("async")
 	discardParamsImpl.BuildName = cliutils.GetBuildName(c.Args().Get(0))
+	discardParamsImpl.ProjectKey = utils.GetBuildProject(c.String("project"))
 	return discardParamsImpl
 }
 
@@ -171,3 +171,21 @@ func createFlagSet(flags []string, args []string) *flag.FlagSet {
 
 	return flagSet
 }
+
+func TestExtractPolicyFlag(t *testing.T) {
+	args, policyCmd, err := extractPolicyFlag([]string{"install", "--policy=policy.yaml", "--build-name=a", "--build-number=1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"install", "--build-name=a", "--build-number=1"}, args)
+	if assert.NotNil(t, policyCmd) {
+		// The dependencies are resolved to the temporary build, rather than to the build of the command.
+		resolveArgs, err := policyCmd.ResolveArgs(args)
+		assert.NoError(t, err)
+		tempBuild := policyCmd.TempBuildConfiguration()
+		assert.Equal(t, []string{"install", "--build-name=" + tempBuild.BuildName, "--build-number=" + tempBuild.BuildNumber}, resolveArgs)
+		assert.Equal(t, []string{"install", "--build-name=a", "--build-number=1"}, args)
+	}
+
+	_, policyCmd, err = extractPolicyFlag([]string{"install"})
+	assert.NoError(t, err)
+	assert.Nil(t, policyCmd)
+}
@@ -0,0 +1,338 @@
+package buildinfo
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	corebuildinfo "github.com/jfrog/jfrog-cli-core/artifactory/commands/buildinfo"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The changelog stops after this number of commits, for example when the previous revision is not in the repository.
+	maxChangelogCommits     = 500
+	changelogPropertyPrefix = "vcs."
+)
+
+// Adds the git details to the build-info, as the build-add-git command of jfrog-cli-core does.
+// On top of these details, a changelog is recorded in the build properties, such as "vcs.commits.0.revision", since the
+// VCS entries of the build-info have no changelog fields. The changelog holds the commits since the revision of the
+// previous published build with the same name, the revisions of the submodules, and whether the working tree has
+// uncommitted changes.
+type BuildAddGitCommand struct {
+	*corebuildinfo.BuildAddGitCommand
+	buildConfiguration *utils.BuildConfiguration
+	dotGitPath         string
+}
+
+func NewBuildAddGitCommand() *BuildAddGitCommand {
+	return &BuildAddGitCommand{BuildAddGitCommand: corebuildinfo.NewBuildAddGitCommand()}
+}
+
+func (bagc *BuildAddGitCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildAddGitCommand {
+	bagc.buildConfiguration = buildConfiguration
+	bagc.BuildAddGitCommand.SetBuildConfiguration(buildConfiguration)
+	return bagc
+}
+
+func (bagc *BuildAddGitCommand) SetDotGitPath(dotGitPath string) *BuildAddGitCommand {
+	bagc.dotGitPath = dotGitPath
+	bagc.BuildAddGitCommand.SetDotGitPath(dotGitPath)
+	return bagc
+}
+
+func (bagc *BuildAddGitCommand) SetConfigFilePath(configFilePath string) *BuildAddGitCommand {
+	bagc.BuildAddGitCommand.SetConfigFilePath(configFilePath)
+	return bagc
+}
+
+func (bagc *BuildAddGitCommand) SetServerId(serverId string) *BuildAddGitCommand {
+	bagc.BuildAddGitCommand.SetServerId(serverId)
+	return bagc
+}
+
+func (bagc *BuildAddGitCommand) Run() error {
+	if err := bagc.BuildAddGitCommand.Run(); err != nil {
+		return err
+	}
+	if bagc.dotGitPath == "" {
+		var exists bool
+		var err error
+		bagc.dotGitPath, exists, err = fileutils.FindUpstream(".git", fileutils.Any)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return errorutils.CheckError(errors.New("Could not find .git"))
+		}
+	}
+	gitManager := clientutils.NewGitManager(bagc.dotGitPath)
+	if err := gitManager.ReadConfig(); err != nil {
+		return err
+	}
+
+	log.Info("Collecting the git changelog...")
+	changelog, err := CollectGitChangelog(bagc.dotGitPath, bagc.getPreviousRevision(gitManager.GetUrl()))
+	if err != nil {
+		return err
+	}
+	log.Info("Adding", strconv.Itoa(len(changelog.Commits)), "commits to the build-info changelog.")
+	populateFunc := func(partial *buildinfo.Partial) {
+		partial.Env = changelog.toProperties()
+	}
+	return utils.SavePartialBuildInfo(bagc.buildConfiguration.BuildName, bagc.buildConfiguration.BuildNumber, bagc.buildConfiguration.Project, populateFunc)
+}
+
+// Returns the revision recorded for the VCS URL by the latest published build with the same name,
+// or an empty string if it can't be found.
+func (bagc *BuildAddGitCommand) getPreviousRevision(vcsUrl string) string {
+	serverDetails, err := bagc.ServerDetails()
+	if err != nil || serverDetails == nil || serverDetails.ArtifactoryUrl == "" {
+		log.Info("No Artifactory server is configured. The changelog will include the current commit only.")
+		return ""
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, false)
+	if err != nil {
+		log.Warn("Couldn't get the previous build from Artifactory:", err.Error())
+		return ""
+	}
+	params := services.BuildInfoParams{BuildName: bagc.buildConfiguration.BuildName, BuildNumber: "LATEST", ProjectKey: bagc.buildConfiguration.Project}
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(params)
+	if err != nil {
+		log.Warn("Couldn't get the previous build from Artifactory:", err.Error())
+		return ""
+	}
+	if !found {
+		log.Info("No previous build of", bagc.buildConfiguration.BuildName, "was found. The changelog will include the current commit only.")
+		return ""
+	}
+	for _, vcs := range publishedBuildInfo.BuildInfo.VcsList {
+		if vcs.Url == vcsUrl {
+			log.Debug("The previous build", publishedBuildInfo.BuildInfo.Number, "was built from revision", vcs.Revision)
+			return vcs.Revision
+		}
+	}
+	log.Info("The previous build", publishedBuildInfo.BuildInfo.Number, "has no revision of", vcsUrl+". The changelog will include the current commit only.")
+	return ""
+}
+
+type GitCommit struct {
+	Revision     string
+	Author       string
+	Email        string
+	Date         time.Time
+	Message      string
+	ChangedFiles []string
+}
+
+type GitSubmodule struct {
+	Path     string
+	Url      string
+	Revision string
+	// The revision checked out in the submodule working tree, if it differs from the revision recorded in the parent repository.
+	CheckedOutRevision string
+}
+
+type GitChangelog struct {
+	PreviousRevision string
+	Commits          []GitCommit
+	Submodules       []GitSubmodule
+	Dirty            bool
+}
+
+// Collects the commits reachable from HEAD but not from the previous revision, as "git log <previous>..HEAD" does.
+// If the previous revision is empty, only the HEAD commit is collected.
+func CollectGitChangelog(repoPath, previousRevision string) (*GitChangelog, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	changelog := &GitChangelog{PreviousRevision: previousRevision}
+	if changelog.Commits, err = collectCommits(repo, head.Hash(), previousRevision); err != nil {
+		return nil, err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	changelog.Dirty = !status.IsClean()
+	if changelog.Submodules, err = collectSubmodules(worktree); err != nil {
+		return nil, err
+	}
+	return changelog, nil
+}
+
+func collectCommits(repo *git.Repository, head plumbing.Hash, previousRevision string) ([]GitCommit, error) {
+	headCommit, err := repo.CommitObject(head)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if previousRevision == "" {
+		commit, err := toGitCommit(headCommit)
+		if err != nil {
+			return nil, err
+		}
+		return []GitCommit{commit}, nil
+	}
+	excluded, err := getAncestors(repo, previousRevision)
+	if err != nil {
+		return nil, err
+	}
+	var commits []GitCommit
+	err = object.NewCommitPreorderIter(headCommit, excluded, nil).ForEach(func(commit *object.Commit) error {
+		if len(commits) == maxChangelogCommits {
+			return storer.ErrStop
+		}
+		gitCommit, err := toGitCommit(commit)
+		if err != nil {
+			return err
+		}
+		commits = append(commits, gitCommit)
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if len(excluded) == 0 {
+		log.Warn("The previous revision", previousRevision, "was not found in the repository. The changelog includes the latest", strconv.Itoa(len(commits)), "commits.")
+	}
+	return commits, nil
+}
+
+// Returns the revision and its ancestors, or an empty map if the revision is not in the repository.
+func getAncestors(repo *git.Repository, revision string) (map[plumbing.Hash]bool, error) {
+	ancestors := make(map[plumbing.Hash]bool)
+	commit, err := repo.CommitObject(plumbing.NewHash(revision))
+	if err == plumbing.ErrObjectNotFound {
+		return ancestors, nil
+	}
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	err = object.NewCommitPreorderIter(commit, nil, nil).ForEach(func(ancestor *object.Commit) error {
+		ancestors[ancestor.Hash] = true
+		return nil
+	})
+	return ancestors, errorutils.CheckError(err)
+}
+
+func toGitCommit(commit *object.Commit) (GitCommit, error) {
+	changedFiles, err := getChangedFiles(commit)
+	if err != nil {
+		return GitCommit{}, errorutils.CheckError(err)
+	}
+	return GitCommit{
+		Revision:     commit.Hash.String(),
+		Author:       commit.Author.Name,
+		Email:        commit.Author.Email,
+		Date:         commit.Author.When,
+		Message:      strings.TrimSpace(commit.Message),
+		ChangedFiles: changedFiles,
+	}, nil
+}
+
+// Returns the files changed by the commit, compared to its first parent.
+func getChangedFiles(commit *object.Commit) ([]string, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	if commit.NumParents() == 0 {
+		err = tree.Files().ForEach(func(file *object.File) error {
+			files = append(files, file.Name)
+			return nil
+		})
+		sort.Strings(files)
+		return files, err
+	}
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return nil, err
+	}
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func collectSubmodules(worktree *git.Worktree) ([]GitSubmodule, error) {
+	submodules, err := worktree.Submodules()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var result []GitSubmodule
+	for _, submodule := range submodules {
+		status, err := submodule.Status()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		gitSubmodule := GitSubmodule{Path: submodule.Config().Path, Url: submodule.Config().URL, Revision: status.Expected.String()}
+		if !status.Current.IsZero() && status.Current != status.Expected {
+			gitSubmodule.CheckedOutRevision = status.Current.String()
+		}
+		result = append(result, gitSubmodule)
+	}
+	return result, nil
+}
+
+// Converts the changelog to build properties, such as "vcs.commits.0.revision".
+func (changelog *GitChangelog) toProperties() map[string]string {
+	properties := map[string]string{changelogPropertyPrefix + "dirty": strconv.FormatBool(changelog.Dirty)}
+	if changelog.PreviousRevision != "" {
+		properties[changelogPropertyPrefix+"previousRevision"] = changelog.PreviousRevision
+	}
+	for i, commit := range changelog.Commits {
+		prefix := changelogPropertyPrefix + "commits." + strconv.Itoa(i) + "."
+		properties[prefix+"revision"] = commit.Revision
+		properties[prefix+"author"] = commit.Author
+		properties[prefix+"email"] = commit.Email
+		properties[prefix+"date"] = commit.Date.Format(buildinfo.TimeFormat)
+		properties[prefix+"message"] = commit.Message
+		properties[prefix+"changedFiles"] = strings.Join(commit.ChangedFiles, ",")
+	}
+	for i, submodule := range changelog.Submodules {
+		prefix := changelogPropertyPrefix + "submodules." + strconv.Itoa(i) + "."
+		properties[prefix+"path"] = submodule.Path
+		properties[prefix+"url"] = submodule.Url
+		properties[prefix+"revision"] = submodule.Revision
+		if submodule.CheckedOutRevision != "" {
+			properties[prefix+"checkedOutRevision"] = submodule.CheckedOutRevision
+		}
+	}
+	return properties
+}
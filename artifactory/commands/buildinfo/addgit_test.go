@@ -0,0 +1,121 @@
+package buildinfo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestGitRepo(t *testing.T) (repoPath string, revisions []string) {
+	repoPath, err := ioutil.TempDir("", "changelog")
+	assert.NoError(t, err)
+	repo, err := git.PlainInit(repoPath, false)
+	assert.NoError(t, err)
+	worktree, err := repo.Worktree()
+	assert.NoError(t, err)
+
+	commit := func(message string, files ...string) {
+		for _, file := range files {
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(repoPath, file), []byte(message), 0644))
+			_, err := worktree.Add(file)
+			assert.NoError(t, err)
+		}
+		signature := &object.Signature{Name: "Dev", Email: "dev@example.com", When: time.Date(2021, 6, len(revisions)+1, 0, 0, 0, 0, time.UTC)}
+		hash, err := worktree.Commit(message, &git.CommitOptions{Author: signature, Committer: signature})
+		assert.NoError(t, err)
+		revisions = append(revisions, hash.String())
+	}
+	commit("Initial commit", "a.txt", "b.txt")
+	commit("Change a", "a.txt")
+	commit("Change b and add c", "b.txt", "c.txt")
+	return repoPath, revisions
+}
+
+func TestCollectGitChangelog(t *testing.T) {
+	repoPath, revisions := createTestGitRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	changelog, err := CollectGitChangelog(repoPath, revisions[0])
+	assert.NoError(t, err)
+	assert.False(t, changelog.Dirty)
+	assert.Len(t, changelog.Commits, 2)
+	assert.Equal(t, revisions[2], changelog.Commits[0].Revision)
+	assert.Equal(t, "Change b and add c", changelog.Commits[0].Message)
+	assert.Equal(t, []string{"b.txt", "c.txt"}, changelog.Commits[0].ChangedFiles)
+	assert.Equal(t, "Dev", changelog.Commits[1].Author)
+	assert.Equal(t, "dev@example.com", changelog.Commits[1].Email)
+	assert.Equal(t, []string{"a.txt"}, changelog.Commits[1].ChangedFiles)
+
+	properties := changelog.toProperties()
+	assert.Equal(t, revisions[0], properties["vcs.previousRevision"])
+	assert.Equal(t, "b.txt,c.txt", properties["vcs.commits.0.changedFiles"])
+	assert.Equal(t, "false", properties["vcs.dirty"])
+
+	// Without a previous revision, only the current commit is collected.
+	changelog, err = CollectGitChangelog(repoPath, "")
+	assert.NoError(t, err)
+	assert.Len(t, changelog.Commits, 1)
+
+	// The previous build was built from the current revision.
+	changelog, err = CollectGitChangelog(repoPath, revisions[2])
+	assert.NoError(t, err)
+	assert.Empty(t, changelog.Commits)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(repoPath, "a.txt"), []byte("uncommitted"), 0644))
+	changelog, err = CollectGitChangelog(repoPath, revisions[2])
+	assert.NoError(t, err)
+	assert.True(t, changelog.Dirty)
+}
+
+func TestCollectGitChangelogRootCommit(t *testing.T) {
+	repoPath, revisions := createTestGitRepo(t)
+	defer os.RemoveAll(repoPath)
+
+	// An unknown previous revision, for example after a force push, collects the whole history.
+	changelog, err := CollectGitChangelog(repoPath, "0123456789012345678901234567890123456789")
+	assert.NoError(t, err)
+	assert.Len(t, changelog.Commits, len(revisions))
+	assert.Equal(t, []string{"a.txt", "b.txt"}, changelog.Commits[2].ChangedFiles)
+}
+
+func TestCollectGitChangelogMerge(t *testing.T) {
+	repoPath, revisions := createTestGitRepo(t)
+	defer os.RemoveAll(repoPath)
+	repo, err := git.PlainOpen(repoPath)
+	assert.NoError(t, err)
+	worktree, err := repo.Worktree()
+	assert.NoError(t, err)
+
+	commit := func(message string, day int, parents ...string) string {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(repoPath, "d.txt"), []byte(message), 0644))
+		_, err := worktree.Add("d.txt")
+		assert.NoError(t, err)
+		signature := &object.Signature{Name: "Dev", Email: "dev@example.com", When: time.Date(2021, 7, day, 0, 0, 0, 0, time.UTC)}
+		options := &git.CommitOptions{Author: signature, Committer: signature}
+		for _, parent := range parents {
+			options.Parents = append(options.Parents, plumbing.NewHash(parent))
+		}
+		hash, err := worktree.Commit(message, options)
+		assert.NoError(t, err)
+		return hash.String()
+	}
+	// A side branch commit, older than the previous build revision, is merged after that build.
+	side := commit("Side change", 1, revisions[2])
+	previous := commit("Previous build", 2, revisions[2])
+	merge := commit("Merge side", 3, previous, side)
+
+	changelog, err := CollectGitChangelog(repoPath, previous)
+	assert.NoError(t, err)
+	var collected []string
+	for _, gitCommit := range changelog.Commits {
+		collected = append(collected, gitCommit.Revision)
+	}
+	assert.ElementsMatch(t, []string{merge, side}, collected)
+}
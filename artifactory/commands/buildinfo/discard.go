@@ -0,0 +1,339 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Discards builds by the retention parameters. Before the builds are discarded, the command can preview
+// the builds which match the retention parameters, and write them to a JSON report.
+type BuildDiscardCommand struct {
+	serverDetails *config.ServerDetails
+	services.DiscardBuildsParams
+	dryRun     bool
+	reportPath string
+}
+
+func NewBuildDiscardCommand() *BuildDiscardCommand {
+	return &BuildDiscardCommand{}
+}
+
+func (bdc *BuildDiscardCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildDiscardCommand {
+	bdc.serverDetails = serverDetails
+	return bdc
+}
+
+func (bdc *BuildDiscardCommand) SetDiscardBuildsParams(params services.DiscardBuildsParams) *BuildDiscardCommand {
+	bdc.DiscardBuildsParams = params
+	return bdc
+}
+
+func (bdc *BuildDiscardCommand) SetDryRun(dryRun bool) *BuildDiscardCommand {
+	bdc.dryRun = dryRun
+	return bdc
+}
+
+func (bdc *BuildDiscardCommand) SetReportPath(reportPath string) *BuildDiscardCommand {
+	bdc.reportPath = reportPath
+	return bdc
+}
+
+func (bdc *BuildDiscardCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bdc.serverDetails, nil
+}
+
+func (bdc *BuildDiscardCommand) CommandName() string {
+	return "rt_build_discard"
+}
+
+func (bdc *BuildDiscardCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(bdc.serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	if bdc.dryRun || bdc.reportPath != "" {
+		report, err := bdc.createReport(servicesManager)
+		if err != nil {
+			return err
+		}
+		logDiscardReport(report)
+		if bdc.reportPath != "" {
+			if err = writeDiscardReport(report, bdc.reportPath); err != nil {
+				return err
+			}
+		}
+		if bdc.dryRun {
+			return nil
+		}
+	}
+	return servicesManager.DiscardBuilds(bdc.DiscardBuildsParams)
+}
+
+// A build run which matches the retention parameters.
+type DiscardedBuild struct {
+	Number  string `json:"number"`
+	Started string `json:"started,omitempty"`
+	// The artifacts of the build run are counted only if they are deleted with the build.
+	ArtifactsCount int   `json:"artifactsCount,omitempty"`
+	ArtifactsSize  int64 `json:"artifactsSize,omitempty"`
+}
+
+type BuildDiscardReport struct {
+	BuildName           string           `json:"buildName"`
+	DryRun              bool             `json:"dryRun"`
+	MaxDays             string           `json:"maxDays,omitempty"`
+	MaxBuilds           string           `json:"maxBuilds,omitempty"`
+	ExcludeBuilds       []string         `json:"excludeBuilds,omitempty"`
+	DeleteArtifacts     bool             `json:"deleteArtifacts"`
+	Builds              []DiscardedBuild `json:"builds"`
+	TotalArtifactsCount int              `json:"totalArtifactsCount,omitempty"`
+	TotalArtifactsSize  int64            `json:"totalArtifactsSize,omitempty"`
+}
+
+func (bdc *BuildDiscardCommand) createReport(servicesManager artifactory.ArtifactoryServicesManager) (*BuildDiscardReport, error) {
+	runs, err := getBuildRuns(servicesManager, bdc.BuildName, bdc.ProjectKey)
+	if err != nil {
+		return nil, err
+	}
+	excludeBuilds := splitExcludeBuilds(bdc.ExcludeBuilds)
+	discarded, err := selectBuildsToDiscard(runs, bdc.MaxDays, bdc.MaxBuilds, excludeBuilds, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	report := &BuildDiscardReport{
+		BuildName:       bdc.BuildName,
+		DryRun:          bdc.dryRun,
+		MaxDays:         bdc.MaxDays,
+		MaxBuilds:       bdc.MaxBuilds,
+		ExcludeBuilds:   excludeBuilds,
+		DeleteArtifacts: bdc.DeleteArtifacts,
+		Builds:          []DiscardedBuild{},
+	}
+	for _, run := range discarded {
+		build := DiscardedBuild{Number: run.Number, Started: run.Started}
+		if bdc.DeleteArtifacts {
+			if build.ArtifactsCount, build.ArtifactsSize, err = getBuildArtifactsSize(servicesManager, bdc.BuildName, run.Number, bdc.ProjectKey); err != nil {
+				return nil, err
+			}
+			report.TotalArtifactsCount += build.ArtifactsCount
+			report.TotalArtifactsSize += build.ArtifactsSize
+		}
+		report.Builds = append(report.Builds, build)
+	}
+	return report, nil
+}
+
+// A run of a build, as returned by the build runs REST API.
+type buildRun struct {
+	Number  string
+	Started string
+}
+
+func getBuildRuns(servicesManager artifactory.ArtifactoryServicesManager, buildName, projectKey string) ([]buildRun, error) {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	requestUrl := serviceDetails.GetUrl() + "api/build/" + url.PathEscape(buildName) + serviceutils.GetProjectQueryParam(projectKey)
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	log.Debug("Getting the runs of build", buildName+"...")
+	resp, body, _, err := servicesManager.Client().SendGet(requestUrl, true, &httpClientDetails)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		log.Info("Build", buildName, "has no published runs.")
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorutils.CheckError(errors.New("Artifactory response: " + resp.Status + "\n" + clientutils.IndentJson(body)))
+	}
+	var response struct {
+		BuildsNumbers []struct {
+			Uri     string `json:"uri"`
+			Started string `json:"started"`
+		} `json:"buildsNumbers"`
+	}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var runs []buildRun
+	for _, number := range response.BuildsNumbers {
+		buildNumber, err := url.PathUnescape(strings.TrimPrefix(number.Uri, "/"))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		runs = append(runs, buildRun{Number: buildNumber, Started: number.Started})
+	}
+	return runs, nil
+}
+
+func splitExcludeBuilds(excludeBuilds string) []string {
+	var numbers []string
+	for _, number := range strings.Split(excludeBuilds, ",") {
+		if number = strings.TrimSpace(number); number != "" {
+			numbers = append(numbers, number)
+		}
+	}
+	return numbers
+}
+
+// Returns the build runs which Artifactory discards for the retention parameters, from the newest to the oldest.
+// Runs started before the max days, and runs beyond the newest max builds are discarded. Excluded runs and runs whose
+// start time can't be parsed are never discarded, and are not counted as part of the max builds.
+func selectBuildsToDiscard(runs []buildRun, maxDays, maxBuilds string, excludeBuilds []string, now time.Time) ([]buildRun, error) {
+	excluded := make(map[string]bool)
+	for _, number := range excludeBuilds {
+		excluded[number] = true
+	}
+	var minimumDate time.Time
+	if maxDays != "" {
+		days, err := strconv.Atoi(maxDays)
+		if err != nil {
+			return nil, errorutils.CheckError(errors.New("the --max-days option must be a number: " + maxDays))
+		}
+		minimumDate = now.Add(-24 * time.Hour * time.Duration(days))
+	}
+	count := -1
+	if maxBuilds != "" {
+		var err error
+		if count, err = strconv.Atoi(maxBuilds); err != nil {
+			return nil, errorutils.CheckError(errors.New("the --max-builds option must be a number: " + maxBuilds))
+		}
+	}
+
+	type datedRun struct {
+		buildRun
+		started time.Time
+	}
+	var candidates []datedRun
+	for _, run := range runs {
+		if excluded[run.Number] {
+			continue
+		}
+		started, err := parseBuildStarted(run.Started)
+		if err != nil {
+			// The run can't be ordered by its age, so it's skipped rather than considered the oldest.
+			log.Warn("Skipping build number", run.Number+", since its start time couldn't be parsed:", err.Error())
+			continue
+		}
+		candidates = append(candidates, datedRun{buildRun: run, started: started})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].started.After(candidates[j].started)
+	})
+
+	var discarded []buildRun
+	for i, candidate := range candidates {
+		tooOld := !minimumDate.IsZero() && candidate.started.Before(minimumDate)
+		tooMany := count >= 0 && i >= count
+		if tooOld || tooMany {
+			discarded = append(discarded, candidate.buildRun)
+		}
+	}
+	return discarded, nil
+}
+
+func parseBuildStarted(started string) (time.Time, error) {
+	parsed, err := time.Parse(buildinfo.TimeFormat, started)
+	if err == nil {
+		return parsed, nil
+	}
+	return time.Parse(time.RFC3339, started)
+}
+
+// Returns the number and the total size of the artifacts associated with the build run.
+// The build runs of a project are stored in the build-info repository of the project, so they are searched by it.
+func getBuildArtifactsSize(servicesManager artifactory.ArtifactoryServicesManager, buildName, buildNumber, projectKey string) (int, int64, error) {
+	criteria, err := json.Marshal(createBuildArtifactsCriteria(buildName, buildNumber, projectKey))
+	if errorutils.CheckError(err) != nil {
+		return 0, 0, err
+	}
+	query := "items.find(" + string(criteria) + `).include("repo","path","name","size")`
+	reader, err := servicesManager.Aql(query)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer reader.Close()
+	body, err := ioutil.ReadAll(reader)
+	if errorutils.CheckError(err) != nil {
+		return 0, 0, err
+	}
+	var response struct {
+		Results []serviceutils.ResultItem `json:"results"`
+	}
+	if err = json.Unmarshal(body, &response); err != nil {
+		return 0, 0, errorutils.CheckError(err)
+	}
+	// An artifact may be associated with more than one module of the build.
+	counted := make(map[string]bool)
+	var size int64
+	for _, item := range response.Results {
+		itemPath := item.GetItemRelativePath()
+		if counted[itemPath] {
+			continue
+		}
+		counted[itemPath] = true
+		size += item.Size
+	}
+	return len(counted), size, nil
+}
+
+func logDiscardReport(report *BuildDiscardReport) {
+	prefix := ""
+	if report.DryRun {
+		prefix = "[Dry run] "
+	}
+	if len(report.Builds) == 0 {
+		log.Info(prefix + "No runs of build " + report.BuildName + " match the retention parameters.")
+		return
+	}
+	log.Info(prefix + strconv.Itoa(len(report.Builds)) + " runs of build " + report.BuildName + " match the retention parameters:")
+	for _, build := range report.Builds {
+		line := "  " + build.Number
+		if build.Started != "" {
+			line += " (started " + build.Started + ")"
+		}
+		if report.DeleteArtifacts {
+			line += ": " + strconv.Itoa(build.ArtifactsCount) + " artifacts, " + strconv.FormatInt(build.ArtifactsSize, 10) + " bytes"
+		}
+		log.Info(line)
+	}
+	if report.DeleteArtifacts {
+		log.Info(prefix + "Total artifacts to delete: " + strconv.Itoa(report.TotalArtifactsCount) + " (" + strconv.FormatInt(report.TotalArtifactsSize, 10) + " bytes).")
+	}
+}
+
+func writeDiscardReport(report *BuildDiscardReport, reportPath string) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if errorutils.CheckError(err) != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(reportPath, content, 0644); errorutils.CheckError(err) != nil {
+		return err
+	}
+	log.Info("The build discard report was written to", reportPath)
+	return nil
+}
+
+func createBuildArtifactsCriteria(buildName, buildNumber, projectKey string) map[string]string {
+	criteria := map[string]string{"artifact.module.build.name": buildName, "artifact.module.build.number": buildNumber}
+	if projectKey != "" {
+		criteria["artifact.module.build.repo"] = projectKey + "-build-info"
+	}
+	return criteria
+}
@@ -0,0 +1,65 @@
+package buildinfo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectBuildsToDiscard(t *testing.T) {
+	now := time.Date(2021, 6, 30, 12, 0, 0, 0, time.UTC)
+	runs := []buildRun{
+		{Number: "1", Started: "2021-06-01T12:00:00.000+0000"},
+		{Number: "2", Started: "2021-06-10T12:00:00.000+0000"},
+		{Number: "3", Started: "2021-06-20T12:00:00.000+0000"},
+		{Number: "4", Started: "2021-06-29T12:00:00.000+0000"},
+		{Number: "5", Started: "2021-06-30T10:00:00.000+0000"},
+		// Never discarded, since its start time can't be parsed.
+		{Number: "6", Started: "yesterday"},
+	}
+	tests := []struct {
+		name          string
+		maxDays       string
+		maxBuilds     string
+		excludeBuilds []string
+		expected      []string
+	}{
+		{"none", "", "", nil, nil},
+		{"maxDays", "15", "", nil, []string{"2", "1"}},
+		{"maxBuilds", "", "2", nil, []string{"3", "2", "1"}},
+		{"maxDaysAndMaxBuilds", "25", "4", nil, []string{"1"}},
+		{"maxBuildsAndExclude", "", "2", []string{"1", "5"}, []string{"2"}},
+		{"zeroMaxBuilds", "", "0", []string{"3"}, []string{"5", "4", "2", "1"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			discarded, err := selectBuildsToDiscard(runs, test.maxDays, test.maxBuilds, test.excludeBuilds, now)
+			assert.NoError(t, err)
+			var numbers []string
+			for _, run := range discarded {
+				numbers = append(numbers, run.Number)
+			}
+			assert.Equal(t, test.expected, numbers)
+		})
+	}
+}
+
+func TestSelectBuildsToDiscardInvalidParams(t *testing.T) {
+	_, err := selectBuildsToDiscard(nil, "ten", "", nil, time.Now())
+	assert.Error(t, err)
+	_, err = selectBuildsToDiscard(nil, "", "ten", nil, time.Now())
+	assert.Error(t, err)
+}
+
+func TestSplitExcludeBuilds(t *testing.T) {
+	assert.Equal(t, []string{"1", "2"}, splitExcludeBuilds("1, 2,"))
+	assert.Nil(t, splitExcludeBuilds(""))
+}
+
+func TestCreateBuildArtifactsCriteria(t *testing.T) {
+	assert.Equal(t, map[string]string{"artifact.module.build.name": "app", "artifact.module.build.number": "3"},
+		createBuildArtifactsCriteria("app", "3", ""))
+	assert.Equal(t, map[string]string{"artifact.module.build.name": "app", "artifact.module.build.number": "3", "artifact.module.build.repo": "proj-build-info"},
+		createBuildArtifactsCriteria("app", "3", "proj"))
+}
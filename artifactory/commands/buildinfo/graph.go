@@ -0,0 +1,327 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type GraphFormat string
+
+const (
+	Dot     GraphFormat = "dot"
+	Mermaid GraphFormat = "mermaid"
+	Json    GraphFormat = "json"
+)
+
+var GraphFormats = []string{string(Dot), string(Mermaid), string(Json)}
+
+// Returns a published build-info by its name and number. The boolean return value is false if the build was not found.
+type buildInfoGetter func(buildName, buildNumber string) (*buildinfo.BuildInfo, bool, error)
+
+type BuildGraphCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *utils.BuildConfiguration
+	format             GraphFormat
+}
+
+func NewBuildGraphCommand() *BuildGraphCommand {
+	return &BuildGraphCommand{format: Dot}
+}
+
+func (bgc *BuildGraphCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildGraphCommand {
+	bgc.serverDetails = serverDetails
+	return bgc
+}
+
+func (bgc *BuildGraphCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildGraphCommand {
+	bgc.buildConfiguration = buildConfiguration
+	return bgc
+}
+
+func (bgc *BuildGraphCommand) SetFormat(format string) error {
+	if format == "" {
+		bgc.format = Dot
+		return nil
+	}
+	for _, supported := range GraphFormats {
+		if format == supported {
+			bgc.format = GraphFormat(format)
+			return nil
+		}
+	}
+	return errorutils.CheckError(fmt.Errorf("the --format option must be one of: %s", strings.Join(GraphFormats, ", ")))
+}
+
+func (bgc *BuildGraphCommand) ServerDetails() (*config.ServerDetails, error) {
+	return bgc.serverDetails, nil
+}
+
+func (bgc *BuildGraphCommand) CommandName() string {
+	return "rt_build_graph"
+}
+
+func (bgc *BuildGraphCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(bgc.serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	getter := func(buildName, buildNumber string) (*buildinfo.BuildInfo, bool, error) {
+		log.Debug("Fetching build", buildName+"/"+buildNumber, "from Artifactory...")
+		params := services.BuildInfoParams{BuildName: buildName, BuildNumber: buildNumber, ProjectKey: bgc.buildConfiguration.Project}
+		publishedBuildInfo, found, err := servicesManager.GetBuildInfo(params)
+		if err != nil || !found {
+			return nil, found, err
+		}
+		return &publishedBuildInfo.BuildInfo, true, nil
+	}
+	graph, err := CreateBuildGraph(bgc.buildConfiguration.BuildName, bgc.buildConfiguration.BuildNumber, getter)
+	if err != nil {
+		return err
+	}
+	output, err := graph.Render(bgc.format)
+	if err != nil {
+		return err
+	}
+	log.Output(output)
+	return nil
+}
+
+// A build in the graph. The appended builds are the modules of type "build" in the build-info.
+type BuildNode struct {
+	Name     string       `json:"name"`
+	Number   string       `json:"number"`
+	Started  string       `json:"started,omitempty"`
+	Missing  bool         `json:"missing,omitempty"`
+	Cycle    bool         `json:"cycle,omitempty"`
+	Appended []*BuildNode `json:"appended,omitempty"`
+}
+
+func (node *BuildNode) Id() string {
+	return node.Name + "/" + node.Number
+}
+
+// An artifact produced by one build of the graph and consumed as a dependency by another.
+type ConsumedArtifact struct {
+	Name     string `json:"name"`
+	Sha1     string `json:"sha1"`
+	Producer string `json:"producer"`
+	Consumer string `json:"consumer"`
+}
+
+type BuildGraph struct {
+	Root              *BuildNode         `json:"root"`
+	ConsumedArtifacts []ConsumedArtifact `json:"consumedArtifacts,omitempty"`
+}
+
+// Walks the published build and all the builds appended to it recursively.
+// Each build is fetched once, even if it is appended to more than one build in the tree.
+func CreateBuildGraph(buildName, buildNumber string, getBuildInfo buildInfoGetter) (*BuildGraph, error) {
+	fetched := make(map[string]*buildinfo.BuildInfo)
+	root, err := walkBuild(buildName, buildNumber, getBuildInfo, fetched, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	if root.Missing {
+		return nil, errorutils.CheckError(errors.New("Build " + root.Id() + " not found in Artifactory."))
+	}
+	return &BuildGraph{Root: root, ConsumedArtifacts: findConsumedArtifacts(fetched)}, nil
+}
+
+func walkBuild(buildName, buildNumber string, getBuildInfo buildInfoGetter, fetched map[string]*buildinfo.BuildInfo, path map[string]bool) (*BuildNode, error) {
+	node := &BuildNode{Name: buildName, Number: buildNumber}
+	id := node.Id()
+	if path[id] {
+		log.Warn("Build", id, "is appended to itself through its own appended builds. Skipping it.")
+		node.Cycle = true
+		return node, nil
+	}
+	bi, exists := fetched[id]
+	if !exists {
+		var found bool
+		var err error
+		bi, found, err = getBuildInfo(buildName, buildNumber)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			log.Warn("Build", id, "not found in Artifactory.")
+			node.Missing = true
+			return node, nil
+		}
+		fetched[id] = bi
+	}
+	node.Started = bi.Started
+
+	path[id] = true
+	defer delete(path, id)
+	for _, module := range bi.Modules {
+		if module.Type != buildinfo.Build {
+			continue
+		}
+		appendedName, appendedNumber, err := splitBuildModuleId(module.Id)
+		if err != nil {
+			return nil, err
+		}
+		child, err := walkBuild(appendedName, appendedNumber, getBuildInfo, fetched, path)
+		if err != nil {
+			return nil, err
+		}
+		node.Appended = append(node.Appended, child)
+	}
+	return node, nil
+}
+
+// The id of an appended build module is in the form of <build name>/<build number>.
+// The build name may contain slashes, so the number is taken after the last one.
+func splitBuildModuleId(moduleId string) (buildName, buildNumber string, err error) {
+	index := strings.LastIndex(moduleId, "/")
+	if index <= 0 || index == len(moduleId)-1 {
+		return "", "", errorutils.CheckError(errors.New("unexpected appended build module ID: " + moduleId))
+	}
+	return moduleId[:index], moduleId[index+1:], nil
+}
+
+// Matches the dependencies of every build against the artifacts of the other builds by their sha1 checksum.
+func findConsumedArtifacts(fetched map[string]*buildinfo.BuildInfo) []ConsumedArtifact {
+	type producedArtifact struct {
+		name     string
+		producer string
+	}
+	ids := make([]string, 0, len(fetched))
+	for id := range fetched {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	producedBySha1 := make(map[string]producedArtifact)
+	for _, id := range ids {
+		for _, module := range fetched[id].Modules {
+			if module.Type == buildinfo.Build {
+				continue
+			}
+			for _, artifact := range module.Artifacts {
+				if artifact.Checksum == nil || artifact.Sha1 == "" {
+					continue
+				}
+				if _, exists := producedBySha1[artifact.Sha1]; !exists {
+					producedBySha1[artifact.Sha1] = producedArtifact{name: artifact.Name, producer: id}
+				}
+			}
+		}
+	}
+
+	var consumed []ConsumedArtifact
+	for _, id := range ids {
+		seen := make(map[string]bool)
+		for _, module := range fetched[id].Modules {
+			for _, dependency := range module.Dependencies {
+				if dependency.Checksum == nil || dependency.Sha1 == "" || seen[dependency.Sha1] {
+					continue
+				}
+				produced, exists := producedBySha1[dependency.Sha1]
+				if !exists || produced.producer == id {
+					continue
+				}
+				seen[dependency.Sha1] = true
+				consumed = append(consumed, ConsumedArtifact{Name: produced.name, Sha1: dependency.Sha1, Producer: produced.producer, Consumer: id})
+			}
+		}
+	}
+	return consumed
+}
+
+func (graph *BuildGraph) Render(format GraphFormat) (string, error) {
+	switch format {
+	case Dot:
+		return graph.renderDot(), nil
+	case Mermaid:
+		return graph.renderMermaid(), nil
+	case Json:
+		content, err := json.MarshalIndent(graph, "", "  ")
+		return string(content), errorutils.CheckError(err)
+	}
+	return "", errorutils.CheckError(errors.New("unsupported build graph format: " + string(format)))
+}
+
+// Returns the unique edges between each build and the builds appended to it, in the order of discovery.
+// Each build is walked once, so builds appended to a few builds don't have their subtrees walked again.
+func (graph *BuildGraph) edges() (nodes []*BuildNode, edges [][2]string) {
+	visitedNodes := make(map[string]bool)
+	visitedEdges := make(map[[2]string]bool)
+	var walk func(node *BuildNode)
+	walk = func(node *BuildNode) {
+		visitedNodes[node.Id()] = true
+		nodes = append(nodes, node)
+		for _, child := range node.Appended {
+			edge := [2]string{node.Id(), child.Id()}
+			if !visitedEdges[edge] {
+				visitedEdges[edge] = true
+				edges = append(edges, edge)
+			}
+			if !visitedNodes[child.Id()] {
+				walk(child)
+			}
+		}
+	}
+	walk(graph.Root)
+	return
+}
+
+func (graph *BuildGraph) renderDot() string {
+	nodes, edges := graph.edges()
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("digraph %q {\n", graph.Root.Id()))
+	for _, node := range nodes {
+		if node.Missing {
+			sb.WriteString(fmt.Sprintf("\t%q [style=dashed, label=%q];\n", node.Id(), node.Id()+" (not found)"))
+		}
+	}
+	for _, edge := range edges {
+		sb.WriteString(fmt.Sprintf("\t%q -> %q;\n", edge[0], edge[1]))
+	}
+	for _, artifact := range graph.ConsumedArtifacts {
+		sb.WriteString(fmt.Sprintf("\t%q -> %q [style=dashed, color=blue, label=%q];\n", artifact.Producer, artifact.Consumer, artifact.Name))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func (graph *BuildGraph) renderMermaid() string {
+	nodes, edges := graph.edges()
+	// Mermaid node IDs can't contain slashes, so each build gets a short generated ID.
+	mermaidIds := make(map[string]string)
+	var sb strings.Builder
+	sb.WriteString("graph TD\n")
+	for i, node := range nodes {
+		mermaidIds[node.Id()] = fmt.Sprintf("b%d", i)
+		label := node.Id()
+		if node.Missing {
+			label += " (not found)"
+		}
+		sb.WriteString(fmt.Sprintf("\t%s[\"%s\"]\n", mermaidIds[node.Id()], escapeMermaid(label)))
+	}
+	for _, edge := range edges {
+		sb.WriteString(fmt.Sprintf("\t%s --> %s\n", mermaidIds[edge[0]], mermaidIds[edge[1]]))
+	}
+	for _, artifact := range graph.ConsumedArtifacts {
+		sb.WriteString(fmt.Sprintf("\t%s -. \"%s\" .-> %s\n", mermaidIds[artifact.Producer], escapeMermaid(artifact.Name), mermaidIds[artifact.Consumer]))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// Mermaid doesn't support backslash escapes in quoted labels, so quotes and the entity character are written as entity codes.
+var mermaidReplacer = strings.NewReplacer("#", "#35;", `"`, "#quot;", "\n", " ", "\r", " ")
+
+func escapeMermaid(label string) string {
+	return mermaidReplacer.Replace(label)
+}
@@ -0,0 +1,154 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestBuildInfos() map[string]*buildinfo.BuildInfo {
+	return map[string]*buildinfo.BuildInfo{
+		"release/1": {
+			Name: "release", Number: "1",
+			Modules: []buildinfo.Module{
+				{Type: buildinfo.Build, Id: "backend/5"},
+				{Type: buildinfo.Build, Id: "frontend/7"},
+			},
+		},
+		"backend/5": {
+			Name: "backend", Number: "5",
+			Modules: []buildinfo.Module{
+				{Type: buildinfo.Maven, Id: "org:backend:1.0", Artifacts: []buildinfo.Artifact{{Name: "backend.jar", Checksum: &buildinfo.Checksum{Sha1: "aaa"}}}},
+				{Type: buildinfo.Build, Id: "common/2"},
+			},
+		},
+		"frontend/7": {
+			Name: "frontend", Number: "7",
+			Modules: []buildinfo.Module{
+				{Type: buildinfo.Npm, Id: "frontend:1.0", Dependencies: []buildinfo.Dependency{{Id: "common.tgz", Checksum: &buildinfo.Checksum{Sha1: "ccc"}}}},
+				{Type: buildinfo.Build, Id: "common/2"},
+				{Type: buildinfo.Build, Id: "missing/1"},
+			},
+		},
+		"common/2": {
+			Name: "common", Number: "2",
+			Modules: []buildinfo.Module{
+				{Type: buildinfo.Npm, Id: "common:1.0", Artifacts: []buildinfo.Artifact{{Name: "common.tgz", Checksum: &buildinfo.Checksum{Sha1: "ccc"}}}},
+			},
+		},
+	}
+}
+
+func createTestGetter(buildInfos map[string]*buildinfo.BuildInfo, calls map[string]int) buildInfoGetter {
+	return func(buildName, buildNumber string) (*buildinfo.BuildInfo, bool, error) {
+		calls[buildName+"/"+buildNumber]++
+		bi, found := buildInfos[buildName+"/"+buildNumber]
+		return bi, found, nil
+	}
+}
+
+func TestCreateBuildGraph(t *testing.T) {
+	calls := make(map[string]int)
+	graph, err := CreateBuildGraph("release", "1", createTestGetter(createTestBuildInfos(), calls))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "release/1", graph.Root.Id())
+	assert.Len(t, graph.Root.Appended, 2)
+	backend, frontend := graph.Root.Appended[0], graph.Root.Appended[1]
+	assert.Equal(t, "backend/5", backend.Id())
+	assert.Equal(t, "common/2", backend.Appended[0].Id())
+	assert.Equal(t, "frontend/7", frontend.Id())
+	assert.Len(t, frontend.Appended, 2)
+	assert.True(t, frontend.Appended[1].Missing)
+
+	// A build appended to more than one build should be fetched once.
+	assert.Equal(t, 1, calls["common/2"])
+
+	assert.Equal(t, []ConsumedArtifact{{Name: "common.tgz", Sha1: "ccc", Producer: "common/2", Consumer: "frontend/7"}}, graph.ConsumedArtifacts)
+}
+
+func TestCreateBuildGraphCycle(t *testing.T) {
+	buildInfos := map[string]*buildinfo.BuildInfo{
+		"a/1": {Modules: []buildinfo.Module{{Type: buildinfo.Build, Id: "b/1"}}},
+		"b/1": {Modules: []buildinfo.Module{{Type: buildinfo.Build, Id: "a/1"}}},
+	}
+	graph, err := CreateBuildGraph("a", "1", createTestGetter(buildInfos, map[string]int{}))
+	assert.NoError(t, err)
+	assert.True(t, graph.Root.Appended[0].Appended[0].Cycle)
+}
+
+func TestCreateBuildGraphRootNotFound(t *testing.T) {
+	_, err := CreateBuildGraph("a", "1", createTestGetter(map[string]*buildinfo.BuildInfo{}, map[string]int{}))
+	assert.Error(t, err)
+}
+
+func TestSplitBuildModuleId(t *testing.T) {
+	tests := []struct {
+		moduleId       string
+		expectedName   string
+		expectedNumber string
+		expectError    bool
+	}{
+		{"build/1", "build", "1", false},
+		{"team/build/1", "team/build", "1", false},
+		{"build", "", "", true},
+		{"build/", "", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.moduleId, func(t *testing.T) {
+			buildName, buildNumber, err := splitBuildModuleId(test.moduleId)
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedName, buildName)
+			assert.Equal(t, test.expectedNumber, buildNumber)
+		})
+	}
+}
+
+func TestRenderBuildGraph(t *testing.T) {
+	graph, err := CreateBuildGraph("release", "1", createTestGetter(createTestBuildInfos(), map[string]int{}))
+	assert.NoError(t, err)
+
+	dot, err := graph.Render(Dot)
+	assert.NoError(t, err)
+	assert.Equal(t, `digraph "release/1" {
+	"missing/1" [style=dashed, label="missing/1 (not found)"];
+	"release/1" -> "backend/5";
+	"backend/5" -> "common/2";
+	"release/1" -> "frontend/7";
+	"frontend/7" -> "common/2";
+	"frontend/7" -> "missing/1";
+	"common/2" -> "frontend/7" [style=dashed, color=blue, label="common.tgz"];
+}`, dot)
+
+	mermaid, err := graph.Render(Mermaid)
+	assert.NoError(t, err)
+	assert.Equal(t, `graph TD
+	b0["release/1"]
+	b1["backend/5"]
+	b2["common/2"]
+	b3["frontend/7"]
+	b4["missing/1 (not found)"]
+	b0 --> b1
+	b1 --> b2
+	b0 --> b3
+	b3 --> b2
+	b3 --> b4
+	b2 -. "common.tgz" .-> b3`, mermaid)
+
+	content, err := graph.Render(Json)
+	assert.NoError(t, err)
+	parsed := new(BuildGraph)
+	assert.NoError(t, json.Unmarshal([]byte(content), parsed))
+	assert.Equal(t, graph, parsed)
+}
+
+func TestEscapeMermaid(t *testing.T) {
+	assert.Equal(t, "lib #quot;core#quot; #35;1", escapeMermaid(`lib "core" #1`))
+	assert.Equal(t, "multi line", escapeMermaid("multi\nline"))
+}
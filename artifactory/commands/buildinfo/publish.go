@@ -0,0 +1,375 @@
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Publishes the build-info collected locally, after passing it through the enrichment hooks and validating it against a JSON schema.
+// Any hook or validation failure blocks the publish.
+type ValidatedBuildPublishCommand struct {
+	buildConfiguration *utils.BuildConfiguration
+	serverDetails      *config.ServerDetails
+	config             *buildinfo.Configuration
+	schemaPath         string
+	hooks              []string
+	detailedSummary    bool
+	summary            *clientutils.Sha256Summary
+}
+
+func NewValidatedBuildPublishCommand() *ValidatedBuildPublishCommand {
+	return &ValidatedBuildPublishCommand{}
+}
+
+func (vbpc *ValidatedBuildPublishCommand) SetConfig(config *buildinfo.Configuration) *ValidatedBuildPublishCommand {
+	vbpc.config = config
+	return vbpc
+}
+
+func (vbpc *ValidatedBuildPublishCommand) SetServerDetails(serverDetails *config.ServerDetails) *ValidatedBuildPublishCommand {
+	vbpc.serverDetails = serverDetails
+	return vbpc
+}
+
+func (vbpc *ValidatedBuildPublishCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *ValidatedBuildPublishCommand {
+	vbpc.buildConfiguration = buildConfiguration
+	return vbpc
+}
+
+func (vbpc *ValidatedBuildPublishCommand) SetSchemaPath(schemaPath string) *ValidatedBuildPublishCommand {
+	vbpc.schemaPath = schemaPath
+	return vbpc
+}
+
+func (vbpc *ValidatedBuildPublishCommand) SetHooks(hooks []string) *ValidatedBuildPublishCommand {
+	vbpc.hooks = hooks
+	return vbpc
+}
+
+func (vbpc *ValidatedBuildPublishCommand) SetDetailedSummary(detailedSummary bool) *ValidatedBuildPublishCommand {
+	vbpc.detailedSummary = detailedSummary
+	return vbpc
+}
+
+func (vbpc *ValidatedBuildPublishCommand) IsDetailedSummary() bool {
+	return vbpc.detailedSummary
+}
+
+func (vbpc *ValidatedBuildPublishCommand) GetSummary() *clientutils.Sha256Summary {
+	return vbpc.summary
+}
+
+func (vbpc *ValidatedBuildPublishCommand) CommandName() string {
+	return "rt_build_publish"
+}
+
+func (vbpc *ValidatedBuildPublishCommand) ServerDetails() (*config.ServerDetails, error) {
+	return vbpc.serverDetails, nil
+}
+
+func (vbpc *ValidatedBuildPublishCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(vbpc.serverDetails, -1, vbpc.config.DryRun)
+	if err != nil {
+		return err
+	}
+	buildInfo, err := vbpc.createBuildInfo()
+	if err != nil {
+		return err
+	}
+	if len(vbpc.hooks) > 0 {
+		if buildInfo, err = runEnrichmentHooks(vbpc.hooks, buildInfo); err != nil {
+			return err
+		}
+	}
+	if vbpc.schemaPath != "" {
+		schema, err := ioutil.ReadFile(vbpc.schemaPath)
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		content, err := json.Marshal(buildInfo)
+		if errorutils.CheckError(err) != nil {
+			return err
+		}
+		if err = ValidateBuildInfo(schema, content); err != nil {
+			return err
+		}
+	}
+
+	summary, err := servicesManager.PublishBuildInfo(buildInfo, vbpc.buildConfiguration.Project)
+	if vbpc.IsDetailedSummary() {
+		vbpc.summary = summary
+	}
+	if err != nil {
+		return err
+	}
+	if !vbpc.config.DryRun {
+		return utils.RemoveBuildDir(vbpc.buildConfiguration.BuildName, vbpc.buildConfiguration.BuildNumber, vbpc.buildConfiguration.Project)
+	}
+	return nil
+}
+
+// Creates the build-info from the partials and the generated build-infos of the build, merged as build-publish merges them.
+func (vbpc *ValidatedBuildPublishCommand) createBuildInfo() (*buildinfo.BuildInfo, error) {
+	bc := vbpc.buildConfiguration
+	partials, err := utils.ReadPartialBuildInfoFiles(bc.BuildName, bc.BuildNumber, bc.Project)
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(partials)
+	generalDetails, err := utils.ReadBuildInfoGeneralDetails(bc.BuildName, bc.BuildNumber, bc.Project)
+	if err != nil {
+		return nil, err
+	}
+	buildInfo := buildinfo.New()
+	buildInfo.SetAgentName(coreutils.GetCliUserAgentName())
+	buildInfo.SetAgentVersion(coreutils.GetCliUserAgentVersion())
+	buildInfo.SetBuildAgentVersion(coreutils.GetClientAgentVersion())
+	buildInfo.Name, buildInfo.Number = bc.BuildName, bc.BuildNumber
+	buildInfo.Started = generalDetails.Timestamp.Format(buildinfo.TimeFormat)
+	buildInfo.ArtifactoryPrincipal = vbpc.serverDetails.User
+	buildInfo.BuildUrl = vbpc.config.BuildUrl
+	if err = mergePartials(buildInfo, partials, vbpc.config.IncludeFilter(), vbpc.config.ExcludeFilter()); err != nil {
+		return nil, err
+	}
+	generatedBuildInfos, err := utils.GetGeneratedBuildsInfo(bc.BuildName, bc.BuildNumber, bc.Project)
+	if err != nil {
+		return nil, err
+	}
+	for _, generated := range generatedBuildInfos {
+		buildInfo.Append(generated)
+	}
+	return buildInfo, nil
+}
+
+// Adds the modules, the environment variables passing the filters, the VCS details and the issues of the partials to the build-info.
+// The artifacts and the dependencies of a module are deduplicated, and the modules without an ID are named after the build.
+// Unlike build-publish, no module is created for the partials of the environment variables and the VCS details.
+func mergePartials(buildInfo *buildinfo.BuildInfo, partials buildinfo.Partials, includeFilter, excludeFilter buildinfo.Filter) error {
+	env := make(buildinfo.Env)
+	var moduleIds []string
+	modules := make(map[string]*buildinfo.Module)
+	addedFiles := make(map[string]bool)
+	issues := buildinfo.Issues{}
+	affectedIssues := make(map[string]bool)
+	getModule := func(partial *buildinfo.Partial) *buildinfo.Module {
+		module, exists := modules[partial.ModuleId]
+		if !exists {
+			id := partial.ModuleId
+			if id == "" {
+				id = buildInfo.Name
+			}
+			module = &buildinfo.Module{Id: id, Type: partial.ModuleType}
+			modules[partial.ModuleId] = module
+			moduleIds = append(moduleIds, partial.ModuleId)
+		}
+		return module
+	}
+	for _, partial := range partials {
+		switch {
+		case partial.Artifacts != nil:
+			module := getModule(partial)
+			for _, artifact := range partial.Artifacts {
+				if key := fmt.Sprintf("%s/artifact/%s-%s-%s", partial.ModuleId, artifact.Name, artifact.Sha1, artifact.Md5); !addedFiles[key] {
+					addedFiles[key] = true
+					module.Artifacts = append(module.Artifacts, artifact)
+				}
+			}
+		case partial.Dependencies != nil:
+			module := getModule(partial)
+			for _, dependency := range partial.Dependencies {
+				if key := fmt.Sprintf("%s/dependency/%s-%s-%s-%s", partial.ModuleId, dependency.Id, dependency.Sha1, dependency.Md5, dependency.Scopes); !addedFiles[key] {
+					addedFiles[key] = true
+					module.Dependencies = append(module.Dependencies, dependency)
+				}
+			}
+		case partial.VcsList != nil:
+			buildInfo.VcsList = append(buildInfo.VcsList, partial.VcsList...)
+			if partial.Issues == nil {
+				continue
+			}
+			issues.Tracker = partial.Issues.Tracker
+			issues.AggregateBuildIssues = partial.Issues.AggregateBuildIssues
+			issues.AggregationBuildStatus = partial.Issues.AggregationBuildStatus
+			for _, issue := range partial.Issues.AffectedIssues {
+				if !affectedIssues[issue.Key] {
+					affectedIssues[issue.Key] = true
+					issues.AffectedIssues = append(issues.AffectedIssues, issue)
+				}
+			}
+		case partial.Env != nil:
+			included, err := includeFilter(partial.Env)
+			if errorutils.CheckError(err) != nil {
+				return err
+			}
+			filtered, err := excludeFilter(included)
+			if errorutils.CheckError(err) != nil {
+				return err
+			}
+			for key, value := range filtered {
+				env[key] = value
+			}
+		case partial.ModuleType == buildinfo.Build:
+			getModule(partial).Checksum = partial.Checksum
+		}
+	}
+	for _, id := range moduleIds {
+		buildInfo.Modules = append(buildInfo.Modules, *modules[id])
+	}
+	if len(env) > 0 {
+		buildInfo.Properties = env
+	}
+	// The issues are published only if their tracker is set.
+	if issues.Tracker != nil && issues.Tracker.Name != "" {
+		buildInfo.Issues = &issues
+	}
+	return nil
+}
+
+// Runs the enrichment hooks in order, each on the build-info patched by the previous one.
+// The fields added by the hooks, which aren't part of the build-info structure, can't be published, so they are dropped with a warning.
+func runEnrichmentHooks(hooks []string, buildInfo *buildinfo.BuildInfo) (*buildinfo.BuildInfo, error) {
+	content, err := json.Marshal(buildInfo)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	for _, hook := range hooks {
+		if content, err = RunEnrichmentHook(hook, content); err != nil {
+			return nil, err
+		}
+	}
+	patched := new(buildinfo.BuildInfo)
+	if err = json.Unmarshal(content, patched); err != nil {
+		return nil, errorutils.CheckError(errors.New("the build-info enrichment hooks returned an invalid build-info: " + err.Error()))
+	}
+	dropped, err := getUnknownFields(content, patched)
+	if err != nil {
+		return nil, err
+	}
+	if len(dropped) > 0 {
+		log.Warn("The following fields added by the build-info enrichment hooks aren't part of the build-info, and are not published: " + strings.Join(dropped, ", "))
+	}
+	return patched, nil
+}
+
+// Returns the sorted top-level fields of the build-info JSON, which aren't part of the build-info structure.
+func getUnknownFields(content []byte, buildInfo *buildinfo.BuildInfo) ([]string, error) {
+	known, err := json.Marshal(buildInfo)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	var fields, knownFields map[string]json.RawMessage
+	if err = json.Unmarshal(content, &fields); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(known, &knownFields); errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	var unknown []string
+	for field := range fields {
+		if _, ok := knownFields[field]; !ok {
+			unknown = append(unknown, field)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
+
+// Runs an enrichment hook executable. The hook receives the build-info JSON on its standard input,
+// and is expected to write the patched build-info JSON to its standard output.
+// The patched build-info JSON is returned as is, including any custom fields the hook adds.
+func RunEnrichmentHook(hook string, buildInfo []byte) ([]byte, error) {
+	original, err := readBuildIdentity(buildInfo)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	log.Info("Running build-info enrichment hook:", hook)
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(hook)
+	cmd.Stdin = bytes.NewReader(buildInfo)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err = cmd.Run(); err != nil {
+		message := fmt.Sprintf("the build-info enrichment hook '%s' failed: %s", hook, err.Error())
+		if stderrContent := strings.TrimSpace(stderr.String()); stderrContent != "" {
+			message += "\n" + stderrContent
+		}
+		return nil, errorutils.CheckError(errors.New(message))
+	}
+	patched, err := readBuildIdentity(stdout.Bytes())
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("the build-info enrichment hook '%s' returned an invalid build-info: %s", hook, err.Error()))
+	}
+	if patched != original {
+		return nil, errorutils.CheckError(fmt.Errorf("the build-info enrichment hook '%s' is not allowed to change the build name or number", hook))
+	}
+	return bytes.TrimSpace(stdout.Bytes()), nil
+}
+
+// The fields of the build-info, which the enrichment hooks aren't allowed to change.
+type buildIdentity struct {
+	Name   string `json:"name"`
+	Number string `json:"number"`
+}
+
+// Reads the name and number of a build-info JSON object. Fails if the content isn't a JSON object.
+func readBuildIdentity(buildInfo []byte) (identity buildIdentity, err error) {
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(buildInfo, &fields); err != nil {
+		return
+	}
+	if fields == nil {
+		return identity, errors.New("the build-info is not a JSON object")
+	}
+	err = json.Unmarshal(buildInfo, &identity)
+	return
+}
+
+var moduleFieldRegExp = regexp.MustCompile(`^modules\.(\d+)`)
+
+// Validates the build-info JSON against the JSON schema. The returned error lists every violated rule.
+func ValidateBuildInfo(schema, buildInfo []byte) error {
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(buildInfo))
+	if err != nil {
+		return errorutils.CheckError(errors.New("failed to validate the build-info against the schema: " + err.Error()))
+	}
+	if result.Valid() {
+		log.Info("The build-info is valid according to the schema.")
+		return nil
+	}
+	// The module IDs are read separately, so a module without an ID, or with an invalid field, doesn't fail the validation.
+	var modules struct {
+		Modules []struct {
+			Id string `json:"id"`
+		} `json:"modules"`
+	}
+	_ = json.Unmarshal(buildInfo, &modules)
+	var violations []string
+	for _, resultError := range result.Errors() {
+		violation := resultError.Field() + ": " + resultError.Description()
+		// Point to the module by its ID, rather than by its index.
+		if match := moduleFieldRegExp.FindStringSubmatch(resultError.Field()); match != nil {
+			if index, err := strconv.Atoi(match[1]); err == nil && index < len(modules.Modules) && modules.Modules[index].Id != "" {
+				violation += " (module " + modules.Modules[index].Id + ")"
+			}
+		}
+		violations = append(violations, "- "+violation)
+	}
+	return errorutils.CheckError(errors.New("the build-info violates the schema:\n" + strings.Join(violations, "\n")))
+}
@@ -0,0 +1,139 @@
+package buildinfo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateBuildInfo(t *testing.T) {
+	homeDir, err := ioutil.TempDir("", "validated-publish")
+	assert.NoError(t, err)
+	defer os.RemoveAll(homeDir)
+	defer os.Setenv(coreutils.HomeDir, os.Getenv(coreutils.HomeDir))
+	assert.NoError(t, os.Setenv(coreutils.HomeDir, homeDir))
+
+	assert.NoError(t, utils.SaveBuildGeneralDetails("build", "1", ""))
+	partials := []buildinfo.Partial{
+		{ModuleId: "app", ModuleType: buildinfo.Generic, Artifacts: []buildinfo.Artifact{{Name: "a.zip", Checksum: &buildinfo.Checksum{Sha1: "1"}}}},
+		{ModuleId: "app", ModuleType: buildinfo.Generic, Dependencies: []buildinfo.Dependency{{Id: "lib.jar", Checksum: &buildinfo.Checksum{Sha1: "2"}}}},
+		{Env: buildinfo.Env{"buildInfo.env.HOME": "/home", "buildInfo.env.TOKEN": "secret"}},
+	}
+	for _, partial := range partials {
+		partial := partial
+		assert.NoError(t, utils.SavePartialBuildInfo("build", "1", "", func(p *buildinfo.Partial) { *p = partial }))
+	}
+	publishCmd := NewValidatedBuildPublishCommand().SetServerDetails(&config.ServerDetails{ArtifactoryUrl: "http://localhost:8081/artifactory/"}).
+		SetBuildConfiguration(&utils.BuildConfiguration{BuildName: "build", BuildNumber: "1"}).
+		SetConfig(&buildinfo.Configuration{EnvInclude: "*", EnvExclude: "*token*"})
+	buildInfo, err := publishCmd.createBuildInfo()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "build", buildInfo.Name)
+	// The env partial adds no module.
+	if assert.Len(t, buildInfo.Modules, 1) {
+		assert.Equal(t, "app", buildInfo.Modules[0].Id)
+		assert.Len(t, buildInfo.Modules[0].Artifacts, 1)
+		assert.Len(t, buildInfo.Modules[0].Dependencies, 1)
+	}
+	assert.Equal(t, buildinfo.Env{"buildInfo.env.HOME": "/home"}, buildInfo.Properties)
+}
+
+func TestRunEnrichmentHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test hooks are shell scripts.")
+	}
+	tempDir, err := ioutil.TempDir("", "hooks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	hook := filepath.Join(tempDir, "add-ticket.sh")
+	assert.NoError(t, ioutil.WriteFile(hook, []byte(`#!/bin/sh
+cat > /dev/null; echo '{"name":"build","number":"1","properties":{"buildInfo.env.TICKET":"JIRA-1"},"ticket":{"key":"JIRA-1"}}'
+`), 0755))
+
+	buildInfo := &buildinfo.BuildInfo{Name: "build", Number: "1"}
+	patched, err := runEnrichmentHooks([]string{hook}, buildInfo)
+	if assert.NoError(t, err) {
+		assert.Equal(t, buildinfo.Env{"buildInfo.env.TICKET": "JIRA-1"}, patched.Properties)
+	}
+	unknown, err := getUnknownFields([]byte(`{"name":"build","number":"1","ticket":{}}`), buildInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ticket"}, unknown)
+}
+
+func TestValidateBuildInfo(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"properties": {
+			"modules": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"required": ["artifacts"],
+					"properties": {
+						"artifacts": {"type": "array", "minItems": 1},
+						"dependencies": {"type": "array", "items": {"required": ["sha1"]}}
+					}
+				}
+			}
+		}
+	}`)
+	valid := []byte(`{"modules":[{"id":"app","artifacts":[{"name":"a.zip"}],"dependencies":[{"id":"lib.jar","sha1":"1"}]}]}`)
+	assert.NoError(t, ValidateBuildInfo(schema, valid))
+
+	invalid := []byte(`{"modules":[{"id":"app","artifacts":[{"name":"a.zip"}]},{"id":"lib","dependencies":[{"id":"lib.jar"}]}]}`)
+	err := ValidateBuildInfo(schema, invalid)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "modules.1: artifacts is required (module lib)")
+		assert.Contains(t, err.Error(), "modules.1.dependencies.0: sha1 is required (module lib)")
+		assert.NotContains(t, err.Error(), "module app")
+	}
+}
+
+func TestRunEnrichmentHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The test hooks are shell scripts.")
+	}
+	tempDir, err := ioutil.TempDir("", "hooks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	createHook := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		assert.NoError(t, ioutil.WriteFile(path, []byte("#!/bin/sh\n"+content+"\n"), 0755))
+		return path
+	}
+	buildInfo := []byte(`{"name":"build","number":"1"}`)
+
+	addTicket := createHook("add-ticket.sh", `cat > /dev/null; echo '{"name":"build","number":"1","properties":{"buildInfo.env.TICKET":"JIRA-1"},"ticket":{"key":"JIRA-1"}}'`)
+	patched, err := RunEnrichmentHook(addTicket, buildInfo)
+	assert.NoError(t, err)
+	// The patched content is returned as is.
+	assert.JSONEq(t, `{"name":"build","number":"1","properties":{"buildInfo.env.TICKET":"JIRA-1"},"ticket":{"key":"JIRA-1"}}`, string(patched))
+
+	failing := createHook("failing.sh", "echo 'no ticket found' >&2; exit 1")
+	_, err = RunEnrichmentHook(failing, buildInfo)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no ticket found")
+	}
+
+	invalidJson := createHook("invalid-json.sh", "echo 'not json'")
+	_, err = RunEnrichmentHook(invalidJson, buildInfo)
+	assert.Error(t, err)
+
+	array := createHook("array.sh", "echo '[]'")
+	_, err = RunEnrichmentHook(array, buildInfo)
+	assert.Error(t, err)
+
+	renaming := createHook("renaming.sh", `echo '{"name":"other","number":"1"}'`)
+	_, err = RunEnrichmentHook(renaming, buildInfo)
+	assert.Error(t, err)
+}
@@ -0,0 +1,256 @@
+package buildinfo
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Runs a command while tracing the files it opens, and records the files read from the input directories
+// as build dependencies and the files written to the output directories as build artifacts.
+type BuildTraceCommand struct {
+	buildConfiguration *utils.BuildConfiguration
+	args               []string
+	inputDirs          []string
+	outputDirs         []string
+}
+
+func NewBuildTraceCommand() *BuildTraceCommand {
+	return &BuildTraceCommand{}
+}
+
+func (btc *BuildTraceCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildTraceCommand {
+	btc.buildConfiguration = buildConfiguration
+	return btc
+}
+
+func (btc *BuildTraceCommand) SetArgs(args []string) *BuildTraceCommand {
+	btc.args = args
+	return btc
+}
+
+func (btc *BuildTraceCommand) SetInputDirs(inputDirs []string) *BuildTraceCommand {
+	btc.inputDirs = inputDirs
+	return btc
+}
+
+func (btc *BuildTraceCommand) SetOutputDirs(outputDirs []string) *BuildTraceCommand {
+	btc.outputDirs = outputDirs
+	return btc
+}
+
+func (btc *BuildTraceCommand) CommandName() string {
+	return "rt_build_trace"
+}
+
+func (btc *BuildTraceCommand) ServerDetails() (*config.ServerDetails, error) {
+	return config.GetDefaultServerConf()
+}
+
+func (btc *BuildTraceCommand) Run() error {
+	if len(btc.args) == 0 {
+		return errorutils.CheckError(errors.New("no command to trace was provided"))
+	}
+	inputDirs, err := toAbsDirs(btc.inputDirs)
+	if err != nil {
+		return err
+	}
+	outputDirs, err := toAbsDirs(btc.outputDirs)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Running and tracing:", strings.Join(btc.args, " "))
+	accesses := newFileAccesses()
+	if err = traceCommand(btc.args, accesses.add); err != nil {
+		return err
+	}
+
+	dependenciesPaths, artifactsPaths := accesses.classify(inputDirs, outputDirs)
+	moduleId := btc.buildConfiguration.Module
+	if moduleId == "" {
+		moduleId = btc.buildConfiguration.BuildName
+	}
+	module, err := createTracedModule(moduleId, dependenciesPaths, artifactsPaths, inputDirs, outputDirs)
+	if err != nil {
+		return err
+	}
+	log.Info("Recording", strconv.Itoa(len(module.Dependencies)), "dependencies and", strconv.Itoa(len(module.Artifacts)), "artifacts.")
+	if len(module.Dependencies) == 0 && len(module.Artifacts) == 0 {
+		return nil
+	}
+	if err = utils.SaveBuildGeneralDetails(btc.buildConfiguration.BuildName, btc.buildConfiguration.BuildNumber, btc.buildConfiguration.Project); err != nil {
+		return err
+	}
+	return utils.SaveBuildInfo(btc.buildConfiguration.BuildName, btc.buildConfiguration.BuildNumber, btc.buildConfiguration.Project, &buildinfo.BuildInfo{Modules: []buildinfo.Module{*module}})
+}
+
+// The kind of access a traced process performed on a file.
+type accessType int
+
+const (
+	readAccess accessType = iota
+	writeAccess
+)
+
+type fileAccess struct {
+	path       string
+	accessType accessType
+}
+
+// The files accessed by the traced command and its child processes, by their absolute path.
+type fileAccesses struct {
+	read    map[string]bool
+	written map[string]bool
+}
+
+func newFileAccesses() *fileAccesses {
+	return &fileAccesses{read: make(map[string]bool), written: make(map[string]bool)}
+}
+
+func (fa *fileAccesses) add(access fileAccess) {
+	path := filepath.Clean(access.path)
+	if access.accessType == writeAccess {
+		fa.written[path] = true
+	} else {
+		fa.read[path] = true
+	}
+}
+
+// Returns the paths of the files read from the input directories and the paths of the files written to the output directories.
+// A file which was written by the command is never considered as a dependency, even if it was also read.
+// Files which do not exist anymore, such as temporary files, are dropped.
+func (fa *fileAccesses) classify(inputDirs, outputDirs []string) (dependencies, artifacts []string) {
+	for path := range fa.read {
+		if !fa.written[path] && isUnderDirs(path, inputDirs) && isRegularFile(path) {
+			dependencies = append(dependencies, path)
+		}
+	}
+	for path := range fa.written {
+		if isUnderDirs(path, outputDirs) && isRegularFile(path) {
+			artifacts = append(artifacts, path)
+		}
+	}
+	sort.Strings(dependencies)
+	sort.Strings(artifacts)
+	return
+}
+
+func isUnderDirs(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRegularFile(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+func toAbsDirs(dirs []string) ([]string, error) {
+	var absDirs []string
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		absDir, err := filepath.Abs(dir)
+		if errorutils.CheckError(err) != nil {
+			return nil, err
+		}
+		// Symlinks are resolved, because the paths reported by the tracer are resolved by the kernel.
+		if resolved, err := filepath.EvalSymlinks(absDir); err == nil {
+			absDir = resolved
+		}
+		absDirs = append(absDirs, absDir)
+	}
+	return absDirs, nil
+}
+
+// Creates the build-info module of the traced files, with the sha256 checksums of the files as module hashes.
+// The dependencies are identified by their paths relative to the input directories, and the artifacts by their paths
+// relative to the output directories.
+func createTracedModule(moduleId string, dependenciesPaths, artifactsPaths, inputDirs, outputDirs []string) (*buildinfo.Module, error) {
+	module := &buildinfo.Module{Id: moduleId, Type: buildinfo.Generic}
+	sha256s := make(projectutils.ModuleHashes)
+	ids := make(map[string]bool)
+	for _, path := range dependenciesPaths {
+		checksum, sha256Checksum, err := calcTracedFileChecksums(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Adding dependency:", path)
+		id := relativeToDirs(path, inputDirs)
+		if ids[id] {
+			// A file with the same relative path in another input directory.
+			id = filepath.ToSlash(path)
+		}
+		ids[id] = true
+		dependency := buildinfo.Dependency{
+			Id:       id,
+			Checksum: checksum,
+		}
+		sha256s.Add("sha256", dependency.Id, sha256Checksum)
+		module.Dependencies = append(module.Dependencies, dependency)
+	}
+	for _, path := range artifactsPaths {
+		checksum, sha256Checksum, err := calcTracedFileChecksums(path)
+		if err != nil {
+			return nil, err
+		}
+		log.Debug("Adding artifact:", path)
+		artifact := buildinfo.Artifact{
+			Name:     filepath.Base(path),
+			Path:     relativeToDirs(path, outputDirs),
+			Checksum: checksum,
+		}
+		sha256s.Add("sha256", artifact.Path, sha256Checksum)
+		module.Artifacts = append(module.Artifacts, artifact)
+	}
+	sha256s.SetProperties(module)
+	return module, nil
+}
+
+// Returns the sha1 and md5 checksums of the file, along with its sha256 checksum, which fileutils doesn't calculate.
+func calcTracedFileChecksums(path string) (checksum *buildinfo.Checksum, sha256Checksum string, err error) {
+	file, err := os.Open(path)
+	if errorutils.CheckError(err) != nil {
+		return
+	}
+	defer file.Close()
+	md5Hash, sha1Hash, sha256Hash := md5.New(), sha1.New(), sha256.New()
+	if _, err = io.Copy(io.MultiWriter(md5Hash, sha1Hash, sha256Hash), file); errorutils.CheckError(err) != nil {
+		return
+	}
+	checksum = &buildinfo.Checksum{Sha1: hex.EncodeToString(sha1Hash.Sum(nil)), Md5: hex.EncodeToString(md5Hash.Sum(nil))}
+	return checksum, hex.EncodeToString(sha256Hash.Sum(nil)), nil
+}
+
+// Returns the path relative to the first directory containing it, in a slash separated form.
+func relativeToDirs(path string, dirs []string) string {
+	for _, dir := range dirs {
+		if isUnderDirs(path, []string{dir}) {
+			if rel, err := filepath.Rel(dir, path); err == nil {
+				return filepath.ToSlash(rel)
+			}
+		}
+	}
+	return filepath.ToSlash(path)
+}
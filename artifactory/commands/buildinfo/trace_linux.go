@@ -0,0 +1,218 @@
+//go:build linux && (amd64 || arm64)
+// +build linux
+// +build amd64 arm64
+
+package buildinfo
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"syscall"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	atFdCwd     = -100
+	maxPathSize = 4096
+)
+
+// A traced syscall, which was entered and not exited yet.
+type pendingSyscall struct {
+	accesses []fileAccess
+}
+
+// Runs the command with ptrace, and reports every file successfully opened or renamed by the command and its child processes.
+func traceCommand(args []string, report func(access fileAccess)) error {
+	// All ptrace requests must be sent from the thread which started the traced process.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	cmd.SysProcAttr = &syscall.SysProcAttr{Ptrace: true}
+	if err := cmd.Start(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	rootPid := cmd.Process.Pid
+
+	// The process stops with SIGTRAP right after exec.
+	var status syscall.WaitStatus
+	if _, err := syscall.Wait4(rootPid, &status, syscall.WALL, nil); err != nil {
+		return errorutils.CheckError(err)
+	}
+	options := syscall.PTRACE_O_TRACESYSGOOD | syscall.PTRACE_O_TRACECLONE | syscall.PTRACE_O_TRACEFORK | syscall.PTRACE_O_TRACEVFORK | syscall.PTRACE_O_TRACEEXEC
+	if err := syscall.PtraceSetOptions(rootPid, options); err != nil {
+		_ = cmd.Process.Kill()
+		return errorutils.CheckError(errors.New("failed to trace the command: " + err.Error()))
+	}
+	if err := syscall.PtraceSyscall(rootPid, 0); err != nil {
+		return errorutils.CheckError(err)
+	}
+
+	// The traced processes, the processes which are currently inside a syscall, and the traced syscalls they're in.
+	known := map[int]bool{rootPid: true}
+	inSyscall := make(map[int]bool)
+	pending := make(map[int]*pendingSyscall)
+	rootExitCode := 0
+	for {
+		pid, err := syscall.Wait4(-1, &status, syscall.WALL, nil)
+		if err == syscall.ECHILD {
+			break
+		}
+		if err == syscall.EINTR {
+			continue
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if status.Exited() || status.Signaled() {
+			delete(known, pid)
+			delete(inSyscall, pid)
+			delete(pending, pid)
+			if pid == rootPid {
+				if status.Exited() {
+					rootExitCode = status.ExitStatus()
+				} else {
+					rootExitCode = 128 + int(status.Signal())
+				}
+			}
+			continue
+		}
+		if !status.Stopped() {
+			continue
+		}
+		newProcess := !known[pid]
+		known[pid] = true
+		signal := 0
+		switch stopSignal := status.StopSignal(); {
+		case stopSignal == syscall.SIGTRAP|0x80:
+			if inSyscall[pid] {
+				inSyscall[pid] = false
+				if call, exists := pending[pid]; exists {
+					delete(pending, pid)
+					reportSyscallExit(pid, call, report)
+				}
+			} else {
+				inSyscall[pid] = true
+				if call := readSyscallEntry(pid); call != nil {
+					pending[pid] = call
+				}
+			}
+		case stopSignal == syscall.SIGTRAP:
+			// A fork, clone or exec event. New processes are traced automatically.
+			if status.TrapCause() == syscall.PTRACE_EVENT_EXEC {
+				inSyscall[pid] = true
+			}
+		case stopSignal == syscall.SIGSTOP && newProcess:
+			// A new child process stops with SIGSTOP once it's attached. The signal is suppressed.
+		default:
+			signal = int(stopSignal)
+		}
+		// The process may have been killed in the meantime, in which case its exit is reported by the next wait.
+		_ = syscall.PtraceSyscall(pid, signal)
+	}
+	if rootExitCode != 0 {
+		return errorutils.CheckError(errors.New("the traced command exited with code " + strconv.Itoa(rootExitCode)))
+	}
+	return nil
+}
+
+// Reads the arguments of a syscall on its entry. Returns nil if the syscall doesn't access files.
+func readSyscallEntry(pid int) *pendingSyscall {
+	var regs syscall.PtraceRegs
+	if err := syscall.PtraceGetRegs(pid, &regs); err != nil {
+		return nil
+	}
+	number, args := syscallArgs(&regs)
+	var accesses []fileAccess
+	switch number {
+	case sysOpen:
+		accesses = openAccess(pid, atFdCwd, args[0], args[1])
+	case sysCreat:
+		accesses = pathAccess(pid, atFdCwd, args[0], writeAccess)
+	case sysOpenat:
+		accesses = openAccess(pid, int(int32(args[0])), args[1], args[2])
+	case sysRename:
+		accesses = pathAccess(pid, atFdCwd, args[1], writeAccess)
+	case sysRenameat, sysRenameat2:
+		accesses = pathAccess(pid, int(int32(args[2])), args[3], writeAccess)
+	}
+	if len(accesses) == 0 {
+		return nil
+	}
+	return &pendingSyscall{accesses: accesses}
+}
+
+// Reports the accessed files if the syscall succeeded.
+func reportSyscallExit(pid int, call *pendingSyscall, report func(access fileAccess)) {
+	var regs syscall.PtraceRegs
+	if err := syscall.PtraceGetRegs(pid, &regs); err != nil {
+		return
+	}
+	if syscallReturnValue(&regs) < 0 {
+		return
+	}
+	for _, access := range call.accesses {
+		report(access)
+	}
+}
+
+func openAccess(pid, dirFd int, pathAddr, flags uint64) []fileAccess {
+	accessType := readAccess
+	if int(flags)&(syscall.O_WRONLY|syscall.O_RDWR|syscall.O_CREAT|syscall.O_TRUNC) != 0 {
+		accessType = writeAccess
+	}
+	if int(flags)&syscall.O_DIRECTORY != 0 {
+		return nil
+	}
+	return pathAccess(pid, dirFd, pathAddr, accessType)
+}
+
+func pathAccess(pid, dirFd int, pathAddr uint64, accessType accessType) []fileAccess {
+	path, err := readTraceeString(pid, uintptr(pathAddr))
+	if err != nil || path == "" {
+		return nil
+	}
+	if !filepath.IsAbs(path) {
+		baseDir, err := resolveTraceeDir(pid, dirFd)
+		if err != nil {
+			log.Debug("Couldn't resolve the relative path", path, "of process", strconv.Itoa(pid)+":", err.Error())
+			return nil
+		}
+		path = filepath.Join(baseDir, path)
+	}
+	return []fileAccess{{path: path, accessType: accessType}}
+}
+
+// Returns the working directory of the process, or the directory opened by the file descriptor.
+func resolveTraceeDir(pid, dirFd int) (string, error) {
+	procDir := "/proc/" + strconv.Itoa(pid)
+	if dirFd == atFdCwd {
+		return os.Readlink(procDir + "/cwd")
+	}
+	return os.Readlink(procDir + "/fd/" + strconv.Itoa(dirFd))
+}
+
+// Reads a NUL terminated string from the memory of the traced process.
+func readTraceeString(pid int, addr uintptr) (string, error) {
+	var result []byte
+	chunk := make([]byte, 256)
+	for len(result) < maxPathSize {
+		count, err := syscall.PtracePeekData(pid, addr+uintptr(len(result)), chunk)
+		if index := bytes.IndexByte(chunk[:count], 0); index >= 0 {
+			return string(append(result, chunk[:index]...)), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		result = append(result, chunk[:count]...)
+	}
+	return "", errors.New("path exceeds the maximum path size")
+}
@@ -0,0 +1,20 @@
+package buildinfo
+
+import "syscall"
+
+const (
+	sysOpen      = 2
+	sysRename    = 82
+	sysCreat     = 85
+	sysOpenat    = 257
+	sysRenameat  = 264
+	sysRenameat2 = 316
+)
+
+func syscallArgs(regs *syscall.PtraceRegs) (number uint64, args [4]uint64) {
+	return regs.Orig_rax, [4]uint64{regs.Rdi, regs.Rsi, regs.Rdx, regs.R10}
+}
+
+func syscallReturnValue(regs *syscall.PtraceRegs) int64 {
+	return int64(regs.Rax)
+}
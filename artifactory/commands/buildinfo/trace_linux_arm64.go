@@ -0,0 +1,21 @@
+package buildinfo
+
+import "syscall"
+
+// The legacy open, rename and creat syscalls don't exist on arm64, so they get numbers which are never used.
+const (
+	sysOpen      = 1 << 32
+	sysRename    = 1<<32 + 1
+	sysCreat     = 1<<32 + 2
+	sysOpenat    = 56
+	sysRenameat  = 38
+	sysRenameat2 = 276
+)
+
+func syscallArgs(regs *syscall.PtraceRegs) (number uint64, args [4]uint64) {
+	return regs.Regs[8], [4]uint64{regs.Regs[0], regs.Regs[1], regs.Regs[2], regs.Regs[3]}
+}
+
+func syscallReturnValue(regs *syscall.PtraceRegs) int64 {
+	return int64(regs.Regs[0])
+}
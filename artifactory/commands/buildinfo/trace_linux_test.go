@@ -0,0 +1,38 @@
+//go:build linux && (amd64 || arm64)
+// +build linux
+// +build amd64 arm64
+
+package buildinfo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceCommand(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "build-trace")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	tempDir, err = filepath.EvalSymlinks(tempDir)
+	assert.NoError(t, err)
+	input, output := filepath.Join(tempDir, "input.txt"), filepath.Join(tempDir, "output.txt")
+	assert.NoError(t, ioutil.WriteFile(input, []byte("content"), 0644))
+
+	accesses := newFileAccesses()
+	err = traceCommand([]string{"sh", "-c", "cd " + tempDir + " && cat input.txt > output.txt"}, accesses.add)
+	if err != nil && strings.Contains(err.Error(), "failed to trace") {
+		t.Skip("ptrace is not permitted in this environment:", err)
+	}
+	assert.NoError(t, err)
+	assert.True(t, accesses.read[input])
+	assert.True(t, accesses.written[output])
+	assert.False(t, accesses.read[output])
+
+	// The exit code of a failing command is reported.
+	assert.Error(t, traceCommand([]string{"sh", "-c", "exit 3"}, accesses.add))
+}
@@ -0,0 +1,71 @@
+package buildinfo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyFileAccesses(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "build-trace")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	inputDir, outputDir := filepath.Join(tempDir, "src"), filepath.Join(tempDir, "out")
+	for _, path := range []string{filepath.Join(inputDir, "main.c"), filepath.Join(inputDir, "lib", "main.c"), filepath.Join(inputDir, "gen.h"), filepath.Join(outputDir, "bin", "app"), filepath.Join(tempDir, "other.txt")} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, []byte(path), 0644))
+	}
+
+	accesses := newFileAccesses()
+	accesses.add(fileAccess{path: filepath.Join(inputDir, "main.c"), accessType: readAccess})
+	accesses.add(fileAccess{path: filepath.Join(inputDir, "lib", "main.c"), accessType: readAccess})
+	// Generated during the build, so it's not a dependency.
+	accesses.add(fileAccess{path: filepath.Join(inputDir, "gen.h"), accessType: writeAccess})
+	accesses.add(fileAccess{path: filepath.Join(inputDir, "gen.h"), accessType: readAccess})
+	// Outside the input directories.
+	accesses.add(fileAccess{path: filepath.Join(tempDir, "other.txt"), accessType: readAccess})
+	// Removed during the build.
+	accesses.add(fileAccess{path: filepath.Join(outputDir, "tmp.o"), accessType: writeAccess})
+	accesses.add(fileAccess{path: filepath.Join(outputDir, "bin", "..", "bin", "app"), accessType: writeAccess})
+
+	dependencies, artifacts := accesses.classify([]string{inputDir}, []string{outputDir})
+	assert.Equal(t, []string{filepath.Join(inputDir, "lib", "main.c"), filepath.Join(inputDir, "main.c")}, dependencies)
+	assert.Equal(t, []string{filepath.Join(outputDir, "bin", "app")}, artifacts)
+
+	module, err := createTracedModule("app", dependencies, artifacts, []string{inputDir}, []string{outputDir})
+	if !assert.NoError(t, err) {
+		return
+	}
+	// Files with the same name are identified by their paths relative to the input directory.
+	if assert.Len(t, module.Dependencies, 2) {
+		assert.Equal(t, "lib/main.c", module.Dependencies[0].Id)
+		assert.Equal(t, "main.c", module.Dependencies[1].Id)
+	}
+	assert.Len(t, module.Artifacts, 1)
+	assert.Equal(t, "app", module.Artifacts[0].Name)
+	assert.Equal(t, "bin/app", module.Artifacts[0].Path)
+	assert.NotEmpty(t, module.Artifacts[0].Sha1)
+	// The sha256 of the content, which is the path of the file.
+	sha256s := module.Properties.(map[string]string)
+	assert.Equal(t, sha256Hex(filepath.Join(inputDir, "main.c")), sha256s["sha256.main.c"])
+	assert.Equal(t, sha256Hex(filepath.Join(inputDir, "lib", "main.c")), sha256s["sha256.lib/main.c"])
+	assert.Equal(t, sha256Hex(filepath.Join(outputDir, "bin", "app")), sha256s["sha256.bin/app"])
+}
+
+func TestIsUnderDirs(t *testing.T) {
+	dirs := []string{filepath.FromSlash("/work/src")}
+	assert.True(t, isUnderDirs(filepath.FromSlash("/work/src/a.c"), dirs))
+	assert.True(t, isUnderDirs(filepath.FromSlash("/work/src"), dirs))
+	assert.False(t, isUnderDirs(filepath.FromSlash("/work/src2/a.c"), dirs))
+	assert.False(t, isUnderDirs(filepath.FromSlash("/work/a.c"), dirs))
+}
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
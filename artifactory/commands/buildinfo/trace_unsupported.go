@@ -0,0 +1,14 @@
+//go:build !linux || (!amd64 && !arm64)
+// +build !linux !amd64,!arm64
+
+package buildinfo
+
+import (
+	"errors"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+func traceCommand(args []string, report func(access fileAccess)) error {
+	return errorutils.CheckError(errors.New("the build-trace command is supported on Linux amd64 and arm64 only"))
+}
@@ -0,0 +1,61 @@
+package buildtools
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// A module kept in the build-info, with the artifacts the properties of the module are set on.
+type FilteredModule struct {
+	Id        string
+	Artifacts []buildinfo.Artifact
+}
+
+// Removes the modules, which aren't included by the configuration, from a build-info file generated by the Maven or
+// Gradle extractor. The rest of the file is kept as written by the extractor. Returns the kept modules and the IDs
+// of the removed ones.
+func FilterGeneratedBuildInfo(buildInfoPath string, modulesConfig *ModulesConfig) (kept []FilteredModule, excluded []string, err error) {
+	content, err := ioutil.ReadFile(buildInfoPath)
+	if err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	var buildInfo map[string]json.RawMessage
+	if err = json.Unmarshal(content, &buildInfo); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	var modules []json.RawMessage
+	if rawModules, exists := buildInfo["modules"]; exists {
+		if err = json.Unmarshal(rawModules, &modules); err != nil {
+			return nil, nil, errorutils.CheckError(err)
+		}
+	}
+	var keptModules []json.RawMessage
+	for _, rawModule := range modules {
+		var module FilteredModule
+		if err = json.Unmarshal(rawModule, &module); err != nil {
+			return nil, nil, errorutils.CheckError(err)
+		}
+		if !modulesConfig.IsIncluded(module.Id) {
+			excluded = append(excluded, module.Id)
+			continue
+		}
+		kept = append(kept, module)
+		keptModules = append(keptModules, rawModule)
+	}
+	if len(excluded) == 0 {
+		return kept, nil, nil
+	}
+	if keptModules == nil {
+		keptModules = []json.RawMessage{}
+	}
+	if buildInfo["modules"], err = json.Marshal(keptModules); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	if content, err = json.Marshal(buildInfo); err != nil {
+		return nil, nil, errorutils.CheckError(err)
+	}
+	return kept, excluded, errorutils.CheckError(ioutil.WriteFile(buildInfoPath, content, 0644))
+}
@@ -0,0 +1,214 @@
+package buildtools
+
+import (
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/common/commands"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The options of the mvn and gradle commands, which override the include and exclude patterns of the configuration file.
+const (
+	IncludeModulesFlag = "--include-modules"
+	ExcludeModulesFlag = "--exclude-modules"
+)
+
+// The keys of the deployment repositories in the Maven and Gradle configuration files.
+var deployRepoKeys = []string{"deployer.repo", "deployer.releaseRepo", "deployer.snapshotRepo"}
+
+// Runs the mvn or gradle command of jfrog-cli-core, and then applies the modules section of the configuration file
+// to the build-info generated by the extractor: the modules, which aren't included, are removed from the build-info,
+// and the properties of every kept module are set on its deployed artifacts.
+// The modules are known from the generated build-info only, so nothing is done unless the build name and number are set.
+// The extractor deploys the artifacts of all of the modules, so the artifacts of the excluded modules are still deployed.
+type ModulesCommand struct {
+	commands.Command
+	configPath         string
+	buildConfiguration *utils.BuildConfiguration
+	includeModules     []string
+	excludeModules     []string
+}
+
+func NewModulesCommand(buildToolCommand commands.Command) *ModulesCommand {
+	return &ModulesCommand{Command: buildToolCommand}
+}
+
+func (mc *ModulesCommand) SetConfigPath(configPath string) *ModulesCommand {
+	mc.configPath = configPath
+	return mc
+}
+
+func (mc *ModulesCommand) SetConfiguration(buildConfiguration *utils.BuildConfiguration) *ModulesCommand {
+	mc.buildConfiguration = buildConfiguration
+	return mc
+}
+
+// Semicolon-separated patterns, which replace the include patterns of the configuration file.
+func (mc *ModulesCommand) SetIncludeModules(includeModules string) *ModulesCommand {
+	mc.includeModules = splitPatterns(includeModules)
+	return mc
+}
+
+// Semicolon-separated patterns, which replace the exclude patterns of the configuration file.
+func (mc *ModulesCommand) SetExcludeModules(excludeModules string) *ModulesCommand {
+	mc.excludeModules = splitPatterns(excludeModules)
+	return mc
+}
+
+func (mc *ModulesCommand) Run() error {
+	modulesConfig, err := ReadModulesConfig(mc.configPath)
+	if err != nil {
+		return err
+	}
+	if mc.includeModules != nil {
+		modulesConfig.Include = mc.includeModules
+	}
+	if mc.excludeModules != nil {
+		modulesConfig.Exclude = mc.excludeModules
+	}
+	if modulesConfig.IsEmpty() {
+		return mc.Command.Run()
+	}
+	if mc.buildConfiguration.BuildName == "" || mc.buildConfiguration.BuildNumber == "" {
+		log.Warn("The modules configuration is applied to the build-info only. Use the --build-name and --build-number options to apply it.")
+		return mc.Command.Run()
+	}
+	buildDir, err := utils.GetBuildDir(mc.buildConfiguration.BuildName, mc.buildConfiguration.BuildNumber, mc.buildConfiguration.Project)
+	if err != nil {
+		return err
+	}
+	existingFiles, err := listFiles(buildDir)
+	if err != nil {
+		return err
+	}
+	if err = mc.Command.Run(); err != nil {
+		return err
+	}
+	generatedFiles, err := listFiles(buildDir)
+	if err != nil {
+		return err
+	}
+	var kept []FilteredModule
+	for buildInfoPath := range generatedFiles {
+		if existingFiles[buildInfoPath] || !strings.HasPrefix(filepath.Base(buildInfoPath), utils.GENERATED_BUILD_INFO_TEMP_PREFIX) {
+			continue
+		}
+		keptModules, excluded, err := FilterGeneratedBuildInfo(buildInfoPath, modulesConfig)
+		if err != nil {
+			return err
+		}
+		if len(excluded) > 0 {
+			log.Info("Excluded the following modules from the build-info. Their artifacts are deployed, if the build deploys them:\n" + strings.Join(excluded, "\n"))
+		}
+		kept = append(kept, keptModules...)
+	}
+	return mc.setModulesProps(modulesConfig, kept)
+}
+
+// Sets the properties of every module on its artifacts, which are found in the deployment repositories by their paths,
+// names and checksums, so that the artifacts of other versions of the module, which have the same content, are left out.
+func (mc *ModulesCommand) setModulesProps(modulesConfig *ModulesConfig, modules []FilteredModule) error {
+	modulesProps := make(map[string]string)
+	for _, module := range modules {
+		if props := modulesConfig.PropertiesFor(module.Id); props != "" && len(module.Artifacts) > 0 {
+			modulesProps[module.Id] = props
+		}
+	}
+	if len(modulesProps) == 0 {
+		return nil
+	}
+	vConfig, err := utils.ReadConfigFile(mc.configPath, utils.YAML)
+	if err != nil {
+		return err
+	}
+	serverDetails, err := utils.GetServerDetails(vConfig)
+	if err != nil || serverDetails == nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	repos := make(map[string]bool)
+	for _, key := range deployRepoKeys {
+		if repo := vConfig.GetString(key); repo != "" {
+			repos[repo] = true
+		}
+	}
+	for _, module := range modules {
+		props, exists := modulesProps[module.Id]
+		if !exists {
+			continue
+		}
+		var conditions []string
+		for _, artifact := range module.Artifacts {
+			if artifact.Checksum == nil || artifact.Sha1 == "" {
+				continue
+			}
+			criteria := map[string]string{"name": artifact.Name, "actual_sha1": artifact.Sha1}
+			if artifactDir := getArtifactDir(module.Id, artifact); artifactDir != "" {
+				criteria["path"] = artifactDir
+			}
+			condition, err := json.Marshal(criteria)
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			conditions = append(conditions, string(condition))
+		}
+		if len(conditions) == 0 {
+			continue
+		}
+		var items []projectutils.AqlItem
+		for repo := range repos {
+			found, err := projectutils.FindItems(servicesManager, repo, conditions)
+			if err != nil {
+				return err
+			}
+			items = append(items, found...)
+		}
+		if len(items) == 0 {
+			log.Debug("No deployed artifacts of module", module.Id, "were found. Its properties are not set.")
+			continue
+		}
+		log.Info("Setting the properties", props, "on", strconv.Itoa(len(items)), "artifacts of module", module.Id+".")
+		if err = projectutils.SetProps(servicesManager, items, props); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the directory of the deployed artifact in the repository. It's taken from the path of the artifact, if the
+// extractor records it. Otherwise, it's derived from the group:artifact:version module ID, in the Maven repository layout.
+// An empty string is returned for other module IDs.
+func getArtifactDir(moduleId string, artifact buildinfo.Artifact) string {
+	if artifact.Path != "" {
+		return path.Dir(artifact.Path)
+	}
+	gav := strings.Split(moduleId, ":")
+	if len(gav) < 3 {
+		return ""
+	}
+	return path.Join(strings.Replace(gav[0], ".", "/", -1), gav[1], gav[2])
+}
+
+func listFiles(dir string) (map[string]bool, error) {
+	files, err := fileutils.ListFiles(dir, false)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool)
+	for _, file := range files {
+		result[file] = true
+	}
+	return result, nil
+}
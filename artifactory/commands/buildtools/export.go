@@ -0,0 +1,314 @@
+package buildtools
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+// The environment variables, which the exported files read the credentials from, unless the credentials are included.
+const (
+	UserEnvVar     = "ARTIFACTORY_USER"
+	PasswordEnvVar = "ARTIFACTORY_PASSWORD"
+)
+
+const (
+	DefaultSettingsPath   = "settings.xml"
+	DefaultInitScriptPath = "artifactory.init.gradle"
+)
+
+// A repository of the exported Maven settings or Gradle init script, with the credentials of its server, if included.
+// If the credentials are empty, they are read from the environment variables.
+type exportedRepository struct {
+	id       string
+	url      string
+	username string
+	password string
+}
+
+// Exports the resolver and deployer of the configuration to settings, which read the credentials from the environment
+// variables. If includeCredentials is set, the credentials of the servers in the CLI configuration are written instead,
+// unless a server has none.
+type exporter struct {
+	configFile         *commandUtils.ConfigFile
+	includeCredentials bool
+}
+
+func newExporter(configFilePath string, includeCredentials bool) (*exporter, error) {
+	content, err := ioutil.ReadFile(configFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	configFile := &commandUtils.ConfigFile{}
+	if err = yaml.Unmarshal(content, configFile); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to parse " + configFilePath + ": " + err.Error()))
+	}
+	if configFile.Resolver.ServerId == "" && configFile.Deployer.ServerId == "" {
+		return nil, errorutils.CheckError(errors.New(configFilePath + " has neither a resolver nor a deployer to export"))
+	}
+	if includeCredentials {
+		log.Warn("The credentials of the configured servers are written to the exported file in plain text.")
+	}
+	return &exporter{configFile: configFile, includeCredentials: includeCredentials}, nil
+}
+
+// Returns the repository of the server, or nil if the server or the repository aren't set.
+func (e *exporter) repository(id, serverId, repo string) (*exportedRepository, error) {
+	if serverId == "" || repo == "" {
+		return nil, nil
+	}
+	serverDetails, err := config.GetSpecificConfig(serverId, false, true)
+	if err != nil {
+		return nil, err
+	}
+	repository := &exportedRepository{id: id, url: serverDetails.GetArtifactoryUrl() + repo}
+	if !e.includeCredentials {
+		return repository, nil
+	}
+	if repository.username, repository.password, err = projectutils.GetBasicAuthCredentials(serverDetails); err != nil {
+		return nil, err
+	}
+	if repository.username == "" || repository.password == "" {
+		log.Warn("The server '" + serverId + "' has no username and password or access token. The credentials of " + id +
+			" are read from the " + UserEnvVar + " and " + PasswordEnvVar + " environment variables.")
+		repository.username, repository.password = "", ""
+	}
+	return repository, nil
+}
+
+// Writes a Maven settings.xml, which mirrors all the repositories, including Maven Central, by the release resolution
+// repository of the Maven configuration. The snapshot resolution repository is added by an active profile, and mirrored
+// separately. The deployment repositories are set as the alternative deployment repositories of the maven-deploy-plugin.
+func ExportMavenSettings(configFilePath, settingsPath string, includeCredentials bool) error {
+	e, err := newExporter(configFilePath, includeCredentials)
+	if err != nil {
+		return err
+	}
+	resolver, deployer := e.configFile.Resolver, e.configFile.Deployer
+	var repositories []*exportedRepository
+	for _, repo := range []struct{ id, serverId, repo string }{
+		{"artifactory", resolver.ServerId, resolver.ReleaseRepo},
+		{"artifactory-snapshots-resolve", resolver.ServerId, resolver.SnapshotRepo},
+		{"artifactory-releases", deployer.ServerId, deployer.ReleaseRepo},
+		{"artifactory-snapshots", deployer.ServerId, deployer.SnapshotRepo},
+	} {
+		repository, err := e.repository(repo.id, repo.serverId, repo.repo)
+		if err != nil {
+			return err
+		}
+		repositories = append(repositories, repository)
+	}
+	return writeExportedFile(settingsPath, createMavenSettings(repositories[0], repositories[1], repositories[2], repositories[3]),
+		"Use it by 'mvn -s "+settingsPath+"', or copy it to ~/.m2/settings.xml.")
+}
+
+func createMavenSettings(release, snapshot, deployRelease, deploySnapshot *exportedRepository) string {
+	var content strings.Builder
+	content.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!-- Generated by JFrog CLI. -->
+<settings xmlns="http://maven.apache.org/SETTINGS/1.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xsi:schemaLocation="http://maven.apache.org/SETTINGS/1.0.0 http://maven.apache.org/xsd/settings-1.0.0.xsd">
+  <servers>
+`)
+	for _, repository := range []*exportedRepository{release, snapshot, deployRelease, deploySnapshot} {
+		if repository == nil {
+			continue
+		}
+		username, password := "${env."+UserEnvVar+"}", "${env."+PasswordEnvVar+"}"
+		if repository.username != "" {
+			username, password = escapeXml(repository.username), escapeXml(repository.password)
+		}
+		content.WriteString(`    <server>
+      <id>` + repository.id + `</id>
+      <username>` + username + `</username>
+      <password>` + password + `</password>
+    </server>
+`)
+	}
+	content.WriteString("  </servers>\n")
+	if release != nil || snapshot != nil {
+		content.WriteString("  <mirrors>\n")
+		if release != nil {
+			// The snapshot repository of the profile is excluded, so that it is mirrored by the snapshot resolution repository.
+			mirrorOf := "*"
+			if snapshot != nil {
+				mirrorOf = "*,!" + snapshot.id
+			}
+			content.WriteString(mavenMirror(release, mirrorOf))
+		}
+		if snapshot != nil {
+			content.WriteString(mavenMirror(snapshot, snapshot.id))
+		}
+		content.WriteString("  </mirrors>\n")
+	}
+	content.WriteString(`  <profiles>
+    <profile>
+      <id>artifactory</id>
+`)
+	if deployRelease != nil || deploySnapshot != nil {
+		content.WriteString("      <properties>\n")
+		if deployRelease != nil {
+			content.WriteString("        <altReleaseDeploymentRepository>" + deployRelease.id + "::default::" + escapeXml(deployRelease.url) + "</altReleaseDeploymentRepository>\n")
+		}
+		if deploySnapshot != nil {
+			content.WriteString("        <altSnapshotDeploymentRepository>" + deploySnapshot.id + "::default::" + escapeXml(deploySnapshot.url) + "</altSnapshotDeploymentRepository>\n")
+		}
+		content.WriteString("      </properties>\n")
+	}
+	if snapshot != nil {
+		// Maven Central has no snapshots, so the snapshot resolution repository is added for the dependencies and plugins.
+		for _, element := range []struct{ list, repository string }{{"repositories", "repository"}, {"pluginRepositories", "pluginRepository"}} {
+			content.WriteString("      <" + element.list + ">\n")
+			content.WriteString(mavenSnapshotRepository(element.repository, snapshot))
+			content.WriteString("      </" + element.list + ">\n")
+		}
+	}
+	content.WriteString(`    </profile>
+  </profiles>
+  <activeProfiles>
+    <activeProfile>artifactory</activeProfile>
+  </activeProfiles>
+</settings>
+`)
+	return content.String()
+}
+
+func mavenMirror(repository *exportedRepository, mirrorOf string) string {
+	return `    <mirror>
+      <id>` + repository.id + `</id>
+      <mirrorOf>` + mirrorOf + `</mirrorOf>
+      <url>` + escapeXml(repository.url) + `</url>
+    </mirror>
+`
+}
+
+func mavenSnapshotRepository(element string, repository *exportedRepository) string {
+	return `        <` + element + `>
+          <id>` + repository.id + `</id>
+          <url>` + escapeXml(repository.url) + `</url>
+          <releases>
+            <enabled>false</enabled>
+          </releases>
+          <snapshots>
+            <enabled>true</enabled>
+          </snapshots>
+        </` + element + `>
+`
+}
+
+// Writes a Gradle init script, which resolves the dependencies of all the projects and the plugins from the resolution
+// repository of the Gradle configuration. The deployment repository is added to the publications of the projects,
+// which apply the maven-publish plugin, by the name 'artifactory'.
+func ExportGradleInitScript(configFilePath, scriptPath string, includeCredentials bool) error {
+	e, err := newExporter(configFilePath, includeCredentials)
+	if err != nil {
+		return err
+	}
+	resolver, err := e.repository("resolver", e.configFile.Resolver.ServerId, e.configFile.Resolver.Repo)
+	if err != nil {
+		return err
+	}
+	deployer, err := e.repository("artifactory", e.configFile.Deployer.ServerId, e.configFile.Deployer.Repo)
+	if err != nil {
+		return err
+	}
+	return writeExportedFile(scriptPath, createGradleInitScript(resolver, deployer),
+		"Use it by 'gradle --init-script "+scriptPath+"', or copy it to ~/.gradle/init.d/.")
+}
+
+func createGradleInitScript(resolver, deployer *exportedRepository) string {
+	var content strings.Builder
+	content.WriteString("// Generated by JFrog CLI.\n")
+	if resolver != nil {
+		content.WriteString(`settingsEvaluated { settings ->
+    settings.pluginManagement {
+        repositories {
+` + gradleRepository(resolver, "            ") + `        }
+    }
+}
+
+allprojects {
+    buildscript {
+        repositories {
+` + gradleRepository(resolver, "            ") + `        }
+    }
+    repositories {
+` + gradleRepository(resolver, "        ") + `    }
+}
+`)
+	}
+	if deployer != nil {
+		if resolver != nil {
+			content.WriteString("\n")
+		}
+		content.WriteString(`allprojects {
+    plugins.withId('maven-publish') {
+        publishing {
+            repositories {
+` + gradleRepository(deployer, "                ") + `            }
+        }
+    }
+}
+`)
+	}
+	return content.String()
+}
+
+func gradleRepository(repository *exportedRepository, indent string) string {
+	username, password := "System.getenv('"+UserEnvVar+"')", "System.getenv('"+PasswordEnvVar+"')"
+	if repository.username != "" {
+		username, password = groovyString(repository.username), groovyString(repository.password)
+	}
+	lines := []string{
+		"maven {",
+		"    name = " + groovyString(repository.id),
+		"    url = " + groovyString(repository.url),
+		"    credentials {",
+		"        username = " + username,
+		"        password = " + password,
+		"    }",
+		"}",
+	}
+	return indent + strings.Join(lines, "\n"+indent) + "\n"
+}
+
+func groovyString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+func escapeXml(value string) string {
+	var escaped bytes.Buffer
+	// Writing to a buffer never fails.
+	_ = xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
+
+// Writes the exported file, which may include credentials, readable by the user only.
+// The permissions of an existing file are restricted before it is overwritten, since they are kept by the write.
+func writeExportedFile(path, content, usage string) error {
+	exists, err := fileutils.IsFileExists(path, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err = os.Chmod(path, 0600); err != nil {
+			return errorutils.CheckError(err)
+		}
+	}
+	if err = ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("Exported the configuration to " + path + ". " + usage)
+	return nil
+}
@@ -0,0 +1,144 @@
+package buildtools
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMavenSettings(t *testing.T) {
+	release := &exportedRepository{id: "artifactory", url: "https://acme.jfrog.io/artifactory/maven-virtual", username: "admin", password: "p&ss<word>"}
+	snapshot := &exportedRepository{id: "artifactory-snapshots-resolve", url: "https://acme.jfrog.io/artifactory/maven-snapshots"}
+	deployRelease := &exportedRepository{id: "artifactory-releases", url: "https://acme.jfrog.io/artifactory/libs-release-local", username: "admin", password: "secret"}
+
+	type mavenSettings struct {
+		Servers []struct {
+			Id       string `xml:"id"`
+			Username string `xml:"username"`
+			Password string `xml:"password"`
+		} `xml:"servers>server"`
+		Mirrors []struct {
+			Id       string `xml:"id"`
+			MirrorOf string `xml:"mirrorOf"`
+			Url      string `xml:"url"`
+		} `xml:"mirrors>mirror"`
+		Profile struct {
+			AltRelease   string `xml:"properties>altReleaseDeploymentRepository"`
+			AltSnapshot  string `xml:"properties>altSnapshotDeploymentRepository"`
+			Repositories []struct {
+				Id               string `xml:"id"`
+				Url              string `xml:"url"`
+				SnapshotsEnabled bool   `xml:"snapshots>enabled"`
+			} `xml:"repositories>repository"`
+			PluginRepositories []struct {
+				Id string `xml:"id"`
+			} `xml:"pluginRepositories>pluginRepository"`
+		} `xml:"profiles>profile"`
+		ActiveProfiles []string `xml:"activeProfiles>activeProfile"`
+	}
+	var parsed mavenSettings
+	if !assert.NoError(t, xml.Unmarshal([]byte(createMavenSettings(release, snapshot, deployRelease, nil)), &parsed)) {
+		return
+	}
+	if assert.Len(t, parsed.Servers, 3) {
+		assert.Equal(t, "p&ss<word>", parsed.Servers[0].Password)
+		// The credentials of a repository without them are read from the environment.
+		assert.Equal(t, "${env.ARTIFACTORY_USER}", parsed.Servers[1].Username)
+		assert.Equal(t, "${env.ARTIFACTORY_PASSWORD}", parsed.Servers[1].Password)
+	}
+	// All the repositories are mirrored by the release repository, except for the snapshot repository.
+	if assert.Len(t, parsed.Mirrors, 2) {
+		assert.Equal(t, "artifactory", parsed.Mirrors[0].Id)
+		assert.Equal(t, "*,!artifactory-snapshots-resolve", parsed.Mirrors[0].MirrorOf)
+		assert.Equal(t, "https://acme.jfrog.io/artifactory/maven-virtual", parsed.Mirrors[0].Url)
+		assert.Equal(t, "artifactory-snapshots-resolve", parsed.Mirrors[1].MirrorOf)
+	}
+	assert.Equal(t, "artifactory-releases::default::https://acme.jfrog.io/artifactory/libs-release-local", parsed.Profile.AltRelease)
+	assert.Empty(t, parsed.Profile.AltSnapshot)
+	if assert.Len(t, parsed.Profile.Repositories, 1) {
+		assert.Equal(t, "https://acme.jfrog.io/artifactory/maven-snapshots", parsed.Profile.Repositories[0].Url)
+		assert.True(t, parsed.Profile.Repositories[0].SnapshotsEnabled)
+	}
+	assert.Len(t, parsed.Profile.PluginRepositories, 1)
+	assert.Equal(t, []string{"artifactory"}, parsed.ActiveProfiles)
+
+	parsed = mavenSettings{}
+	if assert.NoError(t, xml.Unmarshal([]byte(createMavenSettings(release, nil, nil, nil)), &parsed)) && assert.Len(t, parsed.Mirrors, 1) {
+		assert.Equal(t, "*", parsed.Mirrors[0].MirrorOf)
+		assert.Empty(t, parsed.Profile.Repositories)
+	}
+}
+
+func TestCreateGradleInitScript(t *testing.T) {
+	resolver := &exportedRepository{id: "resolver", url: "https://acme.jfrog.io/artifactory/gradle-virtual", username: "admin", password: `it's\secret`}
+	deployer := &exportedRepository{id: "artifactory", url: "https://acme.jfrog.io/artifactory/gradle-local"}
+	script := createGradleInitScript(resolver, deployer)
+	assert.Contains(t, script, "settings.pluginManagement {")
+	assert.Contains(t, script, `            maven {
+                name = 'resolver'
+                url = 'https://acme.jfrog.io/artifactory/gradle-virtual'
+                credentials {
+                    username = 'admin'
+                    password = 'it\'s\\secret'
+                }
+            }`)
+	assert.Contains(t, script, `    plugins.withId('maven-publish') {
+        publishing {
+            repositories {
+                maven {
+                    name = 'artifactory'
+                    url = 'https://acme.jfrog.io/artifactory/gradle-local'
+                    credentials {
+                        username = System.getenv('ARTIFACTORY_USER')
+                        password = System.getenv('ARTIFACTORY_PASSWORD')
+                    }
+                }
+            }
+        }
+    }`)
+	// The resolver is used for the build scripts, the plugins and the dependencies of the projects.
+	assert.Equal(t, 3, strings.Count(script, "name = 'resolver'"))
+
+	assert.NotContains(t, createGradleInitScript(nil, deployer), "pluginManagement")
+}
+
+func TestNewExporter(t *testing.T) {
+	e, err := newExporter(filepath.Join("testdata", "gradle.yaml"), true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "gradle-virtual", e.configFile.Resolver.Repo)
+		assert.Equal(t, "gradle-local", e.configFile.Deployer.Repo)
+	}
+
+	tempDir, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer fileutils.RemoveTempDir(tempDir)
+	emptyConfigPath := filepath.Join(tempDir, "maven.yaml")
+	assert.NoError(t, ioutil.WriteFile(emptyConfigPath, []byte("version: 1\ntype: maven\n"), 0644))
+	_, err = newExporter(emptyConfigPath, false)
+	assert.Error(t, err)
+}
+
+func TestWriteExportedFile(t *testing.T) {
+	tempDir, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer fileutils.RemoveTempDir(tempDir)
+	path := filepath.Join(tempDir, "settings.xml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("old"), 0644))
+
+	// The permissions of an existing file are restricted as well.
+	assert.NoError(t, writeExportedFile(path, "new", ""))
+	info, err := os.Stat(path)
+	if assert.NoError(t, err) && runtime.GOOS != "windows" {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+	content, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+}
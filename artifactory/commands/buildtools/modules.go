@@ -0,0 +1,227 @@
+package buildtools
+
+import (
+	"errors"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+// The key of the modules section in the mvn-config and gradle-config files.
+const modulesKey = "modules"
+
+// The modules section of the Maven and Gradle configuration files, which selects the modules recorded in the build-info
+// and the properties set on the artifacts of each module. For example:
+//
+//	modules:
+//	  include: ["org.acme:*"]
+//	  exclude: ["*:integration-tests"]
+//	  properties:
+//	    "org.acme:api": team=platform;tier=1
+//	  propertiesFile: modules-props.yaml
+//
+// The patterns are matched against the module IDs, with or without their versions, and may include the * and ? wildcards.
+// The properties file maps patterns to properties in the same form as the properties section, and its path is relative
+// to the directory of the configuration file.
+type ModulesConfig struct {
+	Include        []string      `yaml:"include,omitempty"`
+	Exclude        []string      `yaml:"exclude,omitempty"`
+	Properties     yaml.MapSlice `yaml:"properties,omitempty"`
+	PropertiesFile string        `yaml:"propertiesFile,omitempty"`
+	// The properties of the properties section followed by the ones of the properties file, in the order they are listed.
+	modulesProps []modulePatternProps
+}
+
+type modulePatternProps struct {
+	pattern string
+	props   *serviceutils.Properties
+}
+
+// Creates the modules configuration from semicolon-separated include and exclude patterns and a module properties file.
+func NewModulesConfig(includeModules, excludeModules, propertiesFile string) *ModulesConfig {
+	return &ModulesConfig{Include: splitPatterns(includeModules), Exclude: splitPatterns(excludeModules), PropertiesFile: propertiesFile}
+}
+
+// Reads the modules section of the configuration file. If the section is missing, an empty configuration is returned.
+func ReadModulesConfig(configFilePath string) (*ModulesConfig, error) {
+	content, err := ioutil.ReadFile(configFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var configFile struct {
+		Modules ModulesConfig `yaml:"modules"`
+	}
+	if err = yaml.Unmarshal(content, &configFile); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	modulesConfig := &configFile.Modules
+	if err = modulesConfig.addProperties(modulesConfig.Properties, configFilePath); err != nil {
+		return nil, err
+	}
+	if modulesConfig.PropertiesFile != "" {
+		propertiesFile := modulesConfig.PropertiesFile
+		if !filepath.IsAbs(propertiesFile) {
+			propertiesFile = filepath.Join(filepath.Dir(configFilePath), propertiesFile)
+		}
+		if err = modulesConfig.readPropertiesFile(propertiesFile); err != nil {
+			return nil, err
+		}
+	}
+	return modulesConfig, nil
+}
+
+func (mc *ModulesConfig) readPropertiesFile(propertiesFile string) error {
+	content, err := ioutil.ReadFile(propertiesFile)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var properties yaml.MapSlice
+	if err = yaml.Unmarshal(content, &properties); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return mc.addProperties(properties, propertiesFile)
+}
+
+func (mc *ModulesConfig) addProperties(properties yaml.MapSlice, source string) error {
+	for _, item := range properties {
+		pattern, isString := item.Key.(string)
+		propsStr, isValueString := item.Value.(string)
+		if !isString || !isValueString {
+			return errorutils.CheckError(errors.New("the module properties in " + source + " must map module patterns to properties in the form of key1=value1;key2=value2"))
+		}
+		props, err := serviceutils.ParseProperties(propsStr)
+		if err != nil {
+			return err
+		}
+		mc.modulesProps = append(mc.modulesProps, modulePatternProps{pattern: pattern, props: props})
+	}
+	return nil
+}
+
+// Returns true if the configuration has any effect on the build-info.
+func (mc *ModulesConfig) IsEmpty() bool {
+	return len(mc.Include) == 0 && len(mc.Exclude) == 0 && len(mc.modulesProps) == 0
+}
+
+// Returns true if the module should be recorded in the build-info. If include patterns are set, the module must match one of them.
+func (mc *ModulesConfig) IsIncluded(moduleId string) bool {
+	if len(mc.Include) > 0 && !matchesAny(moduleId, mc.Include) {
+		return false
+	}
+	return !matchesAny(moduleId, mc.Exclude)
+}
+
+// Returns the properties of the module, in the form of key1=value1;key2=value2, or an empty string if no pattern matches it.
+// When the module matches several patterns, the properties of all of them are set, and a key set by a later pattern
+// overrides the value set by an earlier one.
+func (mc *ModulesConfig) PropertiesFor(moduleId string) string {
+	merged := make(map[string][]string)
+	for _, patternProps := range mc.modulesProps {
+		if !matches(moduleId, patternProps.pattern) {
+			continue
+		}
+		for key, values := range patternProps.props.ToMap() {
+			merged[key] = values
+		}
+	}
+	var props []string
+	for key, values := range merged {
+		var escapedValues []string
+		for _, value := range values {
+			escapedValues = append(escapedValues, strings.Replace(value, ",", "\\,", -1))
+		}
+		props = append(props, key+"="+strings.Join(escapedValues, ","))
+	}
+	sort.Strings(props)
+	return strings.Join(props, ";")
+}
+
+func matchesAny(moduleId string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matches(moduleId, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches the pattern against the module ID, and against the module ID without its version, which is its last part.
+func matches(moduleId, pattern string) bool {
+	if matched, _ := path.Match(pattern, moduleId); matched {
+		return true
+	}
+	if i := strings.LastIndex(moduleId, ":"); i > 0 {
+		matched, _ := path.Match(pattern, moduleId[:i])
+		return matched
+	}
+	return false
+}
+
+func splitPatterns(patterns string) []string {
+	result := []string{}
+	for _, pattern := range strings.Split(patterns, ";") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			result = append(result, pattern)
+		}
+	}
+	return result
+}
+
+// Adds the modules section to the configuration file, or replaces the existing one. The path of the properties file,
+// which is relative to the working directory, is written relative to the directory of the configuration file.
+func WriteModulesConfig(configFilePath string, modulesConfig *ModulesConfig) error {
+	for _, pattern := range append(append([]string{}, modulesConfig.Include...), modulesConfig.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errorutils.CheckError(errors.New("invalid module pattern '" + pattern + "': " + err.Error()))
+		}
+	}
+	if modulesConfig.PropertiesFile != "" {
+		if err := modulesConfig.readPropertiesFile(modulesConfig.PropertiesFile); err != nil {
+			return err
+		}
+		propertiesFile, err := getRelativePath(configFilePath, modulesConfig.PropertiesFile)
+		if err != nil {
+			return err
+		}
+		modulesConfig.PropertiesFile = propertiesFile
+	}
+	content, err := ioutil.ReadFile(configFilePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	var configFile yaml.MapSlice
+	if err = yaml.Unmarshal(content, &configFile); err != nil {
+		return errorutils.CheckError(err)
+	}
+	var updated yaml.MapSlice
+	for _, item := range configFile {
+		if item.Key != modulesKey {
+			updated = append(updated, item)
+		}
+	}
+	updated = append(updated, yaml.MapItem{Key: modulesKey, Value: modulesConfig})
+	if content, err = yaml.Marshal(updated); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(configFilePath, content, 0644))
+}
+
+// Returns the path of the file relative to the directory of the configuration file.
+func getRelativePath(configFilePath, filePath string) (string, error) {
+	configDir, err := filepath.Abs(filepath.Dir(configFilePath))
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	relativePath, err := filepath.Rel(configDir, absPath)
+	return filepath.ToSlash(relativePath), errorutils.CheckError(err)
+}
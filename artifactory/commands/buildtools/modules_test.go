@@ -0,0 +1,105 @@
+package buildtools
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func readTestModulesConfig(t *testing.T) *ModulesConfig {
+	modulesConfig, err := ReadModulesConfig(filepath.Join("testdata", "maven.yaml"))
+	assert.NoError(t, err)
+	return modulesConfig
+}
+
+func TestIsIncluded(t *testing.T) {
+	modulesConfig := readTestModulesConfig(t)
+	assert.True(t, modulesConfig.IsIncluded("org.acme:api:2.0.0"))
+	assert.False(t, modulesConfig.IsIncluded("org.acme:integration-tests:2.0.0"))
+	assert.False(t, modulesConfig.IsIncluded("com.other:lib:1.0.0"))
+	// Without include patterns, every module, which isn't excluded, is included.
+	assert.True(t, NewModulesConfig("", "*:integration-tests", "").IsIncluded("com.other:lib:1.0.0"))
+}
+
+func TestPropertiesFor(t *testing.T) {
+	modulesConfig := readTestModulesConfig(t)
+	// The team set by the org.acme:api pattern overrides the one set by the earlier org.acme:* pattern.
+	assert.Equal(t, "release=2;team=api;tier=1", modulesConfig.PropertiesFor("org.acme:api:2.0.0"))
+	assert.Equal(t, "team=platform", modulesConfig.PropertiesFor("org.acme:core:1.0.0"))
+	assert.Empty(t, modulesConfig.PropertiesFor("com.other:lib:1.0.0"))
+}
+
+func TestFilterGeneratedBuildInfo(t *testing.T) {
+	tempDir, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer fileutils.RemoveTempDir(tempDir)
+	content, err := ioutil.ReadFile(filepath.Join("testdata", "generatedBuildInfo.json"))
+	assert.NoError(t, err)
+	buildInfoPath := filepath.Join(tempDir, "generatedBuildInfo")
+	assert.NoError(t, ioutil.WriteFile(buildInfoPath, content, 0644))
+
+	kept, excluded, err := FilterGeneratedBuildInfo(buildInfoPath, readTestModulesConfig(t))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"org.acme:integration-tests:2.0.0", "com.other:lib:1.0.0"}, excluded)
+	if assert.Len(t, kept, 1) && assert.Len(t, kept[0].Artifacts, 1) {
+		assert.Equal(t, "api-2.0.0.jar", kept[0].Artifacts[0].Name)
+		assert.Equal(t, "a1", kept[0].Artifacts[0].Sha1)
+	}
+
+	content, err = ioutil.ReadFile(buildInfoPath)
+	assert.NoError(t, err)
+	var buildInfo struct {
+		Properties map[string]string `json:"properties"`
+		Modules    []struct {
+			Id string `json:"id"`
+		} `json:"modules"`
+	}
+	assert.NoError(t, json.Unmarshal(content, &buildInfo))
+	// The rest of the build-info is kept as generated.
+	assert.Equal(t, "ci", buildInfo.Properties["buildInfo.env.USER"])
+	if assert.Len(t, buildInfo.Modules, 1) {
+		assert.Equal(t, "org.acme:api:2.0.0", buildInfo.Modules[0].Id)
+	}
+}
+
+func TestWriteModulesConfig(t *testing.T) {
+	tempDir, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer fileutils.RemoveTempDir(tempDir)
+	configFilePath := filepath.Join(tempDir, "gradle.yaml")
+	assert.NoError(t, ioutil.WriteFile(configFilePath, []byte("version: 1\ntype: gradle\nmodules:\n  exclude:\n  - old\n"), 0644))
+
+	assert.NoError(t, WriteModulesConfig(configFilePath, NewModulesConfig("org.acme:*; org.acme.tools:*", "", "")))
+	modulesConfig, err := ReadModulesConfig(configFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"org.acme:*", "org.acme.tools:*"}, modulesConfig.Include)
+	// The existing modules section is replaced.
+	assert.Empty(t, modulesConfig.Exclude)
+
+	assert.Error(t, WriteModulesConfig(configFilePath, NewModulesConfig("[", "", "")))
+}
+
+func TestGetArtifactDir(t *testing.T) {
+	assert.Equal(t, "org/acme/api/2.0.0", getArtifactDir("org.acme:api:2.0.0", buildinfo.Artifact{Name: "api-2.0.0.jar"}))
+	assert.Equal(t, "org/acme/api/2.0.0-SNAPSHOT", getArtifactDir("org.acme:api:2.0.0", buildinfo.Artifact{Name: "api.jar", Path: "org/acme/api/2.0.0-SNAPSHOT/api.jar"}))
+	assert.Empty(t, getArtifactDir("api", buildinfo.Artifact{Name: "api.jar"}))
+}
+
+func TestWriteModulesConfigPropertiesFile(t *testing.T) {
+	tempDir, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer fileutils.RemoveTempDir(tempDir)
+	configFilePath := filepath.Join(tempDir, "maven.yaml")
+	assert.NoError(t, ioutil.WriteFile(configFilePath, []byte("version: 1\ntype: maven\n"), 0644))
+
+	// The properties file is passed relative to the working directory, and read relative to the configuration file.
+	assert.NoError(t, WriteModulesConfig(configFilePath, NewModulesConfig("", "", filepath.Join("testdata", "modules-props.yaml"))))
+	modulesConfig, err := ReadModulesConfig(configFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "release=2", modulesConfig.PropertiesFor("org.acme:api:2.0.0"))
+}
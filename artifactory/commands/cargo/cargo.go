@@ -0,0 +1,391 @@
+package cargo
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/version"
+)
+
+const (
+	ModuleType buildinfo.ModuleType = "cargo"
+	// The names of the registries, through which Cargo resolves the crates from Artifactory and publishes them.
+	resolutionRegistry = "artifactory"
+	deploymentRegistry = "artifactory-publish"
+	cargoLockFile      = "Cargo.lock"
+	cargoTomlFile      = "Cargo.toml"
+	// The registry.global-credential-providers key, through which the tokens are passed to Cargo, was added in Cargo 1.74.
+	minSupportedCargoVersion = "1.74.0"
+)
+
+// The path of the Cargo configuration file of the project, to which 'jfrog rt cargo-config --export-cargo-config' writes the registries.
+var DefaultConfigPath = filepath.Join(".cargo", "config.toml")
+
+// Runs Cargo with the crates resolved from Artifactory, and 'cargo publish' with the crates published to Artifactory.
+// The registries are passed to Cargo with the --config option, so the Cargo configuration of the project is never changed.
+// Cargo 1.74 or above is required.
+// If the build name and number are passed, the crates locked in Cargo.lock are recorded as the build-info dependencies,
+// and the published .crate file as the build-info artifact.
+type CargoCommand struct {
+	resolverDetails *config.ServerDetails
+	resolverRepo    string
+	deployerDetails *config.ServerDetails
+	deployerRepo    string
+	args            []string
+}
+
+func NewCargoCommand() *CargoCommand {
+	return &CargoCommand{}
+}
+
+func (cc *CargoCommand) SetResolver(serverDetails *config.ServerDetails, repo string) *CargoCommand {
+	cc.resolverDetails, cc.resolverRepo = serverDetails, repo
+	return cc
+}
+
+func (cc *CargoCommand) SetDeployer(serverDetails *config.ServerDetails, repo string) *CargoCommand {
+	cc.deployerDetails, cc.deployerRepo = serverDetails, repo
+	return cc
+}
+
+func (cc *CargoCommand) SetArgs(args []string) *CargoCommand {
+	cc.args = args
+	return cc
+}
+
+func (cc *CargoCommand) ServerDetails() (*config.ServerDetails, error) {
+	if cc.resolverDetails != nil {
+		return cc.resolverDetails, nil
+	}
+	return cc.deployerDetails, nil
+}
+
+func (cc *CargoCommand) CommandName() string {
+	return "rt_cargo"
+}
+
+func (cc *CargoCommand) Run() error {
+	args, buildConfiguration, err := utils.ExtractBuildDetailsFromArgs(cc.args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errorutils.CheckError(errors.New("a cargo command is required"))
+	}
+	// A rustup toolchain override, such as +nightly, must be the first argument.
+	var toolchain []string
+	if strings.HasPrefix(args[0], "+") {
+		toolchain, args = args[:1], args[1:]
+	}
+	isPublish := len(args) > 0 && args[0] == "publish"
+	if isPublish {
+		if cc.deployerDetails == nil {
+			return errorutils.CheckError(errors.New("no deployment repository is configured. Run 'jfrog rt cargo-config' with the deployer options"))
+		}
+		if !projectutils.HasOption(args, "--registry") {
+			args = append(args, "--registry", deploymentRegistry)
+		}
+	}
+	cargoArgs, env, err := cc.createConfig()
+	if err != nil {
+		return err
+	}
+	if err = checkCargoVersion(toolchain); err != nil {
+		return err
+	}
+	if err = runCargo(append(append(toolchain, cargoArgs...), args...), env); err != nil {
+		return err
+	}
+	if buildConfiguration.BuildName == "" || buildConfiguration.BuildNumber == "" {
+		return nil
+	}
+	moduleId, err := getModuleId(buildConfiguration)
+	if err != nil {
+		return err
+	}
+	if err = cc.collectDependencies(moduleId, buildConfiguration); err != nil {
+		return err
+	}
+	if isPublish {
+		return cc.collectPublishedCrate(moduleId, buildConfiguration)
+	}
+	return nil
+}
+
+type registry struct {
+	name          string
+	serverDetails *config.ServerDetails
+	repo          string
+}
+
+// Returns the configured registries, through which the crates are resolved and published.
+func (cc *CargoCommand) getRegistries() (registries []registry) {
+	if cc.resolverDetails != nil {
+		registries = append(registries, registry{resolutionRegistry, cc.resolverDetails, cc.resolverRepo})
+	}
+	if cc.deployerDetails != nil {
+		registries = append(registries, registry{deploymentRegistry, cc.deployerDetails, cc.deployerRepo})
+	}
+	return
+}
+
+// Returns the --config options, which define the Artifactory registries, and the environment variables with their tokens.
+// The resolution registry replaces crates.io, so all of the crates are resolved from Artifactory.
+func (cc *CargoCommand) createConfig() (args, env []string, err error) {
+	env = os.Environ()
+	for _, registry := range cc.getRegistries() {
+		token, err := getToken(registry.serverDetails)
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, "--config", fmt.Sprintf("registries.%s.index=%q", registry.name, getIndexUrl(registry.serverDetails, registry.repo)))
+		env = append(env, getTokenEnvVar(registry.name)+"="+token)
+	}
+	if len(args) > 0 {
+		args = append(args, "--config", `registry.global-credential-providers=["cargo:token"]`)
+	}
+	if cc.resolverDetails != nil {
+		args = append(args, "--config", fmt.Sprintf("source.crates-io.replace-with=%q", resolutionRegistry))
+	}
+	return
+}
+
+// Writes the Artifactory registries to a Cargo configuration file, so that Cargo can be run without the JFrog CLI.
+// The tokens aren't written to the file. Cargo reads them from the environment variables, which are listed in the output.
+// An existing file isn't overwritten.
+func (cc *CargoCommand) ExportConfig(exportPath string) error {
+	registries := cc.getRegistries()
+	if len(registries) == 0 {
+		return errorutils.CheckError(errors.New("no repositories are configured. Run 'jfrog rt cargo-config' with the resolver or deployer options"))
+	}
+	exists, err := fileutils.IsFileExists(exportPath, false)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errorutils.CheckError(fmt.Errorf("the file %s already exists. Add the following to it, or pass a different path with the --export-path option:\n%s", exportPath, createConfigToml(registries, cc.resolverDetails != nil)))
+	}
+	if err = os.MkdirAll(filepath.Dir(exportPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = ioutil.WriteFile(exportPath, []byte(createConfigToml(registries, cc.resolverDetails != nil)), 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info("The Cargo configuration was written to " + exportPath + ". Cargo 1.74 or above reads the tokens of the registries from the following environment variables:")
+	for _, registry := range registries {
+		log.Info("  " + getTokenEnvVar(registry.name))
+	}
+	return nil
+}
+
+// Returns the content of a Cargo configuration file, which defines the registries.
+func createConfigToml(registries []registry, replaceCratesIo bool) string {
+	content := "[registry]\nglobal-credential-providers = [\"cargo:token\"]\n"
+	for _, registry := range registries {
+		content += fmt.Sprintf("\n[registries.%s]\nindex = %q\n", registry.name, getIndexUrl(registry.serverDetails, registry.repo))
+	}
+	if replaceCratesIo {
+		content += fmt.Sprintf("\n[source.crates-io]\nreplace-with = %q\n", resolutionRegistry)
+	}
+	return content
+}
+
+// Returns the name of the environment variable, from which Cargo reads the token of the registry.
+func getTokenEnvVar(registryName string) string {
+	return "CARGO_REGISTRIES_" + strings.ToUpper(strings.Replace(registryName, "-", "_", -1)) + "_TOKEN"
+}
+
+func getIndexUrl(serverDetails *config.ServerDetails, repo string) string {
+	return "sparse+" + serverDetails.GetArtifactoryUrl() + "api/cargo/" + repo + "/index/"
+}
+
+// Returns the value of the Authorization header Cargo sends to Artifactory.
+func getToken(serverDetails *config.ServerDetails) (string, error) {
+	if serverDetails.GetAccessToken() != "" {
+		return "Bearer " + serverDetails.GetAccessToken(), nil
+	}
+	username, password, err := projectutils.GetBasicAuthCredentials(serverDetails)
+	if err != nil {
+		return "", err
+	}
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+}
+
+// Returns an error if the version of Cargo is below the minimal version, which supports the registry.global-credential-providers key.
+func checkCargoVersion(toolchain []string) error {
+	cargoPath, err := getCargoPath()
+	if err != nil {
+		return err
+	}
+	output, err := exec.Command(cargoPath, append(toolchain, "--version")...).Output()
+	if err != nil {
+		return errorutils.CheckError(fmt.Errorf("failed to get the version of cargo: %s", err.Error()))
+	}
+	cargoVersion := parseCargoVersion(string(output))
+	if cargoVersion == "" {
+		log.Debug("Couldn't parse the version of cargo: " + string(output))
+		return nil
+	}
+	if !version.NewVersion(cargoVersion).AtLeast(minSupportedCargoVersion) {
+		return errorutils.CheckError(fmt.Errorf("this command requires cargo %s or above. The current version is %s", minSupportedCargoVersion, cargoVersion))
+	}
+	return nil
+}
+
+// Returns the version in the output of 'cargo --version', such as 'cargo 1.74.0 (ecb9851af 2023-10-18)', without the pre-release suffix.
+func parseCargoVersion(output string) string {
+	fields := strings.Fields(output)
+	if len(fields) < 2 || fields[0] != "cargo" {
+		return ""
+	}
+	return strings.SplitN(fields[1], "-", 2)[0]
+}
+
+func getCargoPath() (string, error) {
+	cargoPath, err := exec.LookPath("cargo")
+	if err != nil {
+		return "", errorutils.CheckError(errors.New("could not find the 'cargo' executable in the system PATH"))
+	}
+	return cargoPath, nil
+}
+
+func runCargo(args, env []string) error {
+	cargoPath, err := getCargoPath()
+	if err != nil {
+		return err
+	}
+	log.Info("Running cargo " + strings.Join(args, " "))
+	cmd := exec.Command(cargoPath, args...)
+	cmd.Env = env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
+
+func (cc *CargoCommand) collectDependencies(moduleId string, buildConfiguration *utils.BuildConfiguration) error {
+	exists, err := fileutils.IsFileExists(cargoLockFile, false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Info("No", cargoLockFile, "file was found. No dependencies are added to the build-info.")
+		return nil
+	}
+	packages, err := ReadCargoLock(cargoLockFile)
+	if err != nil {
+		return err
+	}
+	foundFiles := make(map[string]*projectutils.FoundFile)
+	if cc.resolverDetails != nil {
+		if foundFiles, err = findCrates(cc.resolverDetails, cc.resolverRepo, packages); err != nil {
+			return err
+		}
+	}
+	dependencies, missing := createDependencies(packages, moduleId, foundFiles)
+	if len(missing) > 0 {
+		log.Warn("The following crates were not found in Artifactory, and are added to the build-info without checksums:\n" + strings.Join(missing, "\n"))
+	}
+	log.Info("Adding", strconv.Itoa(len(dependencies)), "dependencies to module", moduleId, "of the build-info.")
+	return projectutils.SaveDependencies(buildConfiguration, ModuleType, moduleId, dependencies)
+}
+
+func findCrates(serverDetails *config.ServerDetails, repo string, packages []*LockedPackage) (map[string]*projectutils.FoundFile, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, false)
+	if err != nil {
+		return nil, err
+	}
+	searchRepo, err := utils.GetRepoNameForDependenciesSearch(repo, servicesManager)
+	if err != nil {
+		return nil, err
+	}
+	var sha256s []string
+	for _, pkg := range packages {
+		if pkg.Checksum != "" {
+			sha256s = append(sha256s, pkg.Checksum)
+		}
+	}
+	return projectutils.FindFilesBySha256(servicesManager, searchRepo, sha256s)
+}
+
+// Creates the build-info dependencies of the crates downloaded from registries. The workspace members and the
+// crates taken from git or from the local file system are left out. The names of the crates which weren't found in Artifactory are returned.
+func createDependencies(packages []*LockedPackage, moduleId string, foundFiles map[string]*projectutils.FoundFile) (dependencies []buildinfo.Dependency, missing []string) {
+	requestedBy := RequestedBy(packages, moduleId)
+	for _, pkg := range packages {
+		if pkg.Checksum == "" {
+			continue
+		}
+		dependency := buildinfo.Dependency{Id: pkg.Id(), Type: "crate", RequestedBy: requestedBy[pkg.Id()]}
+		if file, exists := foundFiles[pkg.Checksum]; exists {
+			dependency.Checksum = file.Checksum
+		} else {
+			missing = append(missing, pkg.Name+" "+pkg.Version)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return
+}
+
+// Records the .crate file packaged by 'cargo publish' as the build-info artifact.
+// Artifactory stores the crates in the crates/<name>/<name>-<version>.crate layout.
+func (cc *CargoCommand) collectPublishedCrate(moduleId string, buildConfiguration *utils.BuildConfiguration) error {
+	name, version, err := ReadCargoTomlPackage(cargoTomlFile)
+	if err != nil {
+		return err
+	}
+	if name == "" || version == "" {
+		log.Warn("The name and version of the published package couldn't be read from " + cargoTomlFile + ". No artifacts are added to the build-info.")
+		return nil
+	}
+	crateName := name + "-" + version + ".crate"
+	targetDir := os.Getenv("CARGO_TARGET_DIR")
+	if targetDir == "" {
+		targetDir = "target"
+	}
+	details, err := fileutils.GetFileDetails(filepath.Join(targetDir, "package", crateName))
+	if err != nil {
+		return err
+	}
+	artifact := buildinfo.Artifact{
+		Name:     crateName,
+		Type:     "crate",
+		Path:     "crates/" + name + "/" + crateName,
+		Checksum: &buildinfo.Checksum{Sha1: details.Checksum.Sha1, Md5: details.Checksum.Md5},
+	}
+	return projectutils.SaveArtifacts(buildConfiguration, ModuleType, moduleId, []buildinfo.Artifact{artifact})
+}
+
+// Returns the module ID passed with the --module option, or the name and version of the package in Cargo.toml.
+// If neither is set, the build name is returned.
+func getModuleId(buildConfiguration *utils.BuildConfiguration) (string, error) {
+	if buildConfiguration.Module != "" {
+		return buildConfiguration.Module, nil
+	}
+	exists, err := fileutils.IsFileExists(cargoTomlFile, false)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		name, version, err := ReadCargoTomlPackage(cargoTomlFile)
+		if err != nil {
+			return "", err
+		}
+		if name != "" {
+			return name + ":" + version, nil
+		}
+	}
+	return buildConfiguration.BuildName, nil
+}
@@ -0,0 +1,44 @@
+package cargo
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCargoVersion(t *testing.T) {
+	assert.Equal(t, "1.74.0", parseCargoVersion("cargo 1.74.0 (ecb9851af 2023-10-18)\n"))
+	assert.Equal(t, "1.76.0", parseCargoVersion("cargo 1.76.0-nightly (abc123 2023-12-01)"))
+	assert.Equal(t, "", parseCargoVersion("error: no such toolchain"))
+}
+
+func TestExportConfig(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "cargo")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	serverDetails := &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "secret"}
+	cargoCmd := NewCargoCommand().SetResolver(serverDetails, "cargo-virtual").SetDeployer(serverDetails, "cargo-local")
+	exportPath := filepath.Join(tempDir, ".cargo", "config.toml")
+	assert.NoError(t, cargoCmd.ExportConfig(exportPath))
+	content, err := ioutil.ReadFile(exportPath)
+	assert.NoError(t, err)
+	assert.Equal(t, `[registry]
+global-credential-providers = ["cargo:token"]
+
+[registries.artifactory]
+index = "sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-virtual/index/"
+
+[registries.artifactory-publish]
+index = "sparse+https://acme.jfrog.io/artifactory/api/cargo/cargo-local/index/"
+
+[source.crates-io]
+replace-with = "artifactory"
+`, string(content))
+	// An existing file isn't overwritten.
+	assert.Error(t, cargoCmd.ExportConfig(exportPath))
+	assert.Error(t, NewCargoCommand().ExportConfig(filepath.Join(tempDir, "other.toml")))
+}
@@ -0,0 +1,128 @@
+package cargo
+
+import (
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/pelletier/go-toml"
+)
+
+// A package locked in Cargo.lock.
+type LockedPackage struct {
+	Name    string
+	Version string
+	// The source of the package. Empty for the packages of the workspace.
+	Source string
+	// The sha256 checksum of the .crate file. Empty for packages which aren't downloaded from a registry.
+	Checksum string
+	// The packages this package depends on.
+	Dependencies []*LockedPackage
+}
+
+func (pkg *LockedPackage) Id() string {
+	return pkg.Name + ":" + pkg.Version
+}
+
+func (pkg *LockedPackage) IsWorkspaceMember() bool {
+	return pkg.Source == ""
+}
+
+func ReadCargoLock(lockFilePath string) ([]*LockedPackage, error) {
+	content, err := ioutil.ReadFile(lockFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parseCargoLock(content)
+}
+
+// Parses Cargo.lock. The dependencies of a package are listed by the name of the package they refer to,
+// followed by the version and the source only if they are needed to tell apart packages with the same name.
+func parseCargoLock(content []byte) ([]*LockedPackage, error) {
+	tree, err := toml.LoadBytes(content)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	packageTrees, _ := tree.Get("package").([]*toml.Tree)
+	packages := make([]*LockedPackage, len(packageTrees))
+	byName := make(map[string][]*LockedPackage)
+	for i, packageTree := range packageTrees {
+		pkg := &LockedPackage{}
+		pkg.Name, _ = packageTree.Get("name").(string)
+		pkg.Version, _ = packageTree.Get("version").(string)
+		pkg.Source, _ = packageTree.Get("source").(string)
+		pkg.Checksum, _ = packageTree.Get("checksum").(string)
+		packages[i] = pkg
+		byName[pkg.Name] = append(byName[pkg.Name], pkg)
+	}
+	for i, packageTree := range packageTrees {
+		references, _ := packageTree.Get("dependencies").([]interface{})
+		for _, reference := range references {
+			referenceString, _ := reference.(string)
+			if dependency := resolveReference(referenceString, byName); dependency != nil {
+				packages[i].Dependencies = append(packages[i].Dependencies, dependency)
+			}
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Id() < packages[j].Id()
+	})
+	return packages, nil
+}
+
+// Resolves a dependency reference in the form of "<name>[ <version>[ (<source>)]]".
+func resolveReference(reference string, byName map[string][]*LockedPackage) *LockedPackage {
+	fields := strings.Fields(reference)
+	if len(fields) == 0 {
+		return nil
+	}
+	for _, pkg := range byName[fields[0]] {
+		if len(fields) == 1 || pkg.Version == fields[1] {
+			if len(fields) < 3 || strings.Trim(fields[2], "()") == pkg.Source {
+				return pkg
+			}
+		}
+	}
+	return nil
+}
+
+// Returns the paths from each of the registry packages to the root module, as expected by the requestedBy field
+// of the build-info dependencies. The workspace members are the roots of the dependency graph, and are represented
+// by the module ID in the paths.
+func RequestedBy(packages []*LockedPackage, moduleId string) map[string][][]string {
+	byId := make(map[string]*LockedPackage, len(packages))
+	var direct []string
+	for _, pkg := range packages {
+		byId[pkg.Id()] = pkg
+		if pkg.IsWorkspaceMember() {
+			direct = append(direct, registryDependencies(pkg)...)
+		}
+	}
+	return projectutils.RequestedBy(moduleId, direct, func(id string) []string {
+		return registryDependencies(byId[id])
+	})
+}
+
+// Returns the IDs of the dependencies of the package, which aren't workspace members.
+func registryDependencies(pkg *LockedPackage) []string {
+	var ids []string
+	for _, dependency := range pkg.Dependencies {
+		if !dependency.IsWorkspaceMember() {
+			ids = append(ids, dependency.Id())
+		}
+	}
+	return ids
+}
+
+// Returns the name and version of the package in Cargo.toml, or empty strings if it's a virtual workspace manifest.
+func ReadCargoTomlPackage(cargoTomlPath string) (name, version string, err error) {
+	tree, err := toml.LoadFile(cargoTomlPath)
+	if err != nil {
+		return "", "", errorutils.CheckError(err)
+	}
+	name, _ = tree.Get("package.name").(string)
+	version, _ = tree.Get("package.version").(string)
+	return
+}
@@ -0,0 +1,53 @@
+package cargo
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCargoLock(t *testing.T) {
+	packages, err := ReadCargoLock(filepath.Join("testdata", "Cargo.lock"))
+	assert.NoError(t, err)
+	if assert.Len(t, packages, 6) {
+		assert.Equal(t, "getrandom:0.2.10", packages[0].Id())
+		assert.Equal(t, "aaa", packages[0].Checksum)
+		assert.True(t, packages[2].IsWorkspaceMember())
+		if assert.Len(t, packages[3].Dependencies, 1) {
+			assert.Equal(t, "rand:0.7.3", packages[3].Dependencies[0].Id())
+		}
+	}
+}
+
+func TestCreateDependencies(t *testing.T) {
+	packages, err := ReadCargoLock(filepath.Join("testdata", "Cargo.lock"))
+	assert.NoError(t, err)
+	found := map[string]*projectutils.FoundFile{"bbb": {Name: "libc-0.2.147.crate", Checksum: &buildinfo.Checksum{Sha1: "1", Md5: "2"}}}
+	dependencies, missing := createDependencies(packages, "myapp:0.1.0", found)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "getrandom:0.2.10", Type: "crate", RequestedBy: [][]string{{"myapp:0.1.0"}, {"rand:0.8.5", "myapp:0.1.0"}}},
+		{Id: "libc:0.2.147", Type: "crate", RequestedBy: [][]string{{"getrandom:0.2.10", "myapp:0.1.0"}, {"myapp:0.1.0"}},
+			Checksum: &buildinfo.Checksum{Sha1: "1", Md5: "2"}},
+		{Id: "rand:0.7.3", Type: "crate", RequestedBy: [][]string{{"myapp:0.1.0"}}},
+		{Id: "rand:0.8.5", Type: "crate", RequestedBy: [][]string{{"myapp:0.1.0"}}},
+	}, dependencies)
+	assert.Equal(t, []string{"getrandom 0.2.10", "rand 0.7.3", "rand 0.8.5"}, missing)
+}
+
+func TestRequestedByManyDependents(t *testing.T) {
+	shared := &LockedPackage{Name: "shared", Version: "1.0.0", Source: "registry"}
+	member := &LockedPackage{Name: "myapp", Version: "0.1.0"}
+	packages := []*LockedPackage{member, shared}
+	for i := 0; i < 2*projectutils.MaxRequestedByPaths; i++ {
+		dependent := &LockedPackage{Name: fmt.Sprintf("dependent%d", i), Version: "1.0.0", Source: "registry", Dependencies: []*LockedPackage{shared}}
+		member.Dependencies = append(member.Dependencies, dependent)
+		packages = append(packages, dependent)
+	}
+	requestedBy := RequestedBy(packages, "myapp:0.1.0")
+	assert.Len(t, requestedBy["shared:1.0.0"], projectutils.MaxRequestedByPaths)
+	assert.Equal(t, []string{"dependent0:1.0.0", "myapp:0.1.0"}, requestedBy["shared:1.0.0"][0])
+}
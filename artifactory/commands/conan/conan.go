@@ -0,0 +1,362 @@
+package conan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	ModuleType buildinfo.ModuleType = "conan"
+	// The names of the remotes, through which Conan resolves the packages from Artifactory and uploads them.
+	resolutionRemote = "artifactory"
+	deploymentRemote = "artifactory-upload"
+)
+
+// Runs Conan 2 with the packages resolved from Artifactory, and 'conan upload' with the packages uploaded to Artifactory.
+// The Artifactory remotes are added to the Conan remotes for the run of the command, and restored when it completes,
+// while the credentials are passed through the environment.
+// If the build name and number are passed, the references locked by 'conan install' and 'conan create' are recorded
+// as the build-info dependencies, and the files uploaded by 'conan upload' as the build-info artifacts.
+type ConanCommand struct {
+	resolverDetails *config.ServerDetails
+	resolverRepo    string
+	deployerDetails *config.ServerDetails
+	deployerRepo    string
+	args            []string
+	conanPath       string
+	env             []string
+}
+
+func NewConanCommand() *ConanCommand {
+	return &ConanCommand{}
+}
+
+func (cc *ConanCommand) SetResolver(serverDetails *config.ServerDetails, repo string) *ConanCommand {
+	cc.resolverDetails, cc.resolverRepo = serverDetails, repo
+	return cc
+}
+
+func (cc *ConanCommand) SetDeployer(serverDetails *config.ServerDetails, repo string) *ConanCommand {
+	cc.deployerDetails, cc.deployerRepo = serverDetails, repo
+	return cc
+}
+
+func (cc *ConanCommand) SetArgs(args []string) *ConanCommand {
+	cc.args = args
+	return cc
+}
+
+func (cc *ConanCommand) ServerDetails() (*config.ServerDetails, error) {
+	if cc.resolverDetails != nil {
+		return cc.resolverDetails, nil
+	}
+	return cc.deployerDetails, nil
+}
+
+func (cc *ConanCommand) CommandName() string {
+	return "rt_conan"
+}
+
+func (cc *ConanCommand) Run() error {
+	args, buildConfiguration, err := utils.ExtractBuildDetailsFromArgs(cc.args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errorutils.CheckError(errors.New("a conan command is required"))
+	}
+	if cc.conanPath, err = exec.LookPath("conan"); err != nil {
+		return errorutils.CheckError(errors.New("could not find the 'conan' executable in the system PATH"))
+	}
+	previousRemotes, err := cc.listRemotes()
+	if err != nil {
+		return err
+	}
+	defer cc.restoreRemotes(previousRemotes)
+	if err = cc.addRemotes(); err != nil {
+		return err
+	}
+	collectBuildInfo := buildConfiguration.BuildName != "" && buildConfiguration.BuildNumber != ""
+	moduleId := buildConfiguration.Module
+	if moduleId == "" {
+		moduleId = buildConfiguration.BuildName
+	}
+	switch args[0] {
+	case "install", "create":
+		return cc.runInstall(args, collectBuildInfo, moduleId, buildConfiguration)
+	case "upload":
+		return cc.runUpload(args, collectBuildInfo, moduleId, buildConfiguration)
+	}
+	return cc.runConan(args, nil)
+}
+
+// Adds the Artifactory remotes, or updates their URLs if they already exist.
+func (cc *ConanCommand) addRemotes() error {
+	remotes := []struct {
+		name          string
+		serverDetails *config.ServerDetails
+		repo          string
+	}{{resolutionRemote, cc.resolverDetails, cc.resolverRepo}, {deploymentRemote, cc.deployerDetails, cc.deployerRepo}}
+	for _, remote := range remotes {
+		if remote.serverDetails == nil {
+			continue
+		}
+		username, password, err := projectutils.GetBasicAuthCredentials(remote.serverDetails)
+		if err != nil {
+			return err
+		}
+		envSuffix := strings.ToUpper(strings.Replace(remote.name, "-", "_", -1))
+		cc.env = append(cc.env, "CONAN_LOGIN_USERNAME_"+envSuffix+"="+username, "CONAN_PASSWORD_"+envSuffix+"="+password)
+		remoteUrl := remote.serverDetails.GetArtifactoryUrl() + "api/conan/" + remote.repo
+		if err = cc.runConan([]string{"remote", "add", remote.name, remoteUrl, "--force"}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// A remote, as listed by 'conan remote list --format json'.
+type conanRemote struct {
+	Name      string `json:"name"`
+	Url       string `json:"url"`
+	VerifySsl bool   `json:"verify_ssl"`
+	Enabled   bool   `json:"enabled"`
+}
+
+func (cc *ConanCommand) listRemotes() ([]conanRemote, error) {
+	cc.env = os.Environ()
+	stdout := &bytes.Buffer{}
+	if err := cc.runConan([]string{"remote", "list", "--format", "json"}, stdout); err != nil {
+		return nil, err
+	}
+	var remotes []conanRemote
+	return remotes, errorutils.CheckError(json.Unmarshal(stdout.Bytes(), &remotes))
+}
+
+// Removes the Artifactory remotes added by addRemotes, or restores the remotes they replaced, since the Conan remotes
+// are shared by all of the Conan projects of the user.
+func (cc *ConanCommand) restoreRemotes(previousRemotes []conanRemote) {
+	for _, name := range []string{resolutionRemote, deploymentRemote} {
+		if err := cc.restoreRemote(name, previousRemotes); err != nil {
+			log.Warn("Failed to restore the", name, "Conan remote:", err.Error())
+		}
+	}
+}
+
+func (cc *ConanCommand) restoreRemote(name string, previousRemotes []conanRemote) error {
+	if (name == resolutionRemote && cc.resolverDetails == nil) || (name == deploymentRemote && cc.deployerDetails == nil) {
+		return nil
+	}
+	for index, remote := range previousRemotes {
+		if remote.Name != name {
+			continue
+		}
+		args := []string{"remote", "update", name, "--url", remote.Url, "--index", strconv.Itoa(index), "--secure"}
+		if !remote.VerifySsl {
+			args[len(args)-1] = "--insecure"
+		}
+		if err := cc.runConan(args, nil); err != nil || remote.Enabled {
+			return err
+		}
+		return cc.runConan([]string{"remote", "disable", name}, nil)
+	}
+	return cc.runConan([]string{"remote", "remove", name}, nil)
+}
+
+func (cc *ConanCommand) runConan(args []string, stdout *bytes.Buffer) error {
+	log.Info("Running conan " + strings.Join(args, " "))
+	cmd := exec.Command(cc.conanPath, args...)
+	cmd.Env = cc.env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if stdout != nil {
+		cmd.Stdout = stdout
+	}
+	return errorutils.CheckError(cmd.Run())
+}
+
+func (cc *ConanCommand) runInstall(args []string, collectBuildInfo bool, moduleId string, buildConfiguration *utils.BuildConfiguration) error {
+	if cc.resolverDetails != nil && !projectutils.HasOption(args, "-r", "--remote") {
+		args = append(args, "--remote", resolutionRemote)
+	}
+	if !collectBuildInfo {
+		return cc.runConan(args, nil)
+	}
+	lockfilePath := getOptionValue(args, "--lockfile-out")
+	if lockfilePath == "" {
+		tempDir, err := fileutils.CreateTempDir()
+		if err != nil {
+			return err
+		}
+		defer fileutils.RemoveTempDir(tempDir)
+		lockfilePath = filepath.Join(tempDir, "conan.lock")
+		args = append(args, "--lockfile-out="+lockfilePath)
+	}
+	if err := cc.runConan(args, nil); err != nil {
+		return err
+	}
+	content, err := ioutil.ReadFile(lockfilePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	locked, err := ParseLockfile(content)
+	if err != nil {
+		return err
+	}
+	checksums := make(map[string]*buildinfo.Checksum)
+	if cc.resolverDetails != nil {
+		if checksums, err = findRecipeManifests(cc.resolverDetails, cc.resolverRepo, locked); err != nil {
+			return err
+		}
+	}
+	dependencies, missing := createDependencies(locked, checksums)
+	if len(missing) > 0 {
+		log.Warn("The recipes of the following references were not found in Artifactory, and are added to the build-info without checksums:\n" + strings.Join(missing, "\n"))
+	}
+	log.Info("Adding", strconv.Itoa(len(dependencies)), "dependencies to module", moduleId, "of the build-info.")
+	return projectutils.SaveDependencies(buildConfiguration, ModuleType, moduleId, dependencies)
+}
+
+// Returns the checksums of the conanmanifest.txt files of the locked recipe revisions, by the paths of their revisions.
+// The manifest lists the checksums of all of the recipe files, so it identifies the recipe revision.
+func findRecipeManifests(serverDetails *config.ServerDetails, repo string, locked []*LockedReference) (map[string]*buildinfo.Checksum, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, false)
+	if err != nil {
+		return nil, err
+	}
+	searchRepo, err := utils.GetRepoNameForDependenciesSearch(repo, servicesManager)
+	if err != nil {
+		return nil, err
+	}
+	var conditions []string
+	for _, ref := range locked {
+		if ref.Revision == "" {
+			continue
+		}
+		condition, err := json.Marshal(map[string]string{"path": recipeExportPath(ref.Reference), "name": "conanmanifest.txt"})
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		conditions = append(conditions, string(condition))
+	}
+	items, err := projectutils.FindItems(servicesManager, searchRepo, conditions)
+	if err != nil {
+		return nil, err
+	}
+	checksums := make(map[string]*buildinfo.Checksum)
+	for _, item := range items {
+		checksums[item.Path] = item.Checksum()
+	}
+	return checksums, nil
+}
+
+func recipeExportPath(ref *Reference) string {
+	return ref.ArtifactoryPath() + "/" + ref.Revision + "/export"
+}
+
+// Creates the build-info dependencies of the locked references. The references, whose recipes weren't found in Artifactory, are returned.
+func createDependencies(locked []*LockedReference, checksums map[string]*buildinfo.Checksum) (dependencies []buildinfo.Dependency, missing []string) {
+	indexes := make(map[string]int)
+	for _, ref := range locked {
+		if index, exists := indexes[ref.Id()]; exists {
+			dependencies[index].Scopes = append(dependencies[index].Scopes, ref.Scope)
+			continue
+		}
+		dependency := buildinfo.Dependency{Id: ref.Id(), Type: "conan", Scopes: []string{ref.Scope}}
+		if ref.Revision != "" {
+			dependency.Checksum = checksums[recipeExportPath(ref.Reference)]
+		}
+		if dependency.Checksum == nil {
+			missing = append(missing, ref.Id())
+		}
+		indexes[ref.Id()] = len(dependencies)
+		dependencies = append(dependencies, dependency)
+	}
+	return
+}
+
+func (cc *ConanCommand) runUpload(args []string, collectBuildInfo bool, moduleId string, buildConfiguration *utils.BuildConfiguration) error {
+	if !projectutils.HasOption(args, "-r", "--remote") {
+		if cc.deployerDetails == nil {
+			return errorutils.CheckError(errors.New("no deployment repository is configured. Run 'jfrog rt conan-config' with the deployer options, or pass the remote with --remote"))
+		}
+		args = append(args, "--remote", deploymentRemote)
+	}
+	if !collectBuildInfo {
+		return cc.runConan(args, nil)
+	}
+	if cc.deployerDetails == nil {
+		return errorutils.CheckError(errors.New("the uploaded files can be recorded in the build-info only when uploading to the configured deployment repository"))
+	}
+	stdout := &bytes.Buffer{}
+	if err := cc.runConan(append(args, "--format=json"), stdout); err != nil {
+		return err
+	}
+	paths, err := ParseUploadedPaths(stdout.Bytes())
+	if err != nil {
+		return err
+	}
+	artifacts, err := findUploadedFiles(cc.deployerDetails, cc.deployerRepo, paths)
+	if err != nil {
+		return err
+	}
+	log.Info("Adding", strconv.Itoa(len(artifacts)), "artifacts to module", moduleId, "of the build-info.")
+	return projectutils.SaveArtifacts(buildConfiguration, ModuleType, moduleId, artifacts)
+}
+
+func findUploadedFiles(serverDetails *config.ServerDetails, repo string, paths []string) ([]buildinfo.Artifact, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, false)
+	if err != nil {
+		return nil, err
+	}
+	var conditions []string
+	for _, uploadedPath := range paths {
+		condition, err := json.Marshal(map[string]string{"path": uploadedPath})
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		conditions = append(conditions, string(condition))
+	}
+	items, err := projectutils.FindItems(servicesManager, repo, conditions)
+	if err != nil {
+		return nil, err
+	}
+	var artifacts []buildinfo.Artifact
+	for _, item := range items {
+		artifacts = append(artifacts, buildinfo.Artifact{
+			Name:     item.Name,
+			Type:     strings.TrimPrefix(path.Ext(item.Name), "."),
+			Path:     path.Join(item.Path, item.Name),
+			Checksum: item.Checksum(),
+		})
+	}
+	return artifacts, nil
+}
+
+// Returns the value of the option, passed either as '--option value' or as '--option=value'.
+func getOptionValue(args []string, option string) string {
+	for i, arg := range args {
+		if arg == option && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(arg, option+"=") {
+			return strings.TrimPrefix(arg, option+"=")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,163 @@
+package conan
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// A Conan reference in the form of name/version[@user/channel][#revision[%timestamp]].
+type Reference struct {
+	Name     string
+	Version  string
+	User     string
+	Channel  string
+	Revision string
+}
+
+func ParseReference(reference string) (*Reference, error) {
+	ref := &Reference{}
+	if index := strings.Index(reference, "#"); index >= 0 {
+		ref.Revision = reference[index+1:]
+		reference = reference[:index]
+		if index = strings.Index(ref.Revision, "%"); index >= 0 {
+			ref.Revision = ref.Revision[:index]
+		}
+	}
+	if index := strings.Index(reference, "@"); index >= 0 {
+		userChannel := strings.SplitN(reference[index+1:], "/", 2)
+		ref.User = userChannel[0]
+		if len(userChannel) == 2 {
+			ref.Channel = userChannel[1]
+		}
+		reference = reference[:index]
+	}
+	nameVersion := strings.SplitN(reference, "/", 2)
+	if len(nameVersion) != 2 || nameVersion[0] == "" || nameVersion[1] == "" {
+		return nil, errorutils.CheckError(errors.New("invalid Conan reference: " + reference))
+	}
+	ref.Name, ref.Version = nameVersion[0], nameVersion[1]
+	return ref, nil
+}
+
+// Returns the reference without the revision, which is the ID of the build-info dependency.
+func (ref *Reference) Id() string {
+	id := ref.Name + "/" + ref.Version
+	if ref.User != "" {
+		id += "@" + ref.User + "/" + ref.Channel
+	}
+	return id
+}
+
+// Returns the path of the reference in an Artifactory Conan repository, in the <user>/<name>/<version>/<channel> layout.
+// A missing user or channel is stored as "_".
+func (ref *Reference) ArtifactoryPath() string {
+	user, channel := ref.User, ref.Channel
+	if user == "" {
+		user = "_"
+	}
+	if channel == "" {
+		channel = "_"
+	}
+	return strings.Join([]string{user, ref.Name, ref.Version, channel}, "/")
+}
+
+// A reference locked in a lockfile, with the scope it's required in.
+type LockedReference struct {
+	*Reference
+	Scope string
+}
+
+// Parses a Conan 2 lockfile, which lists the references under requires, build_requires and python_requires,
+// or a Conan 1 lockfile, which lists them as the nodes of graph_lock.
+func ParseLockfile(content []byte) ([]*LockedReference, error) {
+	var lockfile struct {
+		Requires       []string `json:"requires"`
+		BuildRequires  []string `json:"build_requires"`
+		PythonRequires []string `json:"python_requires"`
+		GraphLock      struct {
+			Nodes map[string]struct {
+				Ref     string `json:"ref"`
+				Context string `json:"context"`
+			} `json:"nodes"`
+		} `json:"graph_lock"`
+	}
+	if err := json.Unmarshal(content, &lockfile); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to parse the Conan lockfile: " + err.Error()))
+	}
+	var locked []*LockedReference
+	add := func(reference, scope string) error {
+		ref, err := ParseReference(reference)
+		if err != nil {
+			return err
+		}
+		locked = append(locked, &LockedReference{Reference: ref, Scope: scope})
+		return nil
+	}
+	for scope, references := range map[string][]string{"requires": lockfile.Requires, "build": lockfile.BuildRequires, "python": lockfile.PythonRequires} {
+		for _, reference := range references {
+			if err := add(reference, scope); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, node := range lockfile.GraphLock.Nodes {
+		// The root node is the consumer, which has a path rather than a reference.
+		if node.Ref == "" {
+			continue
+		}
+		scope := "requires"
+		if node.Context == "build" {
+			scope = "build"
+		}
+		if err := add(node.Ref, scope); err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(locked, func(i, j int) bool {
+		if locked[i].Id() != locked[j].Id() {
+			return locked[i].Id() < locked[j].Id()
+		}
+		return locked[i].Scope < locked[j].Scope
+	})
+	return locked, nil
+}
+
+// Parses the package list printed by 'conan upload --format=json', and returns the Artifactory paths of the
+// uploaded recipe and package revisions, relative to the repository.
+func ParseUploadedPaths(content []byte) ([]string, error) {
+	// remote -> reference -> recipe revisions -> packages -> package revisions
+	var packageList map[string]map[string]struct {
+		Revisions map[string]struct {
+			Packages map[string]struct {
+				Revisions map[string]interface{} `json:"revisions"`
+			} `json:"packages"`
+		} `json:"revisions"`
+	}
+	if err := json.Unmarshal(content, &packageList); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to parse the output of conan upload: " + err.Error()))
+	}
+	var paths []string
+	for _, references := range packageList {
+		for reference, recipe := range references {
+			ref, err := ParseReference(reference)
+			if err != nil {
+				return nil, err
+			}
+			for recipeRevision, revision := range recipe.Revisions {
+				recipePath := ref.ArtifactoryPath() + "/" + recipeRevision
+				paths = append(paths, recipePath+"/export")
+				for packageId, pkg := range revision.Packages {
+					for packageRevision := range pkg.Revisions {
+						paths = append(paths, recipePath+"/package/"+packageId+"/"+packageRevision)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
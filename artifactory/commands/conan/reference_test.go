@@ -0,0 +1,62 @@
+package conan
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("openssl/3.1.2@acme/stable#b5aa%1693475426.0")
+	assert.NoError(t, err)
+	assert.Equal(t, &Reference{Name: "openssl", Version: "3.1.2", User: "acme", Channel: "stable", Revision: "b5aa"}, ref)
+	assert.Equal(t, "openssl/3.1.2@acme/stable", ref.Id())
+	assert.Equal(t, "acme/openssl/3.1.2/stable", ref.ArtifactoryPath())
+
+	ref, err = ParseReference("zlib/1.2.13")
+	assert.NoError(t, err)
+	assert.Equal(t, "zlib/1.2.13", ref.Id())
+	assert.Equal(t, "_/zlib/1.2.13/_", ref.ArtifactoryPath())
+
+	_, err = ParseReference("zlib")
+	assert.Error(t, err)
+}
+
+func TestParseLockfile(t *testing.T) {
+	content, err := ioutil.ReadFile(filepath.Join("testdata", "conan.lock"))
+	assert.NoError(t, err)
+	locked, err := ParseLockfile(content)
+	assert.NoError(t, err)
+	checksums := map[string]*buildinfo.Checksum{"_/zlib/1.2.13/_/97d5730b529b4224045fe7090592d4c1/export": {Sha1: "1", Md5: "2"}}
+	dependencies, missing := createDependencies(locked, checksums)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "cmake/3.27.4", Type: "conan", Scopes: []string{"build"}},
+		{Id: "openssl/3.1.2@acme/stable", Type: "conan", Scopes: []string{"requires"}},
+		{Id: "zlib/1.2.13", Type: "conan", Scopes: []string{"build", "requires"}, Checksum: &buildinfo.Checksum{Sha1: "1", Md5: "2"}},
+	}, dependencies)
+	assert.Equal(t, []string{"cmake/3.27.4", "openssl/3.1.2@acme/stable"}, missing)
+}
+
+func TestParseConan1Lockfile(t *testing.T) {
+	content, err := ioutil.ReadFile(filepath.Join("testdata", "conan1.lock"))
+	assert.NoError(t, err)
+	locked, err := ParseLockfile(content)
+	assert.NoError(t, err)
+	if assert.Len(t, locked, 2) {
+		assert.Equal(t, "cmake/3.20.0", locked[0].Id())
+		assert.Equal(t, "build", locked[0].Scope)
+		assert.Equal(t, "dc0e384f0551386cd76dc29cc964c95e", locked[1].Revision)
+		assert.Equal(t, "requires", locked[1].Scope)
+	}
+}
+
+func TestParseUploadedPaths(t *testing.T) {
+	content, err := ioutil.ReadFile(filepath.Join("testdata", "upload.json"))
+	assert.NoError(t, err)
+	paths, err := ParseUploadedPaths(content)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"_/mylib/1.0/_/aaa/export", "_/mylib/1.0/_/aaa/package/pkg1/bbb"}, paths)
+}
@@ -0,0 +1,112 @@
+package depsbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Packs the files of the directory to a tar.gz archive, with their paths relative to the directory.
+func createArchive(sourceDir, archivePath string) (err error) {
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer func() {
+		if e := archive.Close(); err == nil {
+			err = errorutils.CheckError(e)
+		}
+	}()
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	err = filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relativePath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{Name: filepath.ToSlash(relativePath), Mode: 0644, Size: info.Size(), ModTime: info.ModTime(), Typeflag: tar.TypeReg}
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = tarWriter.Close(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(gzipWriter.Close())
+}
+
+// Extracts the regular files of a tar.gz archive to the directory.
+// Entries, whose paths lead outside of the directory, are rejected.
+func extractArchive(archivePath, targetDir string) error {
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer archive.Close()
+	gzipReader, err := gzip.NewReader(archive)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errorutils.CheckError(err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		targetPath, err := getLocalPath(targetDir, header.Name)
+		if err != nil {
+			return err
+		}
+		if err = extractFile(tarReader, targetPath); err != nil {
+			return err
+		}
+	}
+}
+
+// Returns the local path of a file of the bundle in the directory of the bundle.
+// Paths, which lead outside of the directory, are rejected.
+func getLocalPath(bundleDir, bundlePath string) (string, error) {
+	localPath := filepath.Join(bundleDir, filepath.FromSlash(bundlePath))
+	if !strings.HasPrefix(localPath, filepath.Clean(bundleDir)+string(filepath.Separator)) {
+		return "", errorutils.CheckError(errors.New("the bundle entry '" + bundlePath + "' leads outside of the bundle"))
+	}
+	return localPath, nil
+}
+
+func extractFile(reader io.Reader, targetPath string) error {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return errorutils.CheckError(err)
+	}
+	file, err := os.Create(targetPath)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	defer file.Close()
+	_, err = io.Copy(file, reader)
+	return errorutils.CheckError(err)
+}
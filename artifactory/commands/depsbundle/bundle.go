@@ -0,0 +1,242 @@
+package depsbundle
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The file of the bundle, which lists its files.
+const ManifestFileName = "bundle.json"
+
+const manifestVersion = 1
+
+// The layouts of the bundle, under a directory of their own, by package type:
+//
+//	maven/<group path>/<artifact>/<version>/<file>   A Maven repository layout, usable as a file:// repository.
+//	npm/<package>/-/<package>-<version>.tgz          The tarballs, installable by 'npm install <tarball>'.
+//	go/cache/download/<module>/@v/<version>.zip      A Go module cache, usable by GOPROXY=file://<bundle>/go/cache/download.
+//	pip/<file>                                       A wheelhouse, usable by 'pip install --no-index --find-links pip'.
+//	nuget/<file>                                     A local NuGet feed.
+//	pip|nuget/<repo>/<path>/<file>                   The files, whose names are taken by other files in the flat layouts.
+//	generic/<repo>/<path>/<file>                     The dependencies of other types, by their repository paths.
+const (
+	Maven   = "maven"
+	Npm     = "npm"
+	Go      = "go"
+	Pip     = "pip"
+	Nuget   = "nuget"
+	Generic = "generic"
+)
+
+var layouts = []string{Maven, Npm, Go, Pip, Nuget, Generic}
+
+// The files of the bundle, with the build, which their dependencies were read from.
+type Manifest struct {
+	Version     int          `json:"version"`
+	BuildName   string       `json:"buildName"`
+	BuildNumber string       `json:"buildNumber"`
+	Project     string       `json:"project,omitempty"`
+	Files       []BundleFile `json:"files"`
+	// The dependencies, which weren't found in Artifactory, so the bundle doesn't include them.
+	Missing []string `json:"missing,omitempty"`
+}
+
+type BundleFile struct {
+	Layout string `json:"layout"`
+	// The path of the file in the bundle.
+	Path string `json:"path"`
+	// The path of the file in its repository, which the file is imported to.
+	RepoPath string `json:"repoPath"`
+	Sha1     string `json:"sha1"`
+	// The ids of the build-info dependencies of the file. Files, which are required by the package manager along with
+	// the dependency, such as the .mod files of Go modules, have no dependencies.
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+// A dependency of the build-info, with the type of its module.
+type moduleDependency struct {
+	buildinfo.Dependency
+	moduleType buildinfo.ModuleType
+}
+
+func getLayout(moduleType buildinfo.ModuleType) string {
+	switch moduleType {
+	case buildinfo.Maven, buildinfo.Gradle:
+		return Maven
+	case buildinfo.Npm:
+		return Npm
+	case buildinfo.Go:
+		return Go
+	case buildinfo.Pip:
+		return Pip
+	case buildinfo.Nuget:
+		return Nuget
+	}
+	return Generic
+}
+
+// Returns the path of the file in the bundle by its layout.
+func getBundlePath(layout string, item projectutils.AqlItem) string {
+	repoPath := path.Join(item.Path, item.Name)
+	switch layout {
+	case Maven, Npm:
+		return path.Join(layout, repoPath)
+	case Go:
+		return path.Join(layout, "cache", "download", repoPath)
+	case Pip, Nuget:
+		return path.Join(layout, item.Name)
+	}
+	return path.Join(Generic, item.Repo, repoPath)
+}
+
+// Creates the files of the bundle from the items found in Artifactory, by the sha1 checksums of the dependencies.
+// If a file is found in a few repositories, the first of them by name, whose path fits the layout of the dependency,
+// is used. If none of them fits, the first of them is used, and the dependency is returned as ambiguous.
+// The dependencies, which have no sha1 checksum or aren't found, are returned as missing. The items are sorted in place.
+// Since the pip and NuGet layouts are flat, a file, whose name is taken by another file, is put under the path of its
+// repository, and its dependency is returned as conflicting.
+func createBundleFiles(dependencies []moduleDependency, items []projectutils.AqlItem) (files []BundleFile, missing, ambiguous, conflicting []string) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Repo+"/"+items[i].Path < items[j].Repo+"/"+items[j].Path
+	})
+	itemsBySha1 := make(map[string][]projectutils.AqlItem)
+	for _, item := range items {
+		itemsBySha1[item.Sha1] = append(itemsBySha1[item.Sha1], item)
+	}
+	filesBySha1 := make(map[string]int)
+	bundlePaths := make(map[string]bool)
+	for _, dependency := range dependencies {
+		sha1 := ""
+		if dependency.Checksum != nil {
+			sha1 = strings.ToLower(dependency.Checksum.Sha1)
+		}
+		candidates := itemsBySha1[sha1]
+		if len(candidates) == 0 {
+			missing = append(missing, dependency.Id)
+			continue
+		}
+		if index, ok := filesBySha1[sha1]; ok {
+			if !projectutils.ContainsString(files[index].Dependencies, dependency.Id) {
+				files[index].Dependencies = append(files[index].Dependencies, dependency.Id)
+			}
+			continue
+		}
+		layout := getLayout(dependency.moduleType)
+		item, fits := selectItem(layout, dependency.Id, candidates)
+		if !fits && len(candidates) > 1 {
+			ambiguous = append(ambiguous, dependency.Id+" ("+item.Repo+"/"+path.Join(item.Path, item.Name)+")")
+		}
+		bundlePath := getBundlePath(layout, item)
+		if bundlePaths[bundlePath] {
+			bundlePath = path.Join(layout, item.Repo, item.Path, item.Name)
+			conflicting = append(conflicting, dependency.Id+" ("+bundlePath+")")
+		}
+		bundlePaths[bundlePath] = true
+		filesBySha1[sha1] = len(files)
+		files = append(files, BundleFile{
+			Layout:       layout,
+			Path:         bundlePath,
+			RepoPath:     path.Join(item.Path, item.Name),
+			Sha1:         sha1,
+			Dependencies: []string{dependency.Id},
+		})
+	}
+	sort.Strings(missing)
+	sort.Strings(ambiguous)
+	sort.Strings(conflicting)
+	return
+}
+
+// Returns the first item, whose path fits the layout of the dependency, or the first item if none of them fits.
+func selectItem(layout, dependencyId string, items []projectutils.AqlItem) (item projectutils.AqlItem, fits bool) {
+	for _, item := range items {
+		if fitsLayout(layout, dependencyId, item) {
+			return item, true
+		}
+	}
+	return items[0], false
+}
+
+// Returns true if the path of the item in its repository is the path of the dependency in a repository of its type.
+// The files of the generic layout may have any path.
+func fitsLayout(layout, dependencyId string, item projectutils.AqlItem) bool {
+	parts := strings.Split(dependencyId, ":")
+	switch layout {
+	case Maven:
+		// <group>:<artifact>:<version>[:<classifier>] is stored under <group path>/<artifact>/<version>.
+		return len(parts) >= 3 && item.Path == path.Join(strings.Replace(parts[0], ".", "/", -1), parts[1], parts[2])
+	case Npm:
+		// <package>:<version> is stored as <package>/-/<package base name>-<version>.tgz.
+		return len(parts) == 2 && item.Path == parts[0]+"/-" && item.Name == path.Base(parts[0])+"-"+parts[1]+".tgz"
+	case Go:
+		// <module>:<version> is stored as <escaped module>/@v/<version>.zip.
+		return len(parts) == 2 && unescapeGoModulePath(item.Path) == parts[0]+"/@v" && item.Name == parts[1]+".zip"
+	case Pip:
+		// The dependency is either the file itself, or <package>:<version>, whose file names start with <package>-<version>.
+		if len(parts) != 2 {
+			return item.Name == dependencyId
+		}
+		name, prefix := normalizePipName(item.Name), normalizePipName(parts[0]+"-"+parts[1])
+		return strings.HasPrefix(name, prefix+"-") || strings.HasPrefix(name, prefix+".")
+	case Nuget:
+		// <package>:<version> is stored as <package>.<version>.nupkg.
+		return len(parts) == 2 && strings.EqualFold(item.Name, parts[0]+"."+parts[1]+".nupkg")
+	}
+	return true
+}
+
+// Reverses the escaping of the upper case letters of Go module paths, by which they are stored in Go repositories.
+func unescapeGoModulePath(escaped string) string {
+	var unescaped strings.Builder
+	upper := false
+	for _, r := range escaped {
+		if r == '!' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		unescaped.WriteRune(r)
+	}
+	return unescaped.String()
+}
+
+// Normalizes the names of Python packages and files, in which '-' and '_' are interchangeable, and the case is ignored.
+func normalizePipName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "-").Replace(name))
+}
+
+func writeManifest(manifest *Manifest, bundleDir string) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(filepath.Join(bundleDir, ManifestFileName), content, 0644))
+}
+
+func readManifest(bundleDir string) (*Manifest, error) {
+	content, err := ioutil.ReadFile(filepath.Join(bundleDir, ManifestFileName))
+	if err != nil {
+		return nil, errorutils.CheckError(errors.New("the bundle has no " + ManifestFileName + ": " + err.Error()))
+	}
+	manifest := &Manifest{}
+	if err = json.Unmarshal(content, manifest); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to parse the " + ManifestFileName + " of the bundle: " + err.Error()))
+	}
+	if manifest.Version > manifestVersion {
+		return nil, errorutils.CheckError(errors.New("the bundle was created by a newer version of JFrog CLI"))
+	}
+	return manifest, nil
+}
@@ -0,0 +1,183 @@
+package depsbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateBundleFiles(t *testing.T) {
+	dependencies := []moduleDependency{
+		newDependency(buildinfo.Maven, "org.acme:util:1.0", "AAAA"),
+		newDependency(buildinfo.Gradle, "org.acme:util:1.0", "aaaa"),
+		newDependency(buildinfo.Npm, "@acme/ui:2.1.0", "bbbb"),
+		newDependency(buildinfo.Go, "github.com/pkg/errors:v0.9.1", "cccc"),
+		newDependency(buildinfo.Pip, "requests:2.31.0", "dddd"),
+		newDependency(buildinfo.Docker, "sha256__1234", "eeee"),
+		newDependency(buildinfo.Npm, "internal-lib:2.0.0", ""),
+		newDependency(buildinfo.Npm, "left-pad:1.3.0", "ffff"),
+		newDependency(buildinfo.Npm, "is-odd:3.0.1", "9999"),
+		newDependency(buildinfo.Pip, "requests-2.31.0-py3-none-any.whl", "7777"),
+	}
+	items := []projectutils.AqlItem{
+		{Repo: "maven-remote-cache", Path: "org/acme/util/1.0", Name: "util-1.0.jar", Sha1: "aaaa"},
+		// The first repository by name, whose path fits the layout, is used.
+		{Repo: "libs-release-local", Path: "org/acme/util/1.0", Name: "util-1.0.jar", Sha1: "aaaa"},
+		{Repo: "generic-local", Path: "jars", Name: "util.jar", Sha1: "aaaa"},
+		// Copies of a file, none of which fits the layout.
+		{Repo: "generic-local", Path: "tarballs", Name: "is-odd.tgz", Sha1: "9999"},
+		{Repo: "archive-local", Path: "old", Name: "is-odd-3.0.1.tgz", Sha1: "9999"},
+		{Repo: "npm-remote-cache", Path: "@acme/ui/-", Name: "ui-2.1.0.tgz", Sha1: "bbbb"},
+		{Repo: "go-remote-cache", Path: "github.com/pkg/errors/@v", Name: "v0.9.1.zip", Sha1: "cccc"},
+		{Repo: "pypi-remote-cache", Path: "de/ad/beef", Name: "requests-2.31.0-py3-none-any.whl", Sha1: "dddd"},
+		{Repo: "docker-local", Path: "app/1.0", Name: "sha256__1234", Sha1: "eeee"},
+		// A different file with the same name in another repository.
+		{Repo: "pypi-local", Path: "requests", Name: "requests-2.31.0-py3-none-any.whl", Sha1: "7777"},
+	}
+	files, missing, ambiguous, conflicting := createBundleFiles(dependencies, items)
+	assert.Equal(t, []BundleFile{
+		{Layout: Maven, Path: "maven/org/acme/util/1.0/util-1.0.jar", RepoPath: "org/acme/util/1.0/util-1.0.jar", Sha1: "aaaa",
+			Dependencies: []string{"org.acme:util:1.0"}},
+		{Layout: Npm, Path: "npm/@acme/ui/-/ui-2.1.0.tgz", RepoPath: "@acme/ui/-/ui-2.1.0.tgz", Sha1: "bbbb", Dependencies: []string{"@acme/ui:2.1.0"}},
+		{Layout: Go, Path: "go/cache/download/github.com/pkg/errors/@v/v0.9.1.zip", RepoPath: "github.com/pkg/errors/@v/v0.9.1.zip", Sha1: "cccc",
+			Dependencies: []string{"github.com/pkg/errors:v0.9.1"}},
+		{Layout: Pip, Path: "pip/requests-2.31.0-py3-none-any.whl", RepoPath: "de/ad/beef/requests-2.31.0-py3-none-any.whl", Sha1: "dddd",
+			Dependencies: []string{"requests:2.31.0"}},
+		{Layout: Generic, Path: "generic/docker-local/app/1.0/sha256__1234", RepoPath: "app/1.0/sha256__1234", Sha1: "eeee", Dependencies: []string{"sha256__1234"}},
+		{Layout: Npm, Path: "npm/old/is-odd-3.0.1.tgz", RepoPath: "old/is-odd-3.0.1.tgz", Sha1: "9999", Dependencies: []string{"is-odd:3.0.1"}},
+		{Layout: Pip, Path: "pip/pypi-local/requests/requests-2.31.0-py3-none-any.whl", RepoPath: "requests/requests-2.31.0-py3-none-any.whl", Sha1: "7777",
+			Dependencies: []string{"requests-2.31.0-py3-none-any.whl"}},
+	}, files)
+	assert.Equal(t, []string{"internal-lib:2.0.0", "left-pad:1.3.0"}, missing)
+	assert.Equal(t, []string{"is-odd:3.0.1 (archive-local/old/is-odd-3.0.1.tgz)"}, ambiguous)
+	assert.Equal(t, []string{"requests-2.31.0-py3-none-any.whl (pip/pypi-local/requests/requests-2.31.0-py3-none-any.whl)"}, conflicting)
+	assert.Equal(t, "libs-release-local", mapItemsByFile(items)[fileKey("aaaa", "org/acme/util/1.0/util-1.0.jar")].Repo)
+}
+
+func TestFitsLayout(t *testing.T) {
+	for _, test := range []struct {
+		layout, dependencyId string
+		item                 projectutils.AqlItem
+		fits                 bool
+	}{
+		{Maven, "org.acme:util:1.0", projectutils.AqlItem{Path: "org/acme/util/1.0", Name: "util-1.0.jar"}, true},
+		{Maven, "org.acme:util:1.0", projectutils.AqlItem{Path: "jars", Name: "util-1.0.jar"}, false},
+		{Npm, "@acme/ui:2.1.0", projectutils.AqlItem{Path: "@acme/ui/-", Name: "ui-2.1.0.tgz"}, true},
+		{Npm, "@acme/ui:2.1.0", projectutils.AqlItem{Path: "ui/-", Name: "ui-2.1.0.tgz"}, false},
+		{Go, "github.com/BurntSushi/toml:v1.2.0", projectutils.AqlItem{Path: "github.com/!burnt!sushi/toml/@v", Name: "v1.2.0.zip"}, true},
+		{Go, "github.com/BurntSushi/toml:v1.2.0", projectutils.AqlItem{Path: "github.com/!burnt!sushi/toml", Name: "v1.2.0.zip"}, false},
+		{Pip, "typing_extensions:4.8.0", projectutils.AqlItem{Path: "ab/cd", Name: "typing_extensions-4.8.0-py3-none-any.whl"}, true},
+		{Pip, "requests:2.31.0", projectutils.AqlItem{Path: "ab/cd", Name: "requests-2.31.0.tar.gz"}, true},
+		{Pip, "requests:2.31.0", projectutils.AqlItem{Path: "ab/cd", Name: "requests-toolbelt-1.0.0.tar.gz"}, false},
+		{Pip, "requests-2.31.0-py3-none-any.whl", projectutils.AqlItem{Path: "ab/cd", Name: "requests-2.31.0-py3-none-any.whl"}, true},
+		{Nuget, "Newtonsoft.Json:13.0.1", projectutils.AqlItem{Name: "newtonsoft.json.13.0.1.nupkg"}, true},
+		{Generic, "sha256__1234", projectutils.AqlItem{Path: "app/1.0", Name: "sha256__1234"}, true},
+	} {
+		assert.Equal(t, test.fits, fitsLayout(test.layout, test.dependencyId, test.item), test.dependencyId+" "+test.item.Path)
+	}
+}
+
+func TestArchive(t *testing.T) {
+	tempDir, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer fileutils.RemoveTempDir(tempDir)
+	archivePath := filepath.Join(tempDir, "bundle.tar.gz")
+	assert.NoError(t, createArchive(filepath.Join("testdata", "bundle"), archivePath))
+
+	extractDir := filepath.Join(tempDir, "extracted")
+	assert.NoError(t, extractArchive(archivePath, extractDir))
+	manifest, err := readManifest(extractDir)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "acme", manifest.BuildName)
+		assert.Len(t, manifest.Files, 2)
+	}
+	content, err := ioutil.ReadFile(filepath.Join(extractDir, "npm", "left-pad", "-", "left-pad-1.3.0.tgz"))
+	assert.NoError(t, err)
+	assert.Equal(t, "left-pad tarball\n", string(content))
+
+	// Entries outside of the bundle are rejected.
+	maliciousPath := filepath.Join(tempDir, "malicious.tar.gz")
+	writeTarGz(t, maliciousPath, "../outside.txt")
+	assert.Error(t, extractArchive(maliciousPath, filepath.Join(tempDir, "malicious")))
+	assert.NoFileExists(t, filepath.Join(tempDir, "outside.txt"))
+}
+
+func TestCreateDeployableFiles(t *testing.T) {
+	bundleDir := filepath.Join("testdata", "bundle")
+	manifest, err := readManifest(bundleDir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	repos, err := ParseRepos("maven=libs-release-local; npm=npm-local")
+	assert.NoError(t, err)
+	files, err := createDeployableFiles(manifest, bundleDir, repos)
+	if assert.NoError(t, err) && assert.Len(t, files, 2) {
+		assert.Equal(t, "libs-release-local/org/acme/util/1.0/util-1.0.jar", files[0].TargetPath)
+		assert.Equal(t, filepath.Join(bundleDir, "npm", "left-pad", "-", "left-pad-1.3.0.tgz"), files[1].LocalPath)
+	}
+
+	_, err = createDeployableFiles(manifest, bundleDir, map[string]string{Maven: "libs-release-local"})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the bundle includes npm files")
+	}
+
+	// Paths, which lead outside of the bundle or outside of the repository, are rejected.
+	manifest.Files[0].Path = "../../import.go"
+	_, err = createDeployableFiles(manifest, bundleDir, repos)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "leads outside of the bundle")
+	}
+	manifest.Files[0].Path = "maven/org/acme/util/1.0/util-1.0.jar"
+	manifest.Files[0].RepoPath = "../other-repo/util-1.0.jar"
+	_, err = createDeployableFiles(manifest, bundleDir, repos)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "leads outside of its repository")
+	}
+	manifest.Files[0].RepoPath = "org/acme/util/1.0/util-1.0.jar"
+
+	manifest.Files[0].Sha1 = "0000000000000000000000000000000000000000"
+	_, err = createDeployableFiles(manifest, bundleDir, repos)
+	assert.Error(t, err)
+}
+
+func TestParseRepos(t *testing.T) {
+	repos, err := ParseRepos("go=go-local;pip=pypi-local;")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{Go: "go-local", Pip: "pypi-local"}, repos)
+	_, err = ParseRepos("cargo=cargo-local")
+	assert.Error(t, err)
+	_, err = ParseRepos("maven")
+	assert.Error(t, err)
+}
+
+func newDependency(moduleType buildinfo.ModuleType, id, sha1 string) moduleDependency {
+	dependency := moduleDependency{Dependency: buildinfo.Dependency{Id: id}, moduleType: moduleType}
+	if sha1 != "" {
+		dependency.Checksum = &buildinfo.Checksum{Sha1: sha1}
+	}
+	return dependency
+}
+
+func writeTarGz(t *testing.T, archivePath, entryName string) {
+	archive, err := os.Create(archivePath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer archive.Close()
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+	content := []byte("outside")
+	assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: entryName, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err = tarWriter.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, tarWriter.Close())
+	assert.NoError(t, gzipWriter.Close())
+}
@@ -0,0 +1,282 @@
+package depsbundle
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/http/httpclient"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The extensions of the files, which Go requires along with the zip of a module.
+var goModuleFileExtensions = []string{".mod", ".info"}
+
+// Downloads the dependencies of a build from Artifactory into a tar.gz bundle, laid out by package type,
+// so the build can run on an air-gapped machine, or the dependencies can be imported to an isolated server.
+// The dependencies are read from the published build-info, or from the local build-info, which isn't published yet.
+type DepsBundleCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *utils.BuildConfiguration
+	bundlePath         string
+	local              bool
+}
+
+func NewDepsBundleCommand() *DepsBundleCommand {
+	return &DepsBundleCommand{}
+}
+
+func (dbc *DepsBundleCommand) SetServerDetails(serverDetails *config.ServerDetails) *DepsBundleCommand {
+	dbc.serverDetails = serverDetails
+	return dbc
+}
+
+func (dbc *DepsBundleCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *DepsBundleCommand {
+	dbc.buildConfiguration = buildConfiguration
+	return dbc
+}
+
+// Sets the path of the bundle. Defaults to <build name>-<build number>-deps.tar.gz in the working directory.
+func (dbc *DepsBundleCommand) SetBundlePath(bundlePath string) *DepsBundleCommand {
+	dbc.bundlePath = bundlePath
+	return dbc
+}
+
+// Sets whether to read the dependencies from the local build-info, collected by the build commands, rather than from the published build-info.
+func (dbc *DepsBundleCommand) SetLocal(local bool) *DepsBundleCommand {
+	dbc.local = local
+	return dbc
+}
+
+func (dbc *DepsBundleCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dbc.serverDetails, nil
+}
+
+func (dbc *DepsBundleCommand) CommandName() string {
+	return "rt_deps_bundle"
+}
+
+func (dbc *DepsBundleCommand) Run() error {
+	bc := dbc.buildConfiguration
+	servicesManager, err := utils.CreateServiceManager(dbc.serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	var dependencies []moduleDependency
+	if dbc.local {
+		dependencies, err = readLocalDependencies(bc)
+	} else {
+		dependencies, err = readPublishedDependencies(servicesManager, bc)
+	}
+	if err != nil {
+		return err
+	}
+	if len(dependencies) == 0 {
+		return errorutils.CheckError(errors.New("build " + bc.BuildName + "/" + bc.BuildNumber + " has no dependencies"))
+	}
+	var conditions []string
+	for _, dependency := range dependencies {
+		if dependency.Checksum != nil && dependency.Checksum.Sha1 != "" {
+			condition, err := json.Marshal(map[string]string{"actual_sha1": strings.ToLower(dependency.Checksum.Sha1)})
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			conditions = append(conditions, string(condition))
+		}
+	}
+	items, err := projectutils.FindItems(servicesManager, "", conditions)
+	if err != nil {
+		return err
+	}
+	files, missing, ambiguous, conflicting := createBundleFiles(dependencies, items)
+	itemsByFile := mapItemsByFile(items)
+	goModuleFiles, err := findGoModuleFiles(servicesManager, files, itemsByFile)
+	if err != nil {
+		return err
+	}
+	files = append(files, goModuleFiles...)
+	if len(ambiguous) > 0 {
+		log.Warn("The following dependencies were found by their sha1 checksums in a few repositories, but not in the path of their package type, " +
+			"so the bundle includes the files of the first repositories by name:\n" + strings.Join(ambiguous, "\n"))
+	}
+	if len(conflicting) > 0 {
+		log.Warn("The names of the files of the following dependencies are taken by the files of other dependencies in the flat layouts, " +
+			"so the bundle includes them under the paths of their repositories:\n" + strings.Join(conflicting, "\n"))
+	}
+	if len(missing) > 0 {
+		log.Warn("The following dependencies have no sha1 checksum in the build-info, or weren't found in Artifactory, so the bundle doesn't include them:\n" +
+			strings.Join(missing, "\n"))
+	}
+
+	bundleDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer fileutils.RemoveTempDir(bundleDir)
+	if err = downloadBundleFiles(servicesManager, files, itemsByFile, bundleDir); err != nil {
+		return err
+	}
+	manifest := &Manifest{Version: manifestVersion, BuildName: bc.BuildName, BuildNumber: bc.BuildNumber, Project: bc.Project, Files: files, Missing: missing}
+	if err = writeManifest(manifest, bundleDir); err != nil {
+		return err
+	}
+	bundlePath := dbc.bundlePath
+	if bundlePath == "" {
+		bundlePath = bc.BuildName + "-" + bc.BuildNumber + "-deps.tar.gz"
+	}
+	if err = createArchive(bundleDir, bundlePath); err != nil {
+		return err
+	}
+	log.Info("Bundled", strconv.Itoa(len(files)), "files of", strconv.Itoa(len(dependencies)-len(missing)), "dependencies to", bundlePath+".")
+	return nil
+}
+
+// Reads the dependencies of the partials and the generated build-infos of the local build.
+func readLocalDependencies(buildConfiguration *utils.BuildConfiguration) ([]moduleDependency, error) {
+	partials, err := utils.ReadPartialBuildInfoFiles(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project)
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []moduleDependency
+	for _, partial := range partials {
+		dependencies = appendDependencies(dependencies, partial.ModuleType, partial.Dependencies)
+	}
+	buildInfos, err := utils.GetGeneratedBuildsInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project)
+	if err != nil {
+		return nil, err
+	}
+	for _, buildInfo := range buildInfos {
+		for _, module := range buildInfo.Modules {
+			dependencies = appendDependencies(dependencies, module.Type, module.Dependencies)
+		}
+	}
+	return dependencies, nil
+}
+
+func readPublishedDependencies(servicesManager artifactory.ArtifactoryServicesManager, buildConfiguration *utils.BuildConfiguration) ([]moduleDependency, error) {
+	params := services.NewBuildInfoParams()
+	params.BuildName, params.BuildNumber, params.ProjectKey = buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project
+	publishedBuildInfo, found, err := servicesManager.GetBuildInfo(params)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errorutils.CheckError(errors.New("build " + buildConfiguration.BuildName + "/" + buildConfiguration.BuildNumber +
+			" wasn't found in Artifactory. Use --local to bundle the dependencies of a build, which isn't published yet"))
+	}
+	var dependencies []moduleDependency
+	for _, module := range publishedBuildInfo.BuildInfo.Modules {
+		dependencies = appendDependencies(dependencies, module.Type, module.Dependencies)
+	}
+	return dependencies, nil
+}
+
+func appendDependencies(dependencies []moduleDependency, moduleType buildinfo.ModuleType, moduleDependencies []buildinfo.Dependency) []moduleDependency {
+	for _, dependency := range moduleDependencies {
+		dependencies = append(dependencies, moduleDependency{Dependency: dependency, moduleType: moduleType})
+	}
+	return dependencies
+}
+
+// Returns the .mod and .info files of the Go modules of the bundle, which are stored next to their zips.
+// The build-info includes the zips only, but Go requires the other files as well. The files are searched by a query
+// per repository. The found items are added to itemsByFile.
+func findGoModuleFiles(servicesManager artifactory.ArtifactoryServicesManager, files []BundleFile, itemsByFile map[string]projectutils.AqlItem) ([]BundleFile, error) {
+	conditionsByRepo := make(map[string][]string)
+	var zips []string
+	for _, file := range files {
+		if file.Layout != Go || !strings.HasSuffix(file.RepoPath, ".zip") {
+			continue
+		}
+		repo := itemsByFile[fileKey(file.Sha1, file.RepoPath)].Repo
+		dir, base := path.Split(strings.TrimSuffix(file.RepoPath, ".zip"))
+		for _, extension := range goModuleFileExtensions {
+			condition, err := json.Marshal(map[string]string{"path": strings.TrimSuffix(dir, "/"), "name": base + extension})
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			conditionsByRepo[repo] = append(conditionsByRepo[repo], string(condition))
+		}
+		zips = append(zips, repo+"/"+file.RepoPath)
+	}
+	var repos []string
+	for repo := range conditionsByRepo {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	var goModuleFiles []BundleFile
+	foundCounts := make(map[string]int)
+	for _, repo := range repos {
+		found, err := projectutils.FindItems(servicesManager, repo, conditionsByRepo[repo])
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range found {
+			repoPath := path.Join(item.Path, item.Name)
+			itemsByFile[fileKey(item.Sha1, repoPath)] = item
+			goModuleFiles = append(goModuleFiles, BundleFile{Layout: Go, Path: getBundlePath(Go, item), RepoPath: repoPath, Sha1: item.Sha1})
+			foundCounts[repo+"/"+strings.TrimSuffix(repoPath, path.Ext(repoPath))+".zip"]++
+		}
+	}
+	for _, zip := range zips {
+		if foundCounts[zip] < len(goModuleFileExtensions) {
+			log.Warn("The .mod or .info files of " + zip + " weren't found.")
+		}
+	}
+	return goModuleFiles, nil
+}
+
+// Maps the items by their sha1 checksums and their paths in their repositories.
+// If an item is found in a few repositories, the first of them is mapped.
+func mapItemsByFile(items []projectutils.AqlItem) map[string]projectutils.AqlItem {
+	itemsByFile := make(map[string]projectutils.AqlItem)
+	for _, item := range items {
+		key := fileKey(item.Sha1, path.Join(item.Path, item.Name))
+		if _, ok := itemsByFile[key]; !ok {
+			itemsByFile[key] = item
+		}
+	}
+	return itemsByFile
+}
+
+func fileKey(sha1, repoPath string) string {
+	return sha1 + ":" + repoPath
+}
+
+func downloadBundleFiles(servicesManager artifactory.ArtifactoryServicesManager, files []BundleFile, itemsByFile map[string]projectutils.AqlItem, bundleDir string) error {
+	serviceDetails := servicesManager.GetConfig().GetServiceDetails()
+	httpClientDetails := serviceDetails.CreateHttpClientDetails()
+	for _, file := range files {
+		item := itemsByFile[fileKey(file.Sha1, file.RepoPath)]
+		localPath := filepath.Join(bundleDir, filepath.FromSlash(file.Path))
+		downloadFileDetails := &httpclient.DownloadFileDetails{
+			FileName:      item.Name,
+			DownloadPath:  serviceDetails.GetUrl() + item.Repo + "/" + file.RepoPath,
+			LocalPath:     filepath.Dir(localPath),
+			LocalFileName: filepath.Base(localPath),
+			ExpectedSha1:  file.Sha1,
+		}
+		log.Debug("Downloading", item.Repo+"/"+file.RepoPath, "to", file.Path)
+		resp, err := servicesManager.Client().DownloadFile(downloadFileDetails, "", &httpClientDetails, false)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return errorutils.CheckError(errors.New("failed to download " + item.Repo + "/" + file.RepoPath + ": " + resp.Status))
+		}
+	}
+	return nil
+}
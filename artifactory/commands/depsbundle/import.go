@@ -0,0 +1,147 @@
+package depsbundle
+
+import (
+	"errors"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Uploads the files of a bundle, created by DepsBundleCommand, to the repositories of an isolated server, by the
+// layout of each file. The files are uploaded to the same paths they had in their source repositories, so the
+// package managers resolve them from the repositories as they did from the source server.
+type DepsBundleImportCommand struct {
+	serverDetails *config.ServerDetails
+	bundlePath    string
+	repos         map[string]string
+	dryRun        bool
+}
+
+func NewDepsBundleImportCommand() *DepsBundleImportCommand {
+	return &DepsBundleImportCommand{}
+}
+
+func (dbic *DepsBundleImportCommand) SetServerDetails(serverDetails *config.ServerDetails) *DepsBundleImportCommand {
+	dbic.serverDetails = serverDetails
+	return dbic
+}
+
+func (dbic *DepsBundleImportCommand) SetBundlePath(bundlePath string) *DepsBundleImportCommand {
+	dbic.bundlePath = bundlePath
+	return dbic
+}
+
+// Sets the target repositories by layout.
+func (dbic *DepsBundleImportCommand) SetRepos(repos map[string]string) *DepsBundleImportCommand {
+	dbic.repos = repos
+	return dbic
+}
+
+func (dbic *DepsBundleImportCommand) SetDryRun(dryRun bool) *DepsBundleImportCommand {
+	dbic.dryRun = dryRun
+	return dbic
+}
+
+func (dbic *DepsBundleImportCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dbic.serverDetails, nil
+}
+
+func (dbic *DepsBundleImportCommand) CommandName() string {
+	return "rt_deps_bundle_import"
+}
+
+func (dbic *DepsBundleImportCommand) Run() error {
+	bundleDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer fileutils.RemoveTempDir(bundleDir)
+	if err = extractArchive(dbic.bundlePath, bundleDir); err != nil {
+		return err
+	}
+	manifest, err := readManifest(bundleDir)
+	if err != nil {
+		return err
+	}
+	files, err := createDeployableFiles(manifest, bundleDir, dbic.repos)
+	if err != nil {
+		return err
+	}
+	if dbic.dryRun {
+		for _, file := range files {
+			log.Info("[Dry run] Would upload", file.TargetPath)
+		}
+		return nil
+	}
+	if _, err = projectutils.DeployFiles(dbic.serverDetails, files, nil); err != nil {
+		return err
+	}
+	log.Info("Imported", strconv.Itoa(len(files)), "files of build", manifest.BuildName+"/"+manifest.BuildNumber, "from", dbic.bundlePath+".")
+	return nil
+}
+
+// Verifies the checksums of the files of the extracted bundle, and returns them with the paths they're uploaded to.
+// Fails if the repository of any of the layouts of the bundle isn't set, or if the paths of a file lead outside of the
+// bundle or outside of its repository.
+func createDeployableFiles(manifest *Manifest, bundleDir string, repos map[string]string) ([]projectutils.DeployableFile, error) {
+	var missingRepos []string
+	var files []projectutils.DeployableFile
+	for _, file := range manifest.Files {
+		repo, ok := repos[file.Layout]
+		if !ok {
+			if !projectutils.ContainsString(missingRepos, file.Layout) {
+				missingRepos = append(missingRepos, file.Layout)
+			}
+			continue
+		}
+		localPath, err := getLocalPath(bundleDir, file.Path)
+		if err != nil {
+			return nil, err
+		}
+		if cleanRepoPath := path.Clean(file.RepoPath); path.IsAbs(cleanRepoPath) || cleanRepoPath == ".." || strings.HasPrefix(cleanRepoPath, "../") {
+			return nil, errorutils.CheckError(errors.New("the repository path '" + file.RepoPath + "' of the bundle file " + file.Path + " leads outside of its repository"))
+		}
+		details, err := fileutils.GetFileDetails(localPath)
+		if err != nil {
+			return nil, errorutils.CheckError(errors.New("the bundle file " + file.Path + " is missing: " + err.Error()))
+		}
+		if details.Checksum.Sha1 != file.Sha1 {
+			return nil, errorutils.CheckError(errors.New("the sha1 checksum of the bundle file " + file.Path + " is " +
+				details.Checksum.Sha1 + " rather than " + file.Sha1))
+		}
+		files = append(files, projectutils.DeployableFile{LocalPath: localPath, TargetPath: repo + "/" + file.RepoPath})
+	}
+	if len(missingRepos) > 0 {
+		sort.Strings(missingRepos)
+		return nil, errorutils.CheckError(errors.New("the bundle includes " + strings.Join(missingRepos, ", ") +
+			" files. Set their repositories by the --repos option, such as --repos=\"" + missingRepos[0] + "=<repository>\""))
+	}
+	return files, nil
+}
+
+// Parses the target repositories of the layouts, in the form of "maven=libs-release-local;npm=npm-local".
+func ParseRepos(repos string) (map[string]string, error) {
+	parsed := make(map[string]string)
+	for _, pair := range strings.Split(repos, ";") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		keyValue := strings.SplitN(pair, "=", 2)
+		if len(keyValue) != 2 || strings.TrimSpace(keyValue[1]) == "" {
+			return nil, errorutils.CheckError(errors.New("invalid repository '" + pair + "'. The repositories should be in the form of <layout>=<repository>"))
+		}
+		layout := strings.TrimSpace(keyValue[0])
+		if !projectutils.ContainsString(layouts, layout) {
+			return nil, errorutils.CheckError(errors.New("unknown layout '" + layout + "'. The layouts are " + strings.Join(layouts, ", ")))
+		}
+		parsed[layout] = strings.TrimSpace(keyValue[1])
+	}
+	return parsed, nil
+}
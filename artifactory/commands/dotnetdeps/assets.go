@@ -0,0 +1,135 @@
+package dotnetdeps
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type assetsFile struct {
+	// The entries by their <name>/<version> keys, for every target.
+	Targets   map[string]map[string]assetsTargetEntry `json:"targets"`
+	Libraries map[string]assetsLibrary                `json:"libraries"`
+	Project   struct {
+		Restore struct {
+			PackagesPath string `json:"packagesPath"`
+		} `json:"restore"`
+		// The direct dependencies by the target framework aliases, as written in the project file.
+		Frameworks map[string]struct {
+			Dependencies map[string]struct {
+				Target string `json:"target"`
+			} `json:"dependencies"`
+		} `json:"frameworks"`
+	} `json:"project"`
+}
+
+type assetsTargetEntry struct {
+	Type         string            `json:"type"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+type assetsLibrary struct {
+	Type   string `json:"type"`
+	Sha512 string `json:"sha512"`
+}
+
+func readAssetsIfExists(assetsPath string) (*assetsFile, error) {
+	exists, err := fileutils.IsFileExists(assetsPath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	content, err := ioutil.ReadFile(assetsPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	assets := &assetsFile{}
+	return assets, errorutils.CheckError(json.Unmarshal(content, assets))
+}
+
+// Returns the dependencies of every target framework of the project. The targets are named either by the framework
+// aliases, or by the full framework names, such as .NETCoreApp,Version=v6.0.
+func (assets *assetsFile) frameworks() []*Framework {
+	var keys []string
+	for key := range assets.Targets {
+		keys = append(keys, key)
+	}
+	targetNames, keysByTarget := groupByFramework(keys)
+	var aliases []string
+	for alias := range assets.Project.Frameworks {
+		aliases = append(aliases, alias)
+	}
+	frameworkNames, _ := groupByFramework(aliases)
+	var frameworks []*Framework
+	for _, alias := range frameworkNames {
+		target := matchTarget(alias, targetNames, len(frameworkNames) == 1)
+		if target == "" {
+			log.Debug("No target was found in the assets file for the target framework", alias)
+			continue
+		}
+		g := make(graph)
+		for _, key := range keysByTarget[target] {
+			for id, entry := range assets.Targets[key] {
+				var children []string
+				for child := range entry.Dependencies {
+					children = append(children, child)
+				}
+				name, version := splitAssetsId(id)
+				g.add(&graphEntry{name: name, version: version, sha512: assets.Libraries[id].Sha512, isProject: entry.Type == "project", dependencies: children})
+			}
+		}
+		var roots []string
+		for name, dependency := range assets.Project.Frameworks[alias].Dependencies {
+			if dependency.Target == "" || dependency.Target == "Package" {
+				roots = append(roots, name)
+			}
+		}
+		frameworks = append(frameworks, g.framework(alias, roots))
+	}
+	return frameworks
+}
+
+func matchTarget(alias string, targetNames []string, single bool) string {
+	fullName := frameworkFullName(alias)
+	for _, target := range targetNames {
+		if strings.EqualFold(target, alias) || strings.EqualFold(target, fullName) {
+			return target
+		}
+	}
+	// A project with a single framework has a single target.
+	if single && len(targetNames) == 1 {
+		return targetNames[0]
+	}
+	return ""
+}
+
+var frameworkAliasRegExp = regexp.MustCompile(`^(netcoreapp|netstandard|net)(\d+)(\.\d+)?`)
+
+// Returns the full name of a target framework alias, such as .NETCoreApp,Version=v6.0 for net6.0 or net6.0-windows,
+// and .NETFramework,Version=v4.7.2 for net472.
+func frameworkFullName(alias string) string {
+	match := frameworkAliasRegExp.FindStringSubmatch(strings.ToLower(alias))
+	if match == nil {
+		return ""
+	}
+	switch {
+	case match[1] == "netcoreapp" || match[1] == "net" && match[3] != "":
+		return ".NETCoreApp,Version=v" + match[2] + match[3]
+	case match[1] == "netstandard":
+		return ".NETStandard,Version=v" + match[2] + match[3]
+	}
+	// The .NET Framework aliases have a digit for every part of the version.
+	return ".NETFramework,Version=v" + strings.Join(strings.Split(match[2], ""), ".")
+}
+
+// The IDs of the assets entries are <name>/<version>.
+func splitAssetsId(id string) (name, version string) {
+	if i := strings.Index(id, "/"); i >= 0 {
+		return id[:i], id[i+1:]
+	}
+	return id, ""
+}
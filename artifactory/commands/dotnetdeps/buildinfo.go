@@ -0,0 +1,101 @@
+package dotnetdeps
+
+import (
+	"sort"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils/dotnet/dependencies"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// Creates the build-info module of the project. The target frameworks are recorded as the scopes of the dependencies,
+// and the paths in the requestedBy fields end with the module ID. The checksums are calculated from the nupkg files in
+// the global packages folder, and the sha512 hashes recorded by NuGet are added as module hashes. The IDs of the packages
+// without nupkg files are returned.
+func CreateModule(project *Project, moduleId string) (module buildinfo.Module, missing []string, err error) {
+	module = buildinfo.Module{Id: moduleId, Type: buildinfo.Nuget}
+	if project.IsPackagesConfig() {
+		module.Dependencies, err = packagesConfigDependencies(project)
+		return
+	}
+	packages := make(map[string]*Package)
+	ids := func(frameworkPackages []*Package) []string {
+		var ids []string
+		for _, pkg := range frameworkPackages {
+			packages[pkg.Id()] = pkg
+			ids = append(ids, pkg.Id())
+		}
+		return ids
+	}
+	children := func(id string) []string {
+		return ids(packages[id].Dependencies)
+	}
+	dependenciesById := make(map[string]*buildinfo.Dependency)
+	for _, framework := range project.Frameworks {
+		projectutils.WalkDependencyGraph(moduleId, ids(framework.Direct), children, func(id string, pathToRoot []string) {
+			dependency, exists := dependenciesById[id]
+			if !exists {
+				dependency = &buildinfo.Dependency{Id: id, Type: "nupkg"}
+				dependenciesById[id] = dependency
+			}
+			if framework.Name != "" && !projectutils.ContainsString(dependency.Scopes, framework.Name) {
+				dependency.Scopes = append(dependency.Scopes, framework.Name)
+			}
+			dependency.RequestedBy = projectutils.AppendRequestedBy(dependency.RequestedBy, pathToRoot)
+		})
+	}
+
+	sha512s := make(projectutils.ModuleHashes)
+	for _, dependency := range dependenciesById {
+		pkg := packages[dependency.Id]
+		sha512s.Add("sha512", dependency.Id, pkg.Sha512)
+		if dependency.Checksum, err = nupkgChecksum(pkg, project.PackagesPath); err != nil {
+			return
+		}
+		if dependency.Checksum == nil {
+			missing = append(missing, dependency.Id)
+		}
+		module.Dependencies = append(module.Dependencies, *dependency)
+	}
+	sort.Slice(module.Dependencies, func(i, j int) bool {
+		return module.Dependencies[i].Id < module.Dependencies[j].Id
+	})
+	sort.Strings(missing)
+	sha512s.SetProperties(&module)
+	return
+}
+
+// Returns the checksums of the nupkg file of the package, or nil if the file doesn't exist in the global packages folder.
+func nupkgChecksum(pkg *Package, packagesPath string) (*buildinfo.Checksum, error) {
+	nupkgPath := pkg.NupkgPath(packagesPath)
+	exists, err := fileutils.IsFileExists(nupkgPath, false)
+	if err != nil || !exists {
+		return nil, err
+	}
+	details, err := fileutils.GetFileDetails(nupkgPath)
+	if err != nil {
+		return nil, err
+	}
+	return &buildinfo.Checksum{Sha1: details.Checksum.Sha1, Md5: details.Checksum.Md5}, nil
+}
+
+// packages.config has neither target frameworks nor a dependency tree, so its dependencies are read as in jfrog-cli-core.
+func packagesConfigDependencies(project *Project) ([]buildinfo.Dependency, error) {
+	extractor, err := dependencies.CreateCompatibleExtractor(project.Name, project.Source)
+	if err != nil || extractor == nil {
+		return nil, err
+	}
+	all, err := extractor.AllDependencies()
+	if err != nil {
+		return nil, err
+	}
+	var result []buildinfo.Dependency
+	for _, dependency := range all {
+		result = append(result, *dependency)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result, nil
+}
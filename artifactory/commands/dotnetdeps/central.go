@@ -0,0 +1,198 @@
+package dotnetdeps
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The properties and items of an MSBuild project file, or of Directory.Packages.props. Conditions aren't evaluated.
+type msbuildProject struct {
+	PropertyGroups []struct {
+		TargetFramework                string `xml:"TargetFramework"`
+		TargetFrameworks               string `xml:"TargetFrameworks"`
+		ManagePackageVersionsCentrally string `xml:"ManagePackageVersionsCentrally"`
+	} `xml:"PropertyGroup"`
+	ItemGroups []struct {
+		PackageReferences       []packageItem `xml:"PackageReference"`
+		PackageVersions         []packageItem `xml:"PackageVersion"`
+		GlobalPackageReferences []packageItem `xml:"GlobalPackageReference"`
+	} `xml:"ItemGroup"`
+}
+
+// The version and the version override may be set either as attributes or as child elements.
+type packageItem struct {
+	Include             string `xml:"Include,attr"`
+	VersionAttr         string `xml:"Version,attr"`
+	Version             string `xml:"Version"`
+	VersionOverrideAttr string `xml:"VersionOverride,attr"`
+	VersionOverride     string `xml:"VersionOverride"`
+}
+
+func (item *packageItem) version() string {
+	return firstNonEmpty(item.VersionAttr, item.Version)
+}
+
+func (item *packageItem) versionOverride() string {
+	return firstNonEmpty(item.VersionOverrideAttr, item.VersionOverride)
+}
+
+func readMsbuildProject(path string) (*msbuildProject, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	project := &msbuildProject{}
+	return project, errorutils.CheckError(xml.Unmarshal(content, project))
+}
+
+// Returns the value of the last property group, which sets the property, as MSBuild does.
+func (project *msbuildProject) property(get func(index int) string) string {
+	value := ""
+	for i := range project.PropertyGroups {
+		if v := strings.TrimSpace(get(i)); v != "" {
+			value = v
+		}
+	}
+	return value
+}
+
+// Returns the central package versions by the lower cased package names, and the global package references, which
+// are dependencies of every project.
+func (project *msbuildProject) centralVersions() (versions map[string]string, global []packageItem) {
+	versions = make(map[string]string)
+	for _, itemGroup := range project.ItemGroups {
+		for _, item := range itemGroup.PackageVersions {
+			versions[strings.ToLower(item.Include)] = item.version()
+		}
+		global = append(global, itemGroup.GlobalPackageReferences...)
+	}
+	return
+}
+
+// Reads the package references of the project file, as the direct dependencies of all of its target frameworks.
+// If the package versions are managed centrally, the versions are taken from the nearest Directory.Packages.props
+// in the project directory or above it, unless the package reference overrides the version.
+func readProjectFile(projectFile string) ([]*Framework, error) {
+	project, err := readMsbuildProject(projectFile)
+	if err != nil {
+		return nil, err
+	}
+	items := project.packageReferences()
+	var centralVersions map[string]string
+	propsPath, err := findCentralPackagesFile(filepath.Dir(projectFile))
+	if err != nil {
+		return nil, err
+	}
+	if propsPath != "" {
+		props, err := readMsbuildProject(propsPath)
+		if err != nil {
+			return nil, err
+		}
+		manageCentrally := project.property(func(i int) string { return project.PropertyGroups[i].ManagePackageVersionsCentrally })
+		if manageCentrally == "" {
+			manageCentrally = props.property(func(i int) string { return props.PropertyGroups[i].ManagePackageVersionsCentrally })
+		}
+		if strings.EqualFold(manageCentrally, "true") {
+			log.Debug("Reading the central package versions of", projectFile, "from", propsPath)
+			var global []packageItem
+			centralVersions, global = props.centralVersions()
+			items = append(items, global...)
+		}
+	}
+
+	var direct []*Package
+	added := make(map[string]bool)
+	for _, item := range items {
+		if item.Include == "" || added[strings.ToLower(item.Include)] {
+			continue
+		}
+		version := item.versionOverride()
+		if version == "" && centralVersions != nil {
+			version = centralVersions[strings.ToLower(item.Include)]
+		}
+		if version == "" {
+			version = item.version()
+		}
+		if version == "" {
+			log.Warn("No version was found for the package", item.Include, "of", projectFile+". Skipping it.")
+			continue
+		}
+		added[strings.ToLower(item.Include)] = true
+		direct = append(direct, &Package{Name: item.Include, Version: lowestVersion(version)})
+	}
+
+	names := project.property(func(i int) string { return project.PropertyGroups[i].TargetFrameworks })
+	if names == "" {
+		names = project.property(func(i int) string { return project.PropertyGroups[i].TargetFramework })
+	}
+	var frameworks []*Framework
+	for _, name := range strings.Split(names, ";") {
+		if name = strings.TrimSpace(name); name != "" {
+			frameworks = append(frameworks, &Framework{Name: name, Direct: direct})
+		}
+	}
+	if len(frameworks) == 0 {
+		frameworks = append(frameworks, &Framework{Direct: direct})
+	}
+	return frameworks, nil
+}
+
+func (project *msbuildProject) packageReferences() []packageItem {
+	var items []packageItem
+	for _, itemGroup := range project.ItemGroups {
+		items = append(items, itemGroup.PackageReferences...)
+	}
+	return items
+}
+
+// Returns the path of the nearest Directory.Packages.props in the directory or above it, or an empty string if there's none.
+func findCentralPackagesFile(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	for {
+		propsPath := filepath.Join(dir, CentralPackagesFile)
+		exists, err := fileutils.IsFileExists(propsPath, false)
+		if err != nil || exists {
+			return propsPath, err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// NuGet resolves a version range to the lowest version it includes, so the version of [1.2.3, ) or [1.2.3] is 1.2.3.
+// Other ranges and floating versions are returned as they are.
+func lowestVersion(version string) string {
+	version = strings.TrimSpace(version)
+	if !strings.HasPrefix(version, "[") {
+		return version
+	}
+	lowest := strings.TrimPrefix(version, "[")
+	if i := strings.IndexAny(lowest, ",]"); i >= 0 {
+		lowest = lowest[:i]
+	}
+	if lowest = strings.TrimSpace(lowest); lowest == "" {
+		return version
+	}
+	return lowest
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+package dotnetdeps
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/dotnet"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Runs a .NET command with the dotnet command of jfrog-cli-core, and collects the build-info dependencies by itself.
+// The dependencies are read from packages.lock.json when the project has one, and the versions managed centrally in
+// Directory.Packages.props are honored. The target frameworks of every project are recorded as build-info scopes.
+// Unlike 'rt nuget', whose build-info is collected by jfrog-cli-core from the restored packages only, and which gives
+// every project the module name if one is set, the locked versions are recorded and the projects keep separate modules.
+type DotnetCommand struct {
+	dotnetCommand      *dotnet.DotnetCoreCliCommand
+	buildConfiguration *utils.BuildConfiguration
+	argAndFlags        []string
+	serverDetails      *config.ServerDetails
+}
+
+// The dotnet command of jfrog-cli-core should be created without a build configuration, so that it doesn't collect
+// the build-info.
+func NewDotnetCommand(dotnetCommand *dotnet.DotnetCoreCliCommand) *DotnetCommand {
+	return &DotnetCommand{dotnetCommand: dotnetCommand}
+}
+
+func (dc *DotnetCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *DotnetCommand {
+	dc.buildConfiguration = buildConfiguration
+	return dc
+}
+
+// Sets the arguments and flags of the .NET command, which may start with the path of the solution or the project.
+func (dc *DotnetCommand) SetArgAndFlags(argAndFlags []string) *DotnetCommand {
+	dc.argAndFlags = argAndFlags
+	return dc
+}
+
+func (dc *DotnetCommand) SetServerDetails(serverDetails *config.ServerDetails) *DotnetCommand {
+	dc.serverDetails = serverDetails
+	return dc
+}
+
+func (dc *DotnetCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dc.serverDetails, nil
+}
+
+func (dc *DotnetCommand) CommandName() string {
+	return dc.dotnetCommand.CommandName()
+}
+
+func (dc *DotnetCommand) Run() error {
+	if err := dc.dotnetCommand.Run(); err != nil {
+		return err
+	}
+	if dc.buildConfiguration.BuildName == "" || dc.buildConfiguration.BuildNumber == "" {
+		return nil
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	solutionPath, slnFile, projectFile, err := getSolutionPath(workingDir, dc.argAndFlags)
+	if err != nil {
+		return err
+	}
+	projects, err := LoadProjects(solutionPath, slnFile, projectFile)
+	if err != nil {
+		return err
+	}
+	var modules []buildinfo.Module
+	for _, project := range projects {
+		moduleId := getModuleId(dc.buildConfiguration.Module, project.Name, len(projects))
+		module, missing, err := CreateModule(project, moduleId)
+		if err != nil {
+			return err
+		}
+		if len(missing) > 0 {
+			log.Warn("The following packages of", project.Name, "were not found in", project.PackagesPath+", and are added to the build-info without checksums:\n"+strings.Join(missing, "\n"))
+		}
+		log.Info("Adding", strconv.Itoa(len(module.Dependencies)), "dependencies of", project.Source, "to module", module.Id, "of the build-info.")
+		modules = append(modules, module)
+	}
+	if err = utils.SaveBuildGeneralDetails(dc.buildConfiguration.BuildName, dc.buildConfiguration.BuildNumber, dc.buildConfiguration.Project); err != nil {
+		return err
+	}
+	return utils.SaveBuildInfo(dc.buildConfiguration.BuildName, dc.buildConfiguration.BuildNumber, dc.buildConfiguration.Project, &buildinfo.BuildInfo{Modules: modules})
+}
+
+// Returns the build-info module ID of a project. The module name replaces the project name if the solution has a single
+// project, and prefixes it otherwise, so that the projects are kept in separate modules.
+func getModuleId(moduleName, projectName string, projectsCount int) string {
+	if moduleName == "" {
+		return projectName
+	}
+	if projectsCount == 1 {
+		return moduleName
+	}
+	return moduleName + "/" + projectName
+}
+
+// Returns the directory of the solution, and the sln file or the project file, if the first argument is one. The first
+// argument may be the path of a solution directory, an sln file or a project file. Otherwise, the solution is in the
+// working directory.
+func getSolutionPath(workingDir string, argAndFlags []string) (solutionPath, slnFile, projectFile string, err error) {
+	if len(argAndFlags) == 0 || strings.HasPrefix(argAndFlags[0], "-") {
+		return workingDir, "", "", nil
+	}
+	path := argAndFlags[0]
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(workingDir, path)
+	}
+	exists, err := fileutils.IsDirExists(path, false)
+	if err != nil || exists {
+		return path, "", "", err
+	}
+	if exists, err = fileutils.IsFileExists(path, false); err != nil || !exists {
+		return workingDir, "", "", err
+	}
+	if filepath.Ext(path) == ".sln" {
+		return filepath.Dir(path), filepath.Base(path), "", nil
+	}
+	if strings.HasSuffix(filepath.Ext(path), "proj") {
+		return filepath.Dir(path), "", path, nil
+	}
+	return workingDir, "", "", nil
+}
@@ -0,0 +1,128 @@
+package dotnetdeps
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func ids(packages []*Package) []string {
+	var result []string
+	for _, pkg := range packages {
+		result = append(result, pkg.Id())
+	}
+	return result
+}
+
+func loadSolution(t *testing.T) []*Project {
+	packagesPath, err := filepath.Abs(filepath.Join("testdata", "packages"))
+	assert.NoError(t, err)
+	if value, exists := os.LookupEnv("NUGET_PACKAGES"); exists {
+		defer os.Setenv("NUGET_PACKAGES", value)
+	} else {
+		defer os.Unsetenv("NUGET_PACKAGES")
+	}
+	assert.NoError(t, os.Setenv("NUGET_PACKAGES", packagesPath))
+	projects, err := LoadProjects(filepath.Join("testdata", "solution"), "", "")
+	assert.NoError(t, err)
+	// The solution folder isn't a project.
+	if !assert.Len(t, projects, 3) {
+		t.FailNow()
+	}
+	return projects
+}
+
+func TestReadLockFile(t *testing.T) {
+	app := loadSolution(t)[0]
+	assert.Equal(t, "app", app.Name)
+	assert.Equal(t, LockFileName, filepath.Base(app.Source))
+	if !assert.Len(t, app.Frameworks, 2) {
+		return
+	}
+	// The packages of the referenced project are direct dependencies.
+	net6 := app.Frameworks[0]
+	assert.Equal(t, "net6.0", net6.Name)
+	assert.Equal(t, []string{"Microsoft.Extensions.Logging.Abstractions:8.0.0", "Newtonsoft.Json:13.0.1"}, ids(net6.Direct))
+	assert.Equal(t, []string{"System.Memory:4.5.5"}, ids(net6.Direct[0].Dependencies))
+	assert.Equal(t, "ppPFpBcvxdsfUonNcvITKqLl3bqxWbDCZIzDWHzjpdAHRFfZe0Dw9HmA0+za13IdyrgJwpkDTDA9fHaxOrt20A==", net6.Direct[1].Sha512)
+
+	// The runtime specific section is merged into its framework.
+	net8 := app.Frameworks[1]
+	assert.Equal(t, "net8.0", net8.Name)
+	assert.Equal(t, []string{"Microsoft.Extensions.Logging.Abstractions:8.0.0", "Newtonsoft.Json:13.0.1",
+		"runtime.linux-x64.Microsoft.DotNet.ILCompiler:8.0.0", "Serilog:3.0.1"}, ids(net8.Direct))
+}
+
+func TestReadAssets(t *testing.T) {
+	lib := loadSolution(t)[1]
+	assert.Equal(t, filepath.Join("obj", "project.assets.json"), filepath.Join(filepath.Base(filepath.Dir(lib.Source)), filepath.Base(lib.Source)))
+	if assert.Len(t, lib.Frameworks, 1) {
+		// The target is named by the full name of the framework.
+		assert.Equal(t, "netstandard2.0", lib.Frameworks[0].Name)
+		assert.Equal(t, []string{"Microsoft.Extensions.Logging.Abstractions:8.0.0"}, ids(lib.Frameworks[0].Direct))
+		assert.Equal(t, []string{"System.Memory:4.5.5"}, ids(lib.Frameworks[0].Direct[0].Dependencies))
+	}
+}
+
+func TestReadCentralVersions(t *testing.T) {
+	tool := loadSolution(t)[2]
+	assert.Equal(t, "tool.csproj", filepath.Base(tool.Source))
+	if assert.Len(t, tool.Frameworks, 2) {
+		assert.Equal(t, "net6.0", tool.Frameworks[0].Name)
+		assert.Equal(t, "net8.0", tool.Frameworks[1].Name)
+		// The version override is preferred over the central version, the package without a version is skipped,
+		// and the global package reference is added.
+		assert.Equal(t, []string{"Serilog:3.0.1", "Newtonsoft.Json:12.0.3", "Nerdbank.GitVersioning:3.6.133"}, ids(tool.Frameworks[0].Direct))
+	}
+}
+
+func TestFrameworkFullName(t *testing.T) {
+	assert.Equal(t, ".NETCoreApp,Version=v8.0", frameworkFullName("net8.0-windows"))
+	assert.Equal(t, ".NETCoreApp,Version=v3.1", frameworkFullName("netcoreapp3.1"))
+	assert.Equal(t, ".NETStandard,Version=v2.0", frameworkFullName("netstandard2.0"))
+	assert.Equal(t, ".NETFramework,Version=v4.7.2", frameworkFullName("net472"))
+}
+
+func TestGetModuleId(t *testing.T) {
+	assert.Equal(t, "app", getModuleId("", "app", 3))
+	assert.Equal(t, "shop", getModuleId("shop", "app", 1))
+	assert.Equal(t, "shop/app", getModuleId("shop", "app", 3))
+}
+
+func TestCreateModule(t *testing.T) {
+	app := loadSolution(t)[0]
+	module, missing, err := CreateModule(app, "app")
+	assert.NoError(t, err)
+	assert.Equal(t, buildinfo.Nuget, module.Type)
+	if !assert.Len(t, module.Dependencies, 5) {
+		return
+	}
+	memory := module.Dependencies[3]
+	assert.Equal(t, buildinfo.Dependency{Id: "System.Memory:4.5.5", Type: "nupkg", Scopes: []string{"net6.0"},
+		RequestedBy: [][]string{{"Microsoft.Extensions.Logging.Abstractions:8.0.0", "app"}}}, memory)
+	newtonsoft := module.Dependencies[1]
+	assert.Equal(t, []string{"net6.0", "net8.0"}, newtonsoft.Scopes)
+	// The checksums are calculated from the nupkg file in the global packages folder.
+	if assert.NotNil(t, newtonsoft.Checksum) {
+		assert.Equal(t, "66d9440adaf3ec473dfc24497a22fb6f75e3cdea", newtonsoft.Sha1)
+	}
+	assert.NotContains(t, missing, newtonsoft.Id)
+	assert.Contains(t, missing, memory.Id)
+	assert.Equal(t, "ppPFpBcvxdsfUonNcvITKqLl3bqxWbDCZIzDWHzjpdAHRFfZe0Dw9HmA0+za13IdyrgJwpkDTDA9fHaxOrt20A==", module.Properties.(map[string]string)["sha512.Newtonsoft.Json:13.0.1"])
+}
+
+func TestLoadProjectFile(t *testing.T) {
+	solutionPath := filepath.Join("testdata", "solution")
+	solutionPath, slnFile, projectFile, err := getSolutionPath(solutionPath, []string{filepath.Join("lib", "lib.csproj"), "--no-restore"})
+	assert.NoError(t, err)
+	assert.Empty(t, slnFile)
+	// Only the given project is loaded, rather than the projects of the sln file next to it.
+	projects, err := LoadProjects(solutionPath, slnFile, projectFile)
+	assert.NoError(t, err)
+	if assert.Len(t, projects, 1) {
+		assert.Equal(t, "lib", projects[0].Name)
+	}
+}
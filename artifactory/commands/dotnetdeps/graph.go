@@ -0,0 +1,105 @@
+package dotnetdeps
+
+import (
+	"sort"
+	"strings"
+)
+
+// An entry of the dependency graph of a target framework, as read from packages.lock.json or project.assets.json.
+type graphEntry struct {
+	name    string
+	version string
+	sha512  string
+	// True for a referenced project, which isn't a package. The packages it depends on are dependencies of the project.
+	isProject bool
+	// The names of the entries it depends on.
+	dependencies []string
+}
+
+// The dependency graph of a target framework, by the lower cased names of the entries, since NuGet package names are
+// case insensitive. A framework has a single version of every package.
+type graph map[string]*graphEntry
+
+func (g graph) add(entry *graphEntry) {
+	if _, exists := g[strings.ToLower(entry.name)]; !exists {
+		g[strings.ToLower(entry.name)] = entry
+	}
+}
+
+// Creates the framework with the packages of the root entries as its direct dependencies. The packages, which the
+// referenced projects depend on, are direct dependencies too.
+func (g graph) framework(name string, roots []string) *Framework {
+	framework := &Framework{Name: name}
+	packages := make(map[string]*Package)
+	var getPackage func(entry *graphEntry) *Package
+	getPackage = func(entry *graphEntry) *Package {
+		key := strings.ToLower(entry.name)
+		if pkg, exists := packages[key]; exists {
+			return pkg
+		}
+		pkg := &Package{Name: entry.name, Version: entry.version, Sha512: entry.sha512}
+		packages[key] = pkg
+		for _, child := range g.sorted(entry.dependencies) {
+			if !child.isProject {
+				pkg.Dependencies = append(pkg.Dependencies, getPackage(child))
+			}
+		}
+		return pkg
+	}
+	added := make(map[string]bool)
+	visitedProjects := make(map[string]bool)
+	var addRoots func(names []string)
+	addRoots = func(names []string) {
+		for _, entry := range g.sorted(names) {
+			key := strings.ToLower(entry.name)
+			if entry.isProject {
+				if !visitedProjects[key] {
+					visitedProjects[key] = true
+					addRoots(entry.dependencies)
+				}
+				continue
+			}
+			if !added[key] {
+				added[key] = true
+				framework.Direct = append(framework.Direct, getPackage(entry))
+			}
+		}
+	}
+	addRoots(roots)
+	sort.Slice(framework.Direct, func(i, j int) bool {
+		return strings.ToLower(framework.Direct[i].Name) < strings.ToLower(framework.Direct[j].Name)
+	})
+	return framework
+}
+
+// Returns the entries of the names, which exist in the graph, sorted by their names.
+func (g graph) sorted(names []string) []*graphEntry {
+	var entries []*graphEntry
+	for _, name := range names {
+		if entry, exists := g[strings.ToLower(name)]; exists {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].name) < strings.ToLower(entries[j].name)
+	})
+	return entries
+}
+
+// Returns the framework names of the map keys. The runtime specific sections, named <framework>/<runtime identifier>,
+// are merged into the sections of their frameworks, so every framework is returned once, with its keys.
+func groupByFramework(keys []string) (frameworks []string, keysByFramework map[string][]string) {
+	sort.Strings(keys)
+	keysByFramework = make(map[string][]string)
+	for _, key := range keys {
+		framework := key
+		if i := strings.Index(key, "/"); i >= 0 {
+			framework = key[:i]
+		}
+		if _, exists := keysByFramework[framework]; !exists {
+			frameworks = append(frameworks, framework)
+		}
+		keysByFramework[framework] = append(keysByFramework[framework], key)
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package dotnetdeps
+
+import (
+	"encoding/json"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The types of the packages.lock.json entries, which the dependency tree starts from. The Transitive entries, and the
+// CentralTransitive ones, whose versions are pinned by central package management, are reached from them.
+const (
+	lockDirect  = "Direct"
+	lockProject = "Project"
+)
+
+type lockFile struct {
+	Version int `json:"version"`
+	// The entries by their names, for every target framework.
+	Dependencies map[string]map[string]lockEntry `json:"dependencies"`
+}
+
+type lockEntry struct {
+	Type         string            `json:"type"`
+	Requested    string            `json:"requested"`
+	Resolved     string            `json:"resolved"`
+	ContentHash  string            `json:"contentHash"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// Parses packages.lock.json, which records the resolved packages of every target framework, with their content hashes.
+func parseLockFile(content []byte) ([]*Framework, error) {
+	lock := &lockFile{}
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var keys []string
+	for key := range lock.Dependencies {
+		keys = append(keys, key)
+	}
+	frameworkNames, keysByFramework := groupByFramework(keys)
+	var frameworks []*Framework
+	for _, frameworkName := range frameworkNames {
+		g := make(graph)
+		var roots []string
+		for _, key := range keysByFramework[frameworkName] {
+			for name, entry := range lock.Dependencies[key] {
+				var children []string
+				for child := range entry.Dependencies {
+					children = append(children, child)
+				}
+				g.add(&graphEntry{name: name, version: entry.Resolved, sha512: entry.ContentHash, isProject: entry.Type == lockProject, dependencies: children})
+				if entry.Type == lockDirect || entry.Type == lockProject {
+					roots = append(roots, name)
+				}
+			}
+		}
+		frameworks = append(frameworks, g.framework(frameworkName, roots))
+	}
+	return frameworks, nil
+}
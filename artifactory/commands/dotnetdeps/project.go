@@ -0,0 +1,191 @@
+package dotnetdeps
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils/dotnet/dependencies"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	LockFileName        = "packages.lock.json"
+	CentralPackagesFile = "Directory.Packages.props"
+)
+
+// A NuGet package, with the packages it depends on.
+type Package struct {
+	Name    string
+	Version string
+	// The base64 sha512 hash of the nupkg file, as recorded by NuGet. Empty if it isn't known.
+	Sha512       string
+	Dependencies []*Package
+}
+
+func (pkg *Package) Id() string {
+	return pkg.Name + ":" + pkg.Version
+}
+
+// Returns the path of the nupkg file in the global packages folder, in which the names and versions are lower cased.
+func (pkg *Package) NupkgPath(packagesPath string) string {
+	name, version := strings.ToLower(pkg.Name), strings.ToLower(pkg.Version)
+	return filepath.Join(packagesPath, name, version, name+"."+version+".nupkg")
+}
+
+// The direct dependencies of a project for one of its target frameworks.
+type Framework struct {
+	// The target framework, such as net6.0. Empty if the project file doesn't specify it.
+	Name   string
+	Direct []*Package
+}
+
+// A .NET project, with its dependencies for every target framework.
+type Project struct {
+	Name string
+	// The path of the project file.
+	Path string
+	// The file the dependencies are read from, which is packages.lock.json, obj/project.assets.json, packages.config
+	// or the project file itself.
+	Source     string
+	Frameworks []*Framework
+	// The global packages folder the packages are restored to.
+	PackagesPath string
+}
+
+var projectLineRegExp = regexp.MustCompile(`(?m)^Project\("[^"]*"\)\s*=\s*"([^"]*)"\s*,\s*"([^"]*)"`)
+
+// Loads the projects of the solution in the directory. The projects are read from the sln file, or from all of the sln
+// files in the directory if slnFile is empty. Without sln files, the project files in the directory are loaded.
+// If projectFile is set, only this project is loaded.
+func LoadProjects(solutionPath, slnFile, projectFile string) ([]*Project, error) {
+	if projectFile != "" {
+		project, err := ReadProject(getProjectName(projectFile), projectFile)
+		if err != nil {
+			return nil, err
+		}
+		return []*Project{project}, nil
+	}
+	var slnFiles []string
+	if slnFile != "" {
+		slnFiles = append(slnFiles, filepath.Join(solutionPath, slnFile))
+	} else {
+		var err error
+		slnFiles, err = fileutils.ListFilesByFilterFunc(solutionPath, func(filePath string) (bool, error) {
+			return filepath.Ext(filePath) == ".sln", nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	var projectFiles [][2]string
+	for _, sln := range slnFiles {
+		content, err := ioutil.ReadFile(sln)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for _, match := range projectLineRegExp.FindAllStringSubmatch(string(content), -1) {
+			// The paths in sln files are written with backslashes.
+			projectFile := filepath.Join(filepath.Dir(sln), filepath.FromSlash(strings.Replace(match[2], `\`, "/", -1)))
+			if !strings.HasSuffix(filepath.Ext(projectFile), "proj") {
+				log.Debug("Skipping the project", match[1]+", since it doesn't have a '.*proj' file path.")
+				continue
+			}
+			projectFiles = append(projectFiles, [2]string{match[1], projectFile})
+		}
+	}
+	if len(slnFiles) == 0 {
+		paths, err := fileutils.ListFilesByFilterFunc(solutionPath, func(filePath string) (bool, error) {
+			return strings.HasSuffix(filepath.Ext(filePath), "proj"), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(paths)
+		for _, projectFile := range paths {
+			projectFiles = append(projectFiles, [2]string{getProjectName(projectFile), projectFile})
+		}
+	}
+	var projects []*Project
+	for _, projectFile := range projectFiles {
+		project, err := ReadProject(projectFile[0], projectFile[1])
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
+
+// Returns the name of the project file without its extension, as the name of the project.
+func getProjectName(projectFile string) string {
+	return strings.TrimSuffix(filepath.Base(projectFile), filepath.Ext(projectFile))
+}
+
+// Reads the dependencies of the project. packages.lock.json is preferred, then obj/project.assets.json and then
+// packages.config. Without any of them, the package references of the project file are read, with their versions
+// taken from Directory.Packages.props if the versions are managed centrally.
+func ReadProject(name, projectFile string) (*Project, error) {
+	project := &Project{Name: name, Path: projectFile}
+	projectDir := filepath.Dir(projectFile)
+	assetsPath := filepath.Join(projectDir, dependencies.AssetDirName, dependencies.AssetFileName)
+	assets, err := readAssetsIfExists(assetsPath)
+	if err != nil {
+		return nil, err
+	}
+	if assets != nil && assets.Project.Restore.PackagesPath != "" {
+		project.PackagesPath = assets.Project.Restore.PackagesPath
+	} else if project.PackagesPath, err = defaultPackagesPath(); err != nil {
+		return nil, err
+	}
+
+	lockFilePath := filepath.Join(projectDir, LockFileName)
+	exists, err := fileutils.IsFileExists(lockFilePath, false)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		content, err := ioutil.ReadFile(lockFilePath)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		project.Source = lockFilePath
+		project.Frameworks, err = parseLockFile(content)
+		return project, err
+	}
+	if assets != nil {
+		project.Source = assetsPath
+		project.Frameworks = assets.frameworks()
+		return project, nil
+	}
+	packagesConfigPath := filepath.Join(projectDir, dependencies.PackagesFileName)
+	if exists, err = fileutils.IsFileExists(packagesConfigPath, false); err != nil || exists {
+		project.Source = packagesConfigPath
+		return project, err
+	}
+	project.Source = projectFile
+	project.Frameworks, err = readProjectFile(projectFile)
+	return project, err
+}
+
+// Returns true if the dependencies are read from packages.config, which has no target frameworks.
+func (project *Project) IsPackagesConfig() bool {
+	return filepath.Base(project.Source) == dependencies.PackagesFileName
+}
+
+// Returns the NUGET_PACKAGES environment variable, or ~/.nuget/packages by default.
+func defaultPackagesPath() (string, error) {
+	if packagesPath := os.Getenv("NUGET_PACKAGES"); packagesPath != "" {
+		return packagesPath, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(homeDir, ".nuget", "packages"), nil
+}
@@ -0,0 +1,146 @@
+package dotnetdeps
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils/dotnet/dependencies"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	clientutils "github.com/jfrog/jfrog-client-go/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// A node of the dependency tree, in the format of jfrog-cli-core.
+type treeNode struct {
+	Id           string      `json:"id,omitempty"`
+	Sha1         string      `json:"sha1,omitempty"`
+	Md5          string      `json:"md5,omitempty"`
+	Dependencies []*treeNode `json:"dependencies,omitempty"`
+}
+
+type frameworkTree struct {
+	Name         string      `json:"name,omitempty"`
+	Dependencies []*treeNode `json:"dependencies,omitempty"`
+}
+
+type projectTree struct {
+	Name string `json:"name,omitempty"`
+	// The dependencies of all of the target frameworks.
+	Dependencies     interface{}      `json:"dependencies,omitempty"`
+	TargetFrameworks []*frameworkTree `json:"targetFrameworks,omitempty"`
+}
+
+// Prints the dependency tree of every project of the solution in the working directory. Besides the dependencies of
+// all of the target frameworks, the tree of every target framework is printed.
+func DependencyTreeCmd() error {
+	workspace, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	projects, err := LoadProjects(workspace, "", "")
+	if err != nil {
+		return err
+	}
+	var trees []*projectTree
+	for _, project := range projects {
+		tree, err := createProjectTree(project)
+		if err != nil {
+			return err
+		}
+		trees = append(trees, tree)
+	}
+	content, err := json.Marshal(&struct {
+		Projects []*projectTree `json:"projects,omitempty"`
+	}{Projects: trees})
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Output(clientutils.IndentJson(content))
+	return nil
+}
+
+func createProjectTree(project *Project) (*projectTree, error) {
+	tree := &projectTree{Name: project.Name}
+	if project.IsPackagesConfig() {
+		extractor, err := dependencies.CreateCompatibleExtractor(project.Name, project.Source)
+		if err != nil || extractor == nil {
+			return tree, err
+		}
+		tree.Dependencies, err = dependencies.CreateDependencyTree(extractor)
+		return tree, err
+	}
+	// Merges the dependencies of the packages in all of the target frameworks, by the package IDs.
+	all := &Package{}
+	merged := make(map[string]*Package)
+	var merge func(target *Package, packages []*Package)
+	merge = func(target *Package, packages []*Package) {
+		for _, pkg := range packages {
+			mergedPkg, exists := merged[pkg.Id()]
+			if !exists {
+				mergedPkg = &Package{Name: pkg.Name, Version: pkg.Version}
+				merged[pkg.Id()] = mergedPkg
+			}
+			if !containsPackage(target.Dependencies, mergedPkg) {
+				target.Dependencies = append(target.Dependencies, mergedPkg)
+			}
+			merge(mergedPkg, pkg.Dependencies)
+		}
+	}
+	for _, framework := range project.Frameworks {
+		merge(all, framework.Direct)
+	}
+	checksums := make(map[string]*treeNode)
+	var err error
+	tree.Dependencies, err = createNodes(all.Dependencies, project.PackagesPath, checksums, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, framework := range project.Frameworks {
+		nodes, err := createNodes(framework.Direct, project.PackagesPath, checksums, nil)
+		if err != nil {
+			return nil, err
+		}
+		tree.TargetFrameworks = append(tree.TargetFrameworks, &frameworkTree{Name: framework.Name, Dependencies: nodes})
+	}
+	return tree, nil
+}
+
+// Creates the nodes of the packages and of the packages they depend on. The checksums of the nupkg files are cached by
+// the package IDs. The path holds the IDs of the ancestors, to stop at cycles.
+func createNodes(packages []*Package, packagesPath string, checksums map[string]*treeNode, path []string) ([]*treeNode, error) {
+	var nodes []*treeNode
+	for _, pkg := range packages {
+		if projectutils.ContainsString(path, pkg.Id()) {
+			continue
+		}
+		cached, exists := checksums[pkg.Id()]
+		if !exists {
+			cached = &treeNode{}
+			checksum, err := nupkgChecksum(pkg, packagesPath)
+			if err != nil {
+				return nil, err
+			}
+			if checksum != nil {
+				cached.Sha1, cached.Md5 = checksum.Sha1, checksum.Md5
+			}
+			checksums[pkg.Id()] = cached
+		}
+		children, err := createNodes(pkg.Dependencies, packagesPath, checksums, append(path, pkg.Id()))
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, &treeNode{Id: pkg.Id(), Sha1: cached.Sha1, Md5: cached.Md5, Dependencies: children})
+	}
+	return nodes, nil
+}
+
+func containsPackage(packages []*Package, pkg *Package) bool {
+	for _, p := range packages {
+		if strings.EqualFold(p.Id(), pkg.Id()) {
+			return true
+		}
+	}
+	return false
+}
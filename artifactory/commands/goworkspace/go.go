@@ -0,0 +1,137 @@
+package goworkspace
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/golang"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	_go "github.com/jfrog/jfrog-client-go/artifactory/services/go"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/version"
+)
+
+// The format of 'go list -deps', which prints the module of every package as path@version. The main modules, which are
+// the workspace modules, are printed empty, and the modules replaced by local directories are printed without a version.
+const listDepsModulesFormat = `{{with .Module}}{{if not .Main}}{{with .Replace}}{{.Path}}@{{.Version}}{{else}}{{.Path}}@{{.Version}}{{end}}{{end}}{{end}}`
+
+// Runs the go command in a go.work workspace with the dependencies resolved from Artifactory, as 'jfrog rt go' does.
+// If the build name and number are passed, a build-info module is recorded for every module of the workspace, with the
+// modules its packages depend on, in the versions selected for the workspace. The workspace modules themselves aren't
+// recorded as dependencies, since they aren't in the modules cache.
+type GoWorkspaceCommand struct {
+	configFilePath string
+	goWorkPath     string
+	args           []string
+	goNative       *golang.GoNativeCommand
+}
+
+func NewGoWorkspaceCommand() *GoWorkspaceCommand {
+	return &GoWorkspaceCommand{goNative: golang.NewGoNativeCommand()}
+}
+
+func (gwc *GoWorkspaceCommand) SetConfigFilePath(configFilePath string) *GoWorkspaceCommand {
+	gwc.configFilePath = configFilePath
+	return gwc
+}
+
+func (gwc *GoWorkspaceCommand) SetGoWorkPath(goWorkPath string) *GoWorkspaceCommand {
+	gwc.goWorkPath = goWorkPath
+	return gwc
+}
+
+func (gwc *GoWorkspaceCommand) SetArgs(args []string) *GoWorkspaceCommand {
+	gwc.args = args
+	return gwc
+}
+
+func (gwc *GoWorkspaceCommand) ServerDetails() (*config.ServerDetails, error) {
+	return gwc.goNative.ServerDetails()
+}
+
+func (gwc *GoWorkspaceCommand) CommandName() string {
+	return "rt_go_workspace"
+}
+
+func (gwc *GoWorkspaceCommand) Run() error {
+	args, buildConfiguration, err := utils.ExtractBuildDetailsFromArgs(gwc.args)
+	if err != nil {
+		return err
+	}
+	if buildConfiguration.Module != "" {
+		return errorutils.CheckError(errors.New("the --module option can't be used in a " + goWorkFileName + " workspace, since every Go module of the workspace is recorded as a build-info module"))
+	}
+	// The go command runs without the build details, so that jfrog-cli-core doesn't record the dependencies of
+	// the go.mod in the working directory only.
+	gwc.goNative.SetConfigFilePath(gwc.configFilePath).SetGoArg(args)
+	if err = gwc.goNative.Run(); err != nil {
+		return err
+	}
+	if buildConfiguration.BuildName == "" || buildConfiguration.BuildNumber == "" {
+		return nil
+	}
+	if err = utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project); err != nil {
+		return err
+	}
+	includeInfoFiles, err := gwc.shouldIncludeInfoFiles()
+	if err != nil {
+		return err
+	}
+	modules, err := LoadModules(gwc.goWorkPath)
+	if err != nil {
+		return err
+	}
+	var buildInfoModules []buildinfo.Module
+	for _, module := range modules {
+		output, err := runGoList(module.Dir, os.Environ(), "-deps", "-f", listDepsModulesFormat, "./...")
+		if err != nil {
+			return err
+		}
+		dependencies, err := createDependencies(parseDepsModules(output), includeInfoFiles)
+		if err != nil {
+			return err
+		}
+		sort.Slice(dependencies, func(i, j int) bool {
+			return dependencies[i].Id < dependencies[j].Id
+		})
+		log.Info("Adding", strconv.Itoa(len(dependencies)), "dependencies to module", module.Path, "of the build-info.")
+		buildInfoModules = append(buildInfoModules, buildinfo.Module{Id: module.Path, Type: buildinfo.Go, Dependencies: dependencies})
+	}
+	return utils.SaveBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project, &buildinfo.BuildInfo{Modules: buildInfoModules})
+}
+
+// The info files are recorded as dependencies by Artifactory versions, which support them, as in 'jfrog rt go'.
+func (gwc *GoWorkspaceCommand) shouldIncludeInfoFiles() (bool, error) {
+	serverDetails, err := gwc.ServerDetails()
+	if err != nil {
+		return false, err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, false)
+	if err != nil {
+		return false, err
+	}
+	artifactoryVersion, err := servicesManager.GetConfig().GetServiceDetails().GetVersion()
+	if err != nil {
+		return false, err
+	}
+	return version.NewVersion(artifactoryVersion).AtLeast(_go.ArtifactoryMinSupportedVersionForInfoFile), nil
+}
+
+// Returns the modules printed by 'go list -deps' with listDepsModulesFormat in the path@version form,
+// without the modules replaced by local directories.
+func parseDepsModules(output string) map[string]bool {
+	modules := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasSuffix(line, "@") {
+			modules[line] = true
+		}
+	}
+	return modules
+}
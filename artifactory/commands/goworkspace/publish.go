@@ -0,0 +1,335 @@
+package goworkspace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/gocmd/executers"
+	executersutils "github.com/jfrog/gocmd/executers/utils"
+	commandutils "github.com/jfrog/jfrog-cli-core/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils/golang"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils/golang/project"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	_go "github.com/jfrog/jfrog-client-go/artifactory/services/go"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"github.com/jfrog/jfrog-client-go/utils/version"
+)
+
+const minSupportedArtifactoryVersion = "6.6.1"
+
+// Publishes every module of the go.work workspace to the deployment repository of the go-config, with the same version.
+// The modules are published in dependency order, and the published go.mod of every module requires the other workspace
+// modules in the published version. If the build name and number are set, a build-info module is recorded for every Go module.
+type GoWorkspacePublishCommand struct {
+	configFilePath     string
+	version            string
+	buildConfiguration *utils.BuildConfiguration
+	detailedSummary    bool
+	result             *commandutils.Result
+	serverDetails      *config.ServerDetails
+}
+
+func NewGoWorkspacePublishCommand() *GoWorkspacePublishCommand {
+	return &GoWorkspacePublishCommand{result: new(commandutils.Result)}
+}
+
+func (gwc *GoWorkspacePublishCommand) SetConfigFilePath(configFilePath string) *GoWorkspacePublishCommand {
+	gwc.configFilePath = configFilePath
+	return gwc
+}
+
+func (gwc *GoWorkspacePublishCommand) SetVersion(version string) *GoWorkspacePublishCommand {
+	gwc.version = version
+	return gwc
+}
+
+func (gwc *GoWorkspacePublishCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *GoWorkspacePublishCommand {
+	gwc.buildConfiguration = buildConfiguration
+	return gwc
+}
+
+func (gwc *GoWorkspacePublishCommand) SetDetailedSummary(detailedSummary bool) *GoWorkspacePublishCommand {
+	gwc.detailedSummary = detailedSummary
+	return gwc
+}
+
+func (gwc *GoWorkspacePublishCommand) Result() *commandutils.Result {
+	return gwc.result
+}
+
+func (gwc *GoWorkspacePublishCommand) ServerDetails() (*config.ServerDetails, error) {
+	return gwc.serverDetails, nil
+}
+
+func (gwc *GoWorkspacePublishCommand) CommandName() string {
+	return "rt_go_publish_workspace"
+}
+
+func (gwc *GoWorkspacePublishCommand) Run() error {
+	if gwc.version == "" {
+		return errorutils.CheckError(errors.New("the version of the published modules is required"))
+	}
+	if err := golang.LogGoVersion(); err != nil {
+		return err
+	}
+	vConfig, err := utils.ReadConfigFile(gwc.configFilePath, utils.YAML)
+	if err != nil {
+		return err
+	}
+	deployer, err := utils.GetRepoConfigByPrefix(gwc.configFilePath, utils.ProjectConfigDeployerPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	if gwc.serverDetails, err = deployer.ServerDetails(); err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(gwc.serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	artifactoryVersion, err := servicesManager.GetConfig().GetServiceDetails().GetVersion()
+	if err != nil {
+		return err
+	}
+	if !version.NewVersion(artifactoryVersion).AtLeast(minSupportedArtifactoryVersion) {
+		return errorutils.CheckError(errors.New("This operation requires Artifactory version " + minSupportedArtifactoryVersion + " or higher."))
+	}
+	includeInfoFiles := version.NewVersion(artifactoryVersion).AtLeast(_go.ArtifactoryMinSupportedVersionForInfoFile)
+
+	goWorkPath, err := FindGoWork()
+	if err != nil {
+		return err
+	}
+	modules, err := LoadModules(goWorkPath)
+	if err != nil {
+		return err
+	}
+	if err = ValidateModulePaths(modules, gwc.version); err != nil {
+		return err
+	}
+	workspaceModules := make(map[string]bool)
+	for _, module := range modules {
+		workspaceModules[module.Path] = true
+	}
+	collectBuildInfo := gwc.buildConfiguration.BuildName != "" && gwc.buildConfiguration.BuildNumber != ""
+	if collectBuildInfo {
+		if err = utils.SaveBuildGeneralDetails(gwc.buildConfiguration.BuildName, gwc.buildConfiguration.BuildNumber, gwc.buildConfiguration.Project); err != nil {
+			return err
+		}
+	}
+	log.Info("Publishing", len(modules), "modules of", goWorkPath, "in version", gwc.version+".")
+	var buildInfoModules []buildinfo.Module
+	var readers []*content.ContentReader
+	defer func() {
+		for _, reader := range readers {
+			reader.Close()
+		}
+	}()
+	for _, module := range modules {
+		buildInfoModule, reader, err := gwc.publishModule(module, modules, workspaceModules, deployer.TargetRepo(), servicesManager, includeInfoFiles, collectBuildInfo)
+		if reader != nil {
+			readers = append(readers, reader)
+		}
+		if err != nil {
+			return err
+		}
+		if buildInfoModule != nil {
+			buildInfoModules = append(buildInfoModules, *buildInfoModule)
+		}
+	}
+	if gwc.detailedSummary && len(readers) > 0 {
+		reader, err := content.MergeReaders(readers, content.DefaultKey)
+		if err != nil {
+			return err
+		}
+		gwc.result.SetReader(reader)
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	return utils.SaveBuildInfo(gwc.buildConfiguration.BuildName, gwc.buildConfiguration.BuildNumber, gwc.buildConfiguration.Project, &buildinfo.BuildInfo{Modules: buildInfoModules})
+}
+
+// Publishes the module from a copy of its directory, which includes the go.mod to publish, so the workspace is never changed.
+func (gwc *GoWorkspacePublishCommand) publishModule(module *Module, modules []*Module, workspaceModules map[string]bool, targetRepo string,
+	servicesManager artifactory.ArtifactoryServicesManager, includeInfoFiles, collectBuildInfo bool) (*buildinfo.Module, *content.ContentReader, error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer fileutils.RemoveTempDir(tempDir)
+	if err = copyModuleDir(module.Dir, tempDir); err != nil {
+		return nil, nil, err
+	}
+	modContent, replaced := RewriteModFile(module.ModContent, workspaceModules, module.Requires, gwc.version)
+	if err = writeModFile(tempDir, modContent); err != nil {
+		return nil, nil, err
+	}
+	goProject, err := project.Load(gwc.version, tempDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	summary, err := goProject.PublishPackage(targetRepo, gwc.buildConfiguration.BuildName, gwc.buildConfiguration.BuildNumber, gwc.buildConfiguration.Project, servicesManager)
+	if err != nil {
+		return nil, nil, err
+	}
+	var reader *content.ContentReader
+	if summary != nil {
+		gwc.result.SetSuccessCount(gwc.result.SuccessCount() + summary.TotalSucceeded)
+		gwc.result.SetFailCount(gwc.result.FailCount() + summary.TotalFailed)
+		if gwc.detailedSummary {
+			reader = summary.TransferDetailsReader
+		} else {
+			summary.Close()
+		}
+	}
+	if !collectBuildInfo {
+		return nil, reader, nil
+	}
+	// The dependencies are resolved with the workspace modules replaced by their directories, as they are in the workspace.
+	// The workspace modules themselves aren't recorded as dependencies, since they aren't in the modules cache.
+	if err = writeModFile(tempDir, appendLocalReplaces(modContent, module, modules, replaced)); err != nil {
+		return nil, reader, err
+	}
+	dependencies, err := loadDependencies(tempDir, includeInfoFiles)
+	if err != nil {
+		return nil, reader, err
+	}
+	buildInfoModule := goProject.BuildInfo(true, "", targetRepo).Modules[0]
+	buildInfoModule.Dependencies = dependencies
+	return &buildInfoModule, reader, nil
+}
+
+// Returns the build-info dependencies of the module copy in the directory, which are listed by 'go list -m all' and
+// found in the modules cache. The go command runs outside of the workspace, with GOWORK=off in its environment,
+// since the module copy isn't one of the workspace modules.
+func loadDependencies(moduleDir string, includeInfoFiles bool) ([]buildinfo.Dependency, error) {
+	output, err := runGoList(moduleDir, append(os.Environ(), "GOWORK=off"), "-m", "-mod=mod", "all")
+	if err != nil {
+		return nil, err
+	}
+	return createDependencies(parseModuleList(output), includeInfoFiles)
+}
+
+func runGoList(dir string, env []string, args ...string) (string, error) {
+	args = append([]string{"list"}, args...)
+	log.Info("Running 'go "+strings.Join(args, " ")+"' in", dir)
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errorutils.CheckError(fmt.Errorf("'go list' failed: %s\n%s", err.Error(), stderr.String()))
+	}
+	return string(output), nil
+}
+
+// Returns the build-info dependencies of the modules in the path@version form, which are found in the modules cache.
+func createDependencies(modules map[string]bool, includeInfoFiles bool) ([]buildinfo.Dependency, error) {
+	cachePath, err := executersutils.GetCachePath()
+	if err != nil {
+		return nil, err
+	}
+	packages, err := executers.GetDependencies(cachePath, modules)
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []buildinfo.Dependency
+	for i := range packages {
+		if err = packages[i].CreateBuildInfoDependencies(includeInfoFiles); err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, packages[i].Dependencies()...)
+	}
+	return dependencies, nil
+}
+
+// Returns the modules listed by 'go list -m all' in the path@version form. A replaced module is listed in the version
+// of its replacement, and modules replaced by local directories, such as the workspace modules, are left out.
+func parseModuleList(output string) map[string]bool {
+	modules := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 2:
+			modules[fields[0]+"@"+fields[1]] = true
+		case len(fields) == 5 && fields[2] == "=>":
+			modules[fields[3]+"@"+fields[4]] = true
+		}
+	}
+	return modules
+}
+
+func appendLocalReplaces(modContent []byte, module *Module, modules []*Module, replaced map[string]bool) []byte {
+	var replaces []string
+	for _, other := range modules {
+		if other.Path != module.Path && !replaced[other.Path] {
+			replaces = append(replaces, "replace "+other.Path+" => "+filepath.ToSlash(other.Dir))
+		}
+	}
+	return []byte(strings.TrimRight(string(modContent), "\n") + "\n\n" + strings.Join(replaces, "\n") + "\n")
+}
+
+func writeModFile(dir string, modContent []byte) error {
+	return errorutils.CheckError(ioutil.WriteFile(filepath.Join(dir, goModFileName), modContent, 0644))
+}
+
+// Copies the files of the module, without the nested modules and the version control directories, which aren't published.
+func copyModuleDir(moduleDir, targetDir string) error {
+	return errorutils.CheckError(filepath.Walk(moduleDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(moduleDir, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if relPath == "." {
+				return nil
+			}
+			switch info.Name() {
+			case ".bzr", ".git", ".hg", ".svn":
+				return filepath.SkipDir
+			}
+			if _, err := os.Lstat(filepath.Join(path, goModFileName)); err == nil {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(filepath.Join(targetDir, relPath), 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFile(path, filepath.Join(targetDir, relPath))
+	}))
+}
+
+func copyFile(src, dst string) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+	dstFile, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+	_, err = io.Copy(dstFile, srcFile)
+	return err
+}
@@ -0,0 +1,3 @@
+module example.com/a
+
+require example.com/b v0.0.0
@@ -0,0 +1,12 @@
+module example.com/acme/api
+
+go 1.21.0
+
+toolchain go1.21.5
+
+require (
+	example.com/acme/core v0.0.0-00010101000000-000000000000
+	github.com/google/uuid v1.3.0 // indirect
+)
+
+replace example.com/acme/core => ../core
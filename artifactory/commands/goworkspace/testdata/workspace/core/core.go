@@ -0,0 +1,11 @@
+package core
+
+import (
+	"fmt"
+
+	"example.com/acme/util/strs"
+)
+
+func Name() string {
+	return fmt.Sprint(strs.Upper("core"))
+}
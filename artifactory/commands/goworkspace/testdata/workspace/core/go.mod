@@ -0,0 +1,3 @@
+module example.com/acme/core
+
+go 1.21.0
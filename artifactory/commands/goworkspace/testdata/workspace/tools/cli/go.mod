@@ -0,0 +1,12 @@
+module "example.com/acme/cli"
+
+go 1.21.0
+
+require example.com/acme/api v0.0.0
+
+require example.com/acme/core v0.0.0
+
+replace (
+	example.com/acme/api => ../../api
+	example.com/acme/core v0.0.0 => example.com/fork/core v1.0.0
+)
@@ -0,0 +1,7 @@
+package strs
+
+import "strings"
+
+func Upper(s string) string {
+	return strings.ToUpper(s)
+}
@@ -0,0 +1,373 @@
+package goworkspace
+
+import (
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+const (
+	goWorkFileName = "go.work"
+	goModFileName  = "go.mod"
+)
+
+// A module of the workspace.
+type Module struct {
+	// The module path, as declared in its go.mod.
+	Path string
+	// The absolute path of the module directory.
+	Dir string
+	// The content of the go.mod file.
+	ModContent []byte
+	// The paths of the other workspace modules, which this module requires in its go.mod or imports in its Go files.
+	Requires []string
+}
+
+// Returns the path of the go.work file of the working directory, or an error if the go command runs outside of a workspace.
+func FindGoWork() (string, error) {
+	goWorkPath, err := GetGoWorkPath()
+	if err != nil {
+		return "", err
+	}
+	if goWorkPath == "" {
+		return "", errorutils.CheckError(errors.New("could not find a " + goWorkFileName + " file in the working directory or in its parents"))
+	}
+	return goWorkPath, nil
+}
+
+// Returns the path of the go.work file the go command uses in the working directory, or an empty string if it runs
+// outside of a workspace. The GOWORK environment variable is used if set, as the go command does. Otherwise,
+// go.work is searched in the working directory and its parents.
+func GetGoWorkPath() (string, error) {
+	goWork := os.Getenv("GOWORK")
+	if goWork == "off" {
+		return "", nil
+	}
+	if goWork != "" {
+		return filepath.Abs(goWork)
+	}
+	workspaceDir, exists, err := fileutils.FindUpstream(goWorkFileName, fileutils.File)
+	if err != nil || !exists {
+		return "", err
+	}
+	return filepath.Join(workspaceDir, goWorkFileName), nil
+}
+
+// Returns the module directories listed by the use directives of the go.work file, relative to its directory.
+func ReadGoWork(goWorkPath string) ([]string, error) {
+	content, err := ioutil.ReadFile(goWorkPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var dirs []string
+	inUseBlock := false
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := lineFields(line)
+		switch {
+		case len(fields) == 0:
+		case inUseBlock && fields[0] == ")":
+			inUseBlock = false
+		case inUseBlock:
+			dirs = append(dirs, unquote(fields[0]))
+		case fields[0] == "use" && len(fields) > 1 && fields[1] == "(":
+			inUseBlock = true
+		case fields[0] == "use" && len(fields) > 1:
+			dirs = append(dirs, unquote(fields[1]))
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, errorutils.CheckError(errors.New(goWorkPath + " doesn't use any module"))
+	}
+	return dirs, nil
+}
+
+// Loads the modules of the workspace, and returns them ordered so that every module comes after the workspace modules it requires.
+func LoadModules(goWorkPath string) ([]*Module, error) {
+	dirs, err := ReadGoWork(goWorkPath)
+	if err != nil {
+		return nil, err
+	}
+	workspaceDir := filepath.Dir(goWorkPath)
+	var modules []*Module
+	for _, dir := range dirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(workspaceDir, filepath.FromSlash(dir))
+		}
+		content, err := ioutil.ReadFile(filepath.Join(dir, goModFileName))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		modulePath := parseModulePath(content)
+		if modulePath == "" {
+			return nil, errorutils.CheckError(errors.New("module path missing in " + filepath.Join(dir, goModFileName)))
+		}
+		modules = append(modules, &Module{Path: modulePath, Dir: dir, ModContent: content})
+	}
+	paths := make(map[string]bool)
+	for _, module := range modules {
+		paths[module.Path] = true
+	}
+	for _, module := range modules {
+		imported, err := importedModules(module.Dir, paths)
+		if err != nil {
+			return nil, err
+		}
+		requires := make(map[string]bool)
+		for _, required := range append(requiredPaths(module.ModContent), imported...) {
+			if paths[required] && required != module.Path && !requires[required] {
+				requires[required] = true
+				module.Requires = append(module.Requires, required)
+			}
+		}
+		sort.Strings(module.Requires)
+	}
+	return sortModules(modules)
+}
+
+// Returns the workspace modules, which are imported by the Go files of the module directory. Inside a workspace, a module
+// may import another workspace module without requiring it in its go.mod. An import belongs to the workspace module with
+// the longest path, which prefixes it.
+func importedModules(moduleDir string, modulePaths map[string]bool) ([]string, error) {
+	var imported []string
+	fileSet := token.NewFileSet()
+	err := filepath.Walk(moduleDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if filePath == moduleDir {
+				return nil
+			}
+			if name := info.Name(); name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if _, err := os.Lstat(filepath.Join(filePath, goModFileName)); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(filePath, ".go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fileSet, filePath, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, importSpec := range file.Imports {
+			if modulePath := findModuleOfImport(unquote(importSpec.Path.Value), modulePaths); modulePath != "" {
+				imported = append(imported, modulePath)
+			}
+		}
+		return nil
+	})
+	return imported, errorutils.CheckError(err)
+}
+
+func findModuleOfImport(importPath string, modulePaths map[string]bool) string {
+	for modulePath := importPath; modulePath != "." && modulePath != "/"; modulePath = path.Dir(modulePath) {
+		if modulePaths[modulePath] {
+			return modulePath
+		}
+	}
+	return ""
+}
+
+// Returns an error if the path of a module doesn't match the major version, in which the modules are published.
+// From v2, the module path must end with the /vN suffix of the major version, as the go command requires.
+func ValidateModulePaths(modules []*Module, version string) error {
+	major, err := getMajorVersion(version)
+	if err != nil {
+		return err
+	}
+	var invalid []string
+	for _, module := range modules {
+		if pathMajor := getPathMajorVersion(module.Path); pathMajor != major && !(pathMajor == 1 && major == 0) {
+			invalid = append(invalid, module.Path)
+		}
+	}
+	if len(invalid) > 0 {
+		return errorutils.CheckError(fmt.Errorf("the paths of the following modules don't match the major version of %s. From v2, the module path must end with /v<major version>:\n%s", version, strings.Join(invalid, "\n")))
+	}
+	return nil
+}
+
+// Returns the major version of a semantic version, such as 2 for v2.1.0.
+func getMajorVersion(version string) (int, error) {
+	major := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 2)[0]
+	value, err := strconv.Atoi(major)
+	if err != nil || !strings.HasPrefix(version, "v") {
+		return 0, errorutils.CheckError(errors.New("the version " + version + " isn't a semantic version, such as v1.2.0"))
+	}
+	return value, nil
+}
+
+// Returns the major version of the module path, which is the N of its /vN suffix, or of its .vN suffix for gopkg.in
+// modules. Module paths without a major version suffix are of v0 and v1, and 1 is returned for them.
+func getPathMajorVersion(modulePath string) int {
+	separator := "/v"
+	if strings.HasPrefix(modulePath, "gopkg.in/") {
+		separator = ".v"
+	}
+	i := strings.LastIndex(modulePath, separator)
+	if i < 0 {
+		return 1
+	}
+	major, err := strconv.Atoi(modulePath[i+len(separator):])
+	if err != nil || major < 1 {
+		return 1
+	}
+	return major
+}
+
+// Orders the modules by their dependencies, keeping the order of go.work between modules, which don't depend on each other.
+func sortModules(modules []*Module) ([]*Module, error) {
+	var sorted []*Module
+	published := make(map[string]bool)
+	for len(sorted) < len(modules) {
+		progress := false
+		for _, module := range modules {
+			if published[module.Path] || !allPublished(module.Requires, published) {
+				continue
+			}
+			sorted = append(sorted, module)
+			published[module.Path] = true
+			progress = true
+		}
+		if !progress {
+			var cycle []string
+			for _, module := range modules {
+				if !published[module.Path] {
+					cycle = append(cycle, module.Path)
+				}
+			}
+			return nil, errorutils.CheckError(errors.New("the following workspace modules require each other in a cycle, so they can't be published in order: " + strings.Join(cycle, ", ")))
+		}
+	}
+	return sorted, nil
+}
+
+func allPublished(paths []string, published map[string]bool) bool {
+	for _, path := range paths {
+		if !published[path] {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the go.mod content to publish: the workspace modules are required in the published version, and their
+// replacements with local directories, which are meaningless outside of the workspace, are removed. The workspace
+// modules, which are in requires but aren't required by the go.mod content, such as the imported ones, are added to it.
+// Returns also the workspace modules, which are still replaced by other modules.
+func RewriteModFile(content []byte, workspaceModules map[string]bool, requires []string, version string) (rewritten []byte, replaced map[string]bool) {
+	replaced = make(map[string]bool)
+	var lines []string
+	block := ""
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := lineFields(line)
+		if len(fields) == 0 {
+			lines = append(lines, line)
+			continue
+		}
+		directive := block
+		args := fields
+		if block == "" {
+			directive, args = fields[0], fields[1:]
+			if len(args) == 1 && args[0] == "(" {
+				block = directive
+				lines = append(lines, line)
+				continue
+			}
+		} else if fields[0] == ")" {
+			block = ""
+			lines = append(lines, line)
+			continue
+		}
+		switch {
+		case directive == "require" && len(args) >= 2 && workspaceModules[unquote(args[0])]:
+			line = strings.Replace(line, args[1], version, 1)
+		case directive == "replace" && len(args) >= 3 && workspaceModules[unquote(args[0])]:
+			target := args[len(args)-1]
+			if args[len(args)-2] != "=>" {
+				target = args[len(args)-2]
+			}
+			if isLocalPath(unquote(target)) {
+				continue
+			}
+			replaced[unquote(args[0])] = true
+		}
+		lines = append(lines, line)
+	}
+	required := make(map[string]bool)
+	for _, path := range requiredPaths(content) {
+		required[path] = true
+	}
+	var missing []string
+	for _, path := range requires {
+		if workspaceModules[path] && !required[path] {
+			missing = append(missing, "require "+path+" "+version)
+		}
+	}
+	if len(missing) > 0 {
+		lines = append([]string{strings.TrimRight(strings.Join(lines, "\n"), "\n"), ""}, append(missing, "")...)
+	}
+	return []byte(strings.Join(lines, "\n")), replaced
+}
+
+func parseModulePath(content []byte) string {
+	for _, line := range strings.Split(string(content), "\n") {
+		if fields := lineFields(line); len(fields) == 2 && fields[0] == "module" {
+			return unquote(fields[1])
+		}
+	}
+	return ""
+}
+
+// Returns the paths of the modules required by the go.mod content.
+func requiredPaths(content []byte) []string {
+	var paths []string
+	inRequireBlock := false
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := lineFields(line)
+		switch {
+		case len(fields) == 0:
+		case inRequireBlock && fields[0] == ")":
+			inRequireBlock = false
+		case inRequireBlock:
+			paths = append(paths, unquote(fields[0]))
+		case fields[0] == "require" && len(fields) == 2 && fields[1] == "(":
+			inRequireBlock = true
+		case fields[0] == "require" && len(fields) > 2:
+			paths = append(paths, unquote(fields[1]))
+		}
+	}
+	return paths
+}
+
+// Returns the fields of the line, without its comment.
+func lineFields(line string) []string {
+	if i := strings.Index(line, "//"); i >= 0 {
+		line = line[:i]
+	}
+	return strings.Fields(line)
+}
+
+func unquote(value string) string {
+	return strings.Trim(value, "\"`")
+}
+
+// Returns true if the replacement target is a directory, rather than a module path.
+func isLocalPath(target string) bool {
+	return strings.HasPrefix(target, "./") || strings.HasPrefix(target, "../") || filepath.IsAbs(target)
+}
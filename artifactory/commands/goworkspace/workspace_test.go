@@ -0,0 +1,111 @@
+package goworkspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadGoWork(t *testing.T) {
+	dirs, err := ReadGoWork(filepath.Join("testdata", "workspace", "go.work"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"./tools/cli", "./api", "./core", "./util"}, dirs)
+}
+
+func TestLoadModules(t *testing.T) {
+	modules, err := LoadModules(filepath.Join("testdata", "workspace", "go.work"))
+	assert.NoError(t, err)
+	var paths []string
+	for _, module := range modules {
+		paths = append(paths, module.Path)
+	}
+	// Every module comes after the modules it requires or imports.
+	assert.Equal(t, []string{"example.com/acme/util", "example.com/acme/core", "example.com/acme/api", "example.com/acme/cli"}, paths)
+	assert.Equal(t, []string{"example.com/acme/util"}, modules[1].Requires)
+	assert.Equal(t, []string{"example.com/acme/api", "example.com/acme/core"}, modules[3].Requires)
+
+	_, err = LoadModules(filepath.Join("testdata", "cycle", "go.work"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "example.com/a, example.com/b")
+	}
+}
+
+func TestRewriteModFile(t *testing.T) {
+	modules, err := LoadModules(filepath.Join("testdata", "workspace", "go.work"))
+	assert.NoError(t, err)
+	workspaceModules := map[string]bool{"example.com/acme/core": true, "example.com/acme/api": true, "example.com/acme/cli": true, "example.com/acme/util": true}
+
+	content, replaced := RewriteModFile(modules[2].ModContent, workspaceModules, modules[2].Requires, "v1.2.0")
+	modContent := string(content)
+	assert.Contains(t, modContent, "\texample.com/acme/core v1.2.0\n")
+	assert.Contains(t, modContent, "github.com/google/uuid v1.3.0 // indirect")
+	assert.Contains(t, modContent, "toolchain go1.21.5")
+	// The replacement with the local directory is removed.
+	assert.NotContains(t, modContent, "replace")
+	assert.Empty(t, replaced)
+
+	content, replaced = RewriteModFile(modules[3].ModContent, workspaceModules, modules[3].Requires, "v1.2.0")
+	modContent = string(content)
+	assert.Contains(t, modContent, "require example.com/acme/api v1.2.0\n")
+	assert.Contains(t, modContent, "require example.com/acme/core v1.2.0\n")
+	assert.NotContains(t, modContent, "../../api")
+	// The replacement with another module is kept.
+	assert.Contains(t, modContent, "example.com/acme/core v0.0.0 => example.com/fork/core v1.0.0")
+	assert.Equal(t, map[string]bool{"example.com/acme/core": true}, replaced)
+
+	withReplaces := string(appendLocalReplaces(content, modules[3], modules, replaced))
+	assert.True(t, strings.HasSuffix(withReplaces, "\nreplace example.com/acme/api => "+filepath.ToSlash(modules[2].Dir)+"\n"))
+
+	// The imported workspace module, which isn't required by go.mod, is added to it.
+	content, _ = RewriteModFile(modules[1].ModContent, workspaceModules, modules[1].Requires, "v1.2.0")
+	assert.Equal(t, "module example.com/acme/core\n\ngo 1.21.0\n\nrequire example.com/acme/util v1.2.0\n", string(content))
+}
+
+func TestValidateModulePaths(t *testing.T) {
+	modules := []*Module{{Path: "example.com/acme/core"}, {Path: "gopkg.in/acme/util.v1"}}
+	assert.NoError(t, ValidateModulePaths(modules, "v1.2.0"))
+	assert.NoError(t, ValidateModulePaths(modules, "v0.3.0"))
+	assert.Error(t, ValidateModulePaths(modules, "v2.0.0"))
+	assert.NoError(t, ValidateModulePaths([]*Module{{Path: "example.com/acme/core/v2"}, {Path: "gopkg.in/acme/util.v2"}}, "v2.0.1"))
+	err := ValidateModulePaths([]*Module{{Path: "example.com/acme/core/v2"}, {Path: "example.com/acme/api/v3"}}, "v3.0.0")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "example.com/acme/core/v2")
+		assert.NotContains(t, err.Error(), "example.com/acme/api/v3")
+	}
+	assert.Error(t, ValidateModulePaths(modules, "1.2.0"))
+}
+
+func TestParseModuleList(t *testing.T) {
+	output := "example.com/acme/api\nexample.com/acme/core v0.0.0 => /work/core\ngithub.com/google/uuid v1.3.0\ngolang.org/x/text v0.3.0 => golang.org/x/text v0.3.7\n"
+	assert.Equal(t, map[string]bool{"github.com/google/uuid@v1.3.0": true, "golang.org/x/text@v0.3.7": true}, parseModuleList(output))
+}
+
+func TestParseDepsModules(t *testing.T) {
+	output := "\n\ngithub.com/pkg/errors@v0.9.1\ngolang.org/x/text@v0.3.7\ngithub.com/pkg/errors@v0.9.1\nexample.com/local@\n"
+	assert.Equal(t, map[string]bool{"github.com/pkg/errors@v0.9.1": true, "golang.org/x/text@v0.3.7": true}, parseDepsModules(output))
+}
+
+func TestGetGoWorkPath(t *testing.T) {
+	previous, wasSet := os.LookupEnv("GOWORK")
+	defer func() {
+		if wasSet {
+			os.Setenv("GOWORK", previous)
+		} else {
+			os.Unsetenv("GOWORK")
+		}
+	}()
+	assert.NoError(t, os.Setenv("GOWORK", "off"))
+	goWorkPath, err := GetGoWorkPath()
+	assert.NoError(t, err)
+	assert.Empty(t, goWorkPath)
+	_, err = FindGoWork()
+	assert.Error(t, err)
+
+	assert.NoError(t, os.Setenv("GOWORK", filepath.Join("testdata", "workspace", "go.work")))
+	goWorkPath, err = GetGoWorkPath()
+	assert.NoError(t, err)
+	assert.True(t, filepath.IsAbs(goWorkPath))
+}
@@ -0,0 +1,220 @@
+package helm
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	chartFile  = "Chart.yaml"
+	valuesFile = "values.yaml"
+	chartsDir  = "charts"
+)
+
+// The fields of Chart.yaml, which are used for the build-info.
+type ChartMetadata struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	AppVersion string `yaml:"appVersion"`
+}
+
+func (metadata *ChartMetadata) Id() string {
+	return metadata.Name + ":" + metadata.Version
+}
+
+// A chart read from a directory or from a packaged .tgz file.
+type Chart struct {
+	ChartMetadata
+	// The docker images referenced in values.yaml.
+	Images []string
+	// The subcharts under the charts directory.
+	Subcharts []*Chart
+	// The path of the .tgz file, if the chart is packaged.
+	PackagePath string
+	// The directory of the chart, if it isn't packaged.
+	Dir string
+}
+
+// Reads the chart in the directory, including its subcharts.
+func ReadChartDir(chartDir string) (*Chart, error) {
+	chart := &Chart{Dir: chartDir}
+	if err := readChartFiles(chart, func(name string) ([]byte, error) {
+		return ioutil.ReadFile(filepath.Join(chartDir, name))
+	}); err != nil {
+		return nil, err
+	}
+	entries, err := ioutil.ReadDir(filepath.Join(chartDir, chartsDir))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errorutils.CheckError(err)
+	}
+	for _, entry := range entries {
+		subchartPath := filepath.Join(chartDir, chartsDir, entry.Name())
+		var subchart *Chart
+		switch {
+		case entry.IsDir():
+			subchart, err = ReadChartDir(subchartPath)
+		case strings.HasSuffix(entry.Name(), ".tgz"):
+			subchart, err = ReadChartPackage(subchartPath)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		chart.Subcharts = append(chart.Subcharts, subchart)
+	}
+	return chart, nil
+}
+
+// Reads the chart packaged in the .tgz file. The subcharts packaged inside it aren't read.
+func ReadChartPackage(packagePath string) (*Chart, error) {
+	files, err := readPackageFiles(packagePath)
+	if err != nil {
+		return nil, err
+	}
+	chart := &Chart{PackagePath: packagePath}
+	err = readChartFiles(chart, func(name string) ([]byte, error) {
+		content, exists := files[name]
+		if !exists {
+			return nil, os.ErrNotExist
+		}
+		return content, nil
+	})
+	return chart, err
+}
+
+// Returns Chart.yaml and values.yaml of the packaged chart, which are stored under the <chart name> directory.
+func readPackageFiles(packagePath string) (map[string][]byte, error) {
+	file, err := os.Open(packagePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("failed to read %s: %s", packagePath, err.Error()))
+	}
+	files := make(map[string][]byte)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, errorutils.CheckError(fmt.Errorf("failed to read %s: %s", packagePath, err.Error()))
+		}
+		parts := strings.Split(path.Clean(header.Name), "/")
+		if len(parts) != 2 || (parts[1] != chartFile && parts[1] != valuesFile) {
+			continue
+		}
+		if files[parts[1]], err = ioutil.ReadAll(tarReader); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+}
+
+func readChartFiles(chart *Chart, readFile func(name string) ([]byte, error)) error {
+	content, err := readFile(chartFile)
+	if err != nil {
+		return errorutils.CheckError(fmt.Errorf("failed to read %s: %s", chartFile, err.Error()))
+	}
+	if err = yaml.Unmarshal(content, &chart.ChartMetadata); err != nil {
+		return errorutils.CheckError(fmt.Errorf("failed to parse %s: %s", chartFile, err.Error()))
+	}
+	if chart.Name == "" || chart.Version == "" {
+		return errorutils.CheckError(errors.New(chartFile + " must include the name and the version of the chart"))
+	}
+	content, err = readFile(valuesFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errorutils.CheckError(err)
+	}
+	var values interface{}
+	if err = yaml.Unmarshal(content, &values); err != nil {
+		return errorutils.CheckError(fmt.Errorf("failed to parse %s of chart %s: %s", valuesFile, chart.Name, err.Error()))
+	}
+	chart.Images = FindImages(values, chart.AppVersion)
+	return nil
+}
+
+// Returns the docker images referenced in the chart values, sorted and without duplicates.
+// An image is either a string under an 'image' key, or a map under an 'image' key with the 'repository' key,
+// and optionally the 'registry', 'tag' and 'digest' keys. If the tag isn't set, the app version of the chart is used, as most charts do.
+func FindImages(values interface{}, appVersion string) []string {
+	images := make(map[string]bool)
+	collectImages(values, appVersion, images)
+	var sorted []string
+	for image := range images {
+		sorted = append(sorted, image)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func collectImages(value interface{}, appVersion string, images map[string]bool) {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		for key, child := range typed {
+			if key == "image" {
+				if image := toImage(child, appVersion); image != "" {
+					images[image] = true
+					continue
+				}
+			}
+			collectImages(child, appVersion, images)
+		}
+	case []interface{}:
+		for _, child := range typed {
+			collectImages(child, appVersion, images)
+		}
+	}
+}
+
+func toImage(value interface{}, appVersion string) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case map[interface{}]interface{}:
+		repository := toString(typed["repository"])
+		if repository == "" {
+			return ""
+		}
+		if registry := toString(typed["registry"]); registry != "" {
+			repository = registry + "/" + repository
+		}
+		if digest := toString(typed["digest"]); digest != "" {
+			return repository + "@" + digest
+		}
+		tag := toString(typed["tag"])
+		if tag == "" {
+			tag = appVersion
+		}
+		if tag == "" {
+			return repository
+		}
+		return repository + ":" + tag
+	}
+	return ""
+}
+
+func toString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,112 @@
+package helm
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadChartDir(t *testing.T) {
+	chart, err := ReadChartDir(filepath.Join("testdata", "mychart"))
+	assert.NoError(t, err)
+	assert.Equal(t, "mychart:1.2.0", chart.Id())
+	assert.Equal(t, []string{"acme/migrations@sha256:abc", "acme/web:3.4.5", "busybox:1.36", "docker.acme.io/acme/worker:2"}, chart.Images)
+	if assert.Len(t, chart.Subcharts, 1) {
+		assert.Equal(t, "redis:17.0.0", chart.Subcharts[0].Id())
+		assert.Equal(t, []string{"docker.io/bitnami/redis:7.0.4"}, chart.Subcharts[0].Images)
+	}
+}
+
+func TestReadChartPackage(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "helm")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	packagePath := filepath.Join(tempDir, "redis-17.0.0.tgz")
+	createChartPackage(t, packagePath, "redis", filepath.Join("testdata", "mychart", "charts", "redis"))
+
+	chart, err := ReadChartPackage(packagePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "redis:17.0.0", chart.Id())
+	assert.Equal(t, packagePath, chart.PackagePath)
+	assert.Equal(t, []string{"docker.io/bitnami/redis:7.0.4"}, chart.Images)
+}
+
+func TestGetChartDir(t *testing.T) {
+	tests := []struct {
+		args           []string
+		chartDir       string
+		isChartCommand bool
+	}{
+		{[]string{"package", "mychart", "--destination", "out"}, "mychart", true},
+		{[]string{"package", "--version", "1.0.0"}, ".", true},
+		{[]string{"dependency", "update", "--skip-refresh", "charts/web"}, "charts/web", true},
+		{[]string{"dep", "build"}, ".", true},
+		{[]string{"dependency", "list"}, "", false},
+		{[]string{"lint", "mychart"}, "", false},
+		{[]string{"--debug"}, "", false},
+	}
+	for _, test := range tests {
+		chartDir, isChartCommand := getChartDir(test.args)
+		assert.Equal(t, test.chartDir, chartDir, test.args)
+		assert.Equal(t, test.isChartCommand, isChartCommand, test.args)
+	}
+}
+
+func TestGetPackagePath(t *testing.T) {
+	chart := &Chart{ChartMetadata: ChartMetadata{Name: "mychart", Version: "1.2.0"}}
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"package", "mychart"}, "mychart-1.2.0.tgz"},
+		{[]string{"package", "mychart", "-d", "out"}, filepath.Join("out", "mychart-1.2.0.tgz")},
+		{[]string{"package", "mychart", "--destination=out", "--version", "1.3.0"}, filepath.Join("out", "mychart-1.3.0.tgz")},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, getPackagePath(test.args, chart))
+	}
+}
+
+func TestGetImageManifestDirs(t *testing.T) {
+	assert.Equal(t, []string{"acme/web/3.4.5"}, getImageManifestDirs("acme/web:3.4.5"))
+	assert.Equal(t, []string{"acme/worker/2"}, getImageManifestDirs("docker.acme.io/acme/worker:2"))
+	assert.Equal(t, []string{"acme/migrations/sha256:abc"}, getImageManifestDirs("acme/migrations@sha256:abc"))
+	assert.Equal(t, []string{"busybox/1.36", "library/busybox/1.36"}, getImageManifestDirs("busybox:1.36"))
+	assert.Equal(t, []string{"app/latest"}, getImageManifestDirs("localhost:5000/app"))
+}
+
+func TestCalcDirChecksum(t *testing.T) {
+	dir := filepath.Join("testdata", "mychart", "charts", "redis")
+	checksum, err := calcDirChecksum(dir)
+	assert.NoError(t, err)
+	assert.Len(t, checksum.Sha1, 40)
+	assert.Len(t, checksum.Md5, 32)
+	otherChecksum, err := calcDirChecksum(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, otherChecksum)
+	parentChecksum, err := calcDirChecksum(filepath.Join("testdata", "mychart"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, checksum.Sha1, parentChecksum.Sha1)
+}
+
+func createChartPackage(t *testing.T, packagePath, chartName, chartDir string) {
+	file, err := os.Create(packagePath)
+	assert.NoError(t, err)
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	defer gzipWriter.Close()
+	tarWriter := tar.NewWriter(gzipWriter)
+	defer tarWriter.Close()
+	for _, name := range []string{chartFile, valuesFile} {
+		content, err := ioutil.ReadFile(filepath.Join(chartDir, name))
+		assert.NoError(t, err)
+		assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: chartName + "/" + name, Mode: 0644, Size: int64(len(content))}))
+		_, err = tarWriter.Write(content)
+		assert.NoError(t, err)
+	}
+}
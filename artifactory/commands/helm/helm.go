@@ -0,0 +1,457 @@
+package helm
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ModuleType buildinfo.ModuleType = "helm"
+	// The name of the chart repository, through which Helm resolves the chart dependencies from Artifactory.
+	// Charts should refer to it as "@artifactory" or by its URL in the repository field of their dependencies.
+	helmRepoName = "artifactory"
+)
+
+// Runs Helm with the chart dependencies resolved from Artifactory.
+// 'helm push <chart.tgz>' is handled by the CLI, which deploys the chart to the deployment repository.
+// If the build name and number are passed, the charts and the images they reference are recorded in the build-info.
+type HelmCommand struct {
+	resolverDetails *config.ServerDetails
+	resolverRepo    string
+	deployerDetails *config.ServerDetails
+	deployerRepo    string
+	args            []string
+}
+
+func NewHelmCommand() *HelmCommand {
+	return &HelmCommand{}
+}
+
+func (hc *HelmCommand) SetResolver(serverDetails *config.ServerDetails, repo string) *HelmCommand {
+	hc.resolverDetails, hc.resolverRepo = serverDetails, repo
+	return hc
+}
+
+func (hc *HelmCommand) SetDeployer(serverDetails *config.ServerDetails, repo string) *HelmCommand {
+	hc.deployerDetails, hc.deployerRepo = serverDetails, repo
+	return hc
+}
+
+func (hc *HelmCommand) SetArgs(args []string) *HelmCommand {
+	hc.args = args
+	return hc
+}
+
+func (hc *HelmCommand) ServerDetails() (*config.ServerDetails, error) {
+	if hc.resolverDetails != nil {
+		return hc.resolverDetails, nil
+	}
+	return hc.deployerDetails, nil
+}
+
+func (hc *HelmCommand) CommandName() string {
+	return "rt_helm"
+}
+
+func (hc *HelmCommand) Run() error {
+	args, buildConfiguration, err := utils.ExtractBuildDetailsFromArgs(hc.args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errorutils.CheckError(errors.New("a helm command is required"))
+	}
+	collectBuildInfo := buildConfiguration.BuildName != "" && buildConfiguration.BuildNumber != ""
+	if args[0] == "push" && len(args) == 2 {
+		return hc.push(args[1], buildConfiguration, collectBuildInfo)
+	}
+	if err = hc.runHelm(args); err != nil {
+		return err
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	chartDir, isChartCommand := getChartDir(args)
+	if !isChartCommand {
+		log.Debug("No build-info is collected for 'helm " + args[0] + "'.")
+		return nil
+	}
+	chart, err := ReadChartDir(chartDir)
+	if err != nil {
+		return err
+	}
+	if err = hc.saveChartDependencies(chart, buildConfiguration); err != nil {
+		return err
+	}
+	if getPositionalArgs(args)[0] != "package" {
+		return nil
+	}
+	return savePackageArtifact(chart, getPackagePath(args, chart), buildConfiguration)
+}
+
+// Returns the chart directory of 'helm package' and 'helm dependency update/build', which default to the working directory.
+func getChartDir(args []string) (chartDir string, isChartCommand bool) {
+	positional := getPositionalArgs(args)
+	if len(positional) == 0 {
+		return "", false
+	}
+	switch {
+	case positional[0] == "package":
+		positional = positional[1:]
+	case (positional[0] == "dependency" || positional[0] == "dep") && len(positional) > 1 &&
+		(positional[1] == "update" || positional[1] == "up" || positional[1] == "build"):
+		positional = positional[2:]
+	default:
+		return "", false
+	}
+	if len(positional) == 0 {
+		return ".", true
+	}
+	return positional[0], true
+}
+
+// Returns the path of the .tgz file created by 'helm package', in the destination directory, which defaults to the working directory.
+func getPackagePath(args []string, chart *Chart) string {
+	version := chart.Version
+	if value := getOptionValue(args, "--version"); value != "" {
+		version = value
+	}
+	destination := getOptionValue(args, "--destination", "-d")
+	if destination == "" {
+		destination = "."
+	}
+	return filepath.Join(destination, chart.Name+"-"+version+".tgz")
+}
+
+// Returns the value of the option, which is passed either as '--option=value' or as '--option value'.
+func getOptionValue(args []string, names ...string) string {
+	for i, arg := range args {
+		for _, name := range names {
+			if strings.HasPrefix(arg, name+"=") {
+				return strings.TrimPrefix(arg, name+"=")
+			}
+			if arg == name && i+1 < len(args) {
+				return args[i+1]
+			}
+		}
+	}
+	return ""
+}
+
+// Returns the arguments, which aren't options or option values. Only the boolean options of the chart commands are
+// expected to appear without a value, so an option without '=' is assumed to be followed by its value, unless it's known to be boolean.
+func getPositionalArgs(args []string) []string {
+	booleanOptions := map[string]bool{"--debug": true, "--dependency-update": true, "-u": true, "--sign": true, "--skip-refresh": true, "--verify": true}
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch {
+		case !strings.HasPrefix(args[i], "-"):
+			positional = append(positional, args[i])
+		case !strings.Contains(args[i], "=") && !booleanOptions[args[i]]:
+			i++
+		}
+	}
+	return positional
+}
+
+func (hc *HelmCommand) runHelm(args []string) error {
+	helmPath, err := exec.LookPath("helm")
+	if err != nil {
+		return errorutils.CheckError(errors.New("could not find the 'helm' executable in the system PATH"))
+	}
+	cmd := exec.Command(helmPath, args...)
+	cmd.Env = os.Environ()
+	if hc.resolverDetails != nil {
+		repoConfigDir, err := fileutils.CreateTempDir()
+		if err != nil {
+			return err
+		}
+		defer fileutils.RemoveTempDir(repoConfigDir)
+		repoConfigPath := filepath.Join(repoConfigDir, "repositories.yaml")
+		if err = hc.writeRepositoryConfig(helmPath, repoConfigPath); err != nil {
+			return err
+		}
+		cmd.Env = append(cmd.Env, "HELM_REPOSITORY_CONFIG="+repoConfigPath)
+	}
+	log.Info("Running helm " + strings.Join(args, " "))
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
+
+// Writes a Helm repositories file with the repositories of the user and the resolution repository, including
+// the credentials, so that they're never written to the Helm configuration of the user.
+func (hc *HelmCommand) writeRepositoryConfig(helmPath, repoConfigPath string) error {
+	username, password, err := projectutils.GetBasicAuthCredentials(hc.resolverDetails)
+	if err != nil {
+		return err
+	}
+	repoConfig, err := readUserRepositoryConfig(helmPath)
+	if err != nil {
+		return err
+	}
+	repositories := []interface{}{map[string]string{
+		"name":     helmRepoName,
+		"url":      hc.resolverDetails.GetArtifactoryUrl() + "api/helm/" + hc.resolverRepo,
+		"username": username,
+		"password": password,
+	}}
+	if userRepositories, ok := repoConfig["repositories"].([]interface{}); ok {
+		for _, repository := range userRepositories {
+			if entry, ok := repository.(map[interface{}]interface{}); ok && entry["name"] == helmRepoName {
+				log.Debug("The", helmRepoName, "repository of the Helm configuration is replaced by the resolution repository.")
+				continue
+			}
+			repositories = append(repositories, repository)
+		}
+	}
+	repoConfig["repositories"] = repositories
+	content, err := yaml.Marshal(repoConfig)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	return errorutils.CheckError(ioutil.WriteFile(repoConfigPath, content, 0600))
+}
+
+// Returns the content of the Helm repositories file of the user, or an empty configuration if the user has none.
+func readUserRepositoryConfig(helmPath string) (map[string]interface{}, error) {
+	repoConfigPath := os.Getenv("HELM_REPOSITORY_CONFIG")
+	if repoConfigPath == "" {
+		output, err := exec.Command(helmPath, "env", "HELM_REPOSITORY_CONFIG").Output()
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		repoConfigPath = strings.TrimSpace(string(output))
+	}
+	repoConfig := map[string]interface{}{"apiVersion": ""}
+	exists, err := fileutils.IsFileExists(repoConfigPath, false)
+	if err != nil || !exists {
+		return repoConfig, err
+	}
+	content, err := ioutil.ReadFile(repoConfigPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if err = yaml.Unmarshal(content, &repoConfig); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return repoConfig, nil
+}
+
+func (hc *HelmCommand) push(packagePath string, buildConfiguration *utils.BuildConfiguration, collectBuildInfo bool) error {
+	if hc.deployerDetails == nil {
+		return errorutils.CheckError(errors.New("no deployment repository is configured. Run 'jfrog rt helm-config' with the deployer options"))
+	}
+	chart, err := ReadChartPackage(packagePath)
+	if err != nil {
+		return err
+	}
+	log.Info("Pushing", chart.Id(), "to", hc.deployerRepo+"...")
+	artifacts, err := projectutils.DeployFiles(hc.deployerDetails, []projectutils.DeployableFile{{
+		LocalPath:  packagePath,
+		TargetPath: hc.deployerRepo + "/" + filepath.Base(packagePath),
+	}}, buildConfiguration)
+	if err != nil {
+		return err
+	}
+	if collectBuildInfo {
+		if err = projectutils.SaveArtifacts(buildConfiguration, ModuleType, getModuleId(chart, buildConfiguration), artifacts); err != nil {
+			return err
+		}
+	}
+	log.Info("helm push finished successfully.")
+	return nil
+}
+
+// Records the chart and each of its subcharts as build-info modules. The dependencies of the chart module are its
+// subcharts and the images it references, while the dependencies of each subchart module are the images the subchart
+// references. Subcharts, which reference no images, have no modules. The checksums of a subchart directory are
+// calculated over its files, and the checksums of the images are the checksums of their manifests in Artifactory.
+func (hc *HelmCommand) saveChartDependencies(chart *Chart, buildConfiguration *utils.BuildConfiguration) error {
+	imageChecksums, err := hc.findImageChecksums(chart)
+	if err != nil {
+		return err
+	}
+	dependencies := imageDependencies(chart.Images, imageChecksums)
+	for _, subchart := range chart.Subcharts {
+		var checksum *buildinfo.Checksum
+		if subchart.PackagePath != "" {
+			details, err := fileutils.GetFileDetails(subchart.PackagePath)
+			if err != nil {
+				return err
+			}
+			checksum = &buildinfo.Checksum{Sha1: details.Checksum.Sha1, Md5: details.Checksum.Md5}
+		} else if checksum, err = calcDirChecksum(subchart.Dir); err != nil {
+			return err
+		}
+		dependencies = append(dependencies, buildinfo.Dependency{Id: subchart.Id(), Type: "tgz", Checksum: checksum})
+	}
+	moduleId := getModuleId(chart, buildConfiguration)
+	log.Info("Adding", strconv.Itoa(len(dependencies)), "dependencies to module", moduleId, "of the build-info.")
+	if err := projectutils.SaveDependencies(buildConfiguration, ModuleType, moduleId, dependencies); err != nil {
+		return err
+	}
+	for _, subchart := range chart.Subcharts {
+		if len(subchart.Images) == 0 {
+			continue
+		}
+		if err := projectutils.SaveDependencies(buildConfiguration, ModuleType, subchart.Id(), imageDependencies(subchart.Images, imageChecksums)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Records the .tgz file created by 'helm package' as an artifact of the chart module.
+func savePackageArtifact(chart *Chart, packagePath string, buildConfiguration *utils.BuildConfiguration) error {
+	details, err := fileutils.GetFileDetails(packagePath)
+	if err != nil {
+		return err
+	}
+	artifact := buildinfo.Artifact{
+		Name:     filepath.Base(packagePath),
+		Type:     "tgz",
+		Checksum: &buildinfo.Checksum{Sha1: details.Checksum.Sha1, Md5: details.Checksum.Md5},
+	}
+	return projectutils.SaveArtifacts(buildConfiguration, ModuleType, getModuleId(chart, buildConfiguration), []buildinfo.Artifact{artifact})
+}
+
+// Returns the checksums of the manifests of the images referenced by the chart and its subcharts, by image.
+// Images, whose manifests aren't found in Artifactory, have no checksums.
+func (hc *HelmCommand) findImageChecksums(chart *Chart) (map[string]*buildinfo.Checksum, error) {
+	images := append([]string{}, chart.Images...)
+	for _, subchart := range chart.Subcharts {
+		images = append(images, subchart.Images...)
+	}
+	serverDetails, _ := hc.ServerDetails()
+	if len(images) == 0 || serverDetails == nil {
+		return nil, nil
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, false)
+	if err != nil {
+		return nil, err
+	}
+	var conditions []string
+	imagesByPath := make(map[string]string)
+	for _, image := range images {
+		for _, manifestDir := range getImageManifestDirs(image) {
+			imagesByPath[manifestDir] = image
+			for _, manifestName := range imageManifestNames {
+				condition, err := json.Marshal(map[string]string{"path": manifestDir, "name": manifestName})
+				if err != nil {
+					return nil, errorutils.CheckError(err)
+				}
+				conditions = append(conditions, string(condition))
+			}
+		}
+	}
+	items, err := projectutils.FindItems(servicesManager, "", conditions)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Repo+"/"+items[i].Path+"/"+items[i].Name < items[j].Repo+"/"+items[j].Path+"/"+items[j].Name
+	})
+	checksums := make(map[string]*buildinfo.Checksum)
+	for _, item := range items {
+		if image, ok := imagesByPath[item.Path]; ok && checksums[image] == nil {
+			checksums[image] = item.Checksum()
+		}
+	}
+	for _, image := range images {
+		if checksums[image] == nil {
+			log.Warn("The manifest of image", image, "wasn't found in Artifactory, so the image is recorded without checksums.")
+		}
+	}
+	return checksums, nil
+}
+
+// The manifest files of an image tag in a Docker repository, for single-platform and multi-platform images.
+var imageManifestNames = []string{"manifest.json", "list.manifest.json"}
+
+// Returns the directories the manifest of the image may be stored in, in a Docker repository of Artifactory.
+// The registry host is dropped, and an official Docker Hub image may be stored under the 'library' directory.
+func getImageManifestDirs(image string) []string {
+	name, reference := image, "latest"
+	if index := strings.Index(image, "@"); index >= 0 {
+		name, reference = image[:index], image[index+1:]
+	} else if index := strings.LastIndex(image, ":"); index > strings.LastIndex(image, "/") {
+		name, reference = image[:index], image[index+1:]
+	}
+	parts := strings.Split(name, "/")
+	dockerHub := true
+	if len(parts) > 1 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		dockerHub = parts[0] == "docker.io"
+		parts = parts[1:]
+	}
+	dirs := []string{path.Join(append(parts, reference)...)}
+	if dockerHub && len(parts) == 1 {
+		dirs = append(dirs, path.Join("library", parts[0], reference))
+	}
+	return dirs
+}
+
+func imageDependencies(images []string, checksums map[string]*buildinfo.Checksum) []buildinfo.Dependency {
+	var dependencies []buildinfo.Dependency
+	for _, image := range images {
+		dependencies = append(dependencies, buildinfo.Dependency{Id: image, Type: string(buildinfo.Docker), Checksum: checksums[image]})
+	}
+	return dependencies
+}
+
+// Calculates the checksums of a directory over the relative paths and the contents of its files, in the order of their paths.
+func calcDirChecksum(dir string) (*buildinfo.Checksum, error) {
+	var paths []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			paths = append(paths, path)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	sort.Strings(paths)
+	sha1Hash, md5Hash := sha1.New(), md5.New()
+	writer := io.MultiWriter(sha1Hash, md5Hash)
+	for _, path := range paths {
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		_, _ = writer.Write([]byte(filepath.ToSlash(relativePath) + "\x00"))
+		_, _ = writer.Write(content)
+	}
+	return &buildinfo.Checksum{Sha1: hex.EncodeToString(sha1Hash.Sum(nil)), Md5: hex.EncodeToString(md5Hash.Sum(nil))}, nil
+}
+
+func getModuleId(chart *Chart, buildConfiguration *utils.BuildConfiguration) string {
+	if buildConfiguration.Module != "" {
+		return buildConfiguration.Module
+	}
+	return chart.Id()
+}
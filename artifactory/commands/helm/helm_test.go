@@ -0,0 +1,53 @@
+package helm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestWriteRepositoryConfig(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "helm")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	userConfigPath := filepath.Join(tempDir, "user.yaml")
+	userConfig := `apiVersion: ""
+generated: "2021-06-01T00:00:00Z"
+repositories:
+- name: bitnami
+  url: https://charts.bitnami.com/bitnami
+- name: artifactory
+  url: https://old.acme.io/artifactory/api/helm/charts
+`
+	assert.NoError(t, ioutil.WriteFile(userConfigPath, []byte(userConfig), 0600))
+	previous, wasSet := os.LookupEnv("HELM_REPOSITORY_CONFIG")
+	assert.NoError(t, os.Setenv("HELM_REPOSITORY_CONFIG", userConfigPath))
+	defer func() {
+		if wasSet {
+			os.Setenv("HELM_REPOSITORY_CONFIG", previous)
+		} else {
+			os.Unsetenv("HELM_REPOSITORY_CONFIG")
+		}
+	}()
+
+	hc := NewHelmCommand().SetResolver(&config.ServerDetails{ArtifactoryUrl: "https://acme.io/artifactory/", User: "user", Password: "pass"}, "helm-remote")
+	repoConfigPath := filepath.Join(tempDir, "repositories.yaml")
+	assert.NoError(t, hc.writeRepositoryConfig("helm", repoConfigPath))
+	content, err := ioutil.ReadFile(repoConfigPath)
+	assert.NoError(t, err)
+	var repoConfig struct {
+		Generated    string              `yaml:"generated"`
+		Repositories []map[string]string `yaml:"repositories"`
+	}
+	assert.NoError(t, yaml.Unmarshal(content, &repoConfig))
+	assert.Equal(t, "2021-06-01T00:00:00Z", repoConfig.Generated)
+	assert.Equal(t, []map[string]string{
+		{"name": "artifactory", "url": "https://acme.io/artifactory/api/helm/helm-remote", "username": "user", "password": "pass"},
+		{"name": "bitnami", "url": "https://charts.bitnami.com/bitnami"},
+	}, repoConfig.Repositories)
+}
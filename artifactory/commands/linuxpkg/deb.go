@@ -0,0 +1,212 @@
+package linuxpkg
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/mholt/archiver"
+)
+
+const (
+	arMagic         = "!<arch>\n"
+	arHeaderSize    = 60
+	debControlFile  = "control"
+	debControlTarGz = "control.tar"
+)
+
+// The package names of Debian may contain lower case letters, digits and the + - . characters.
+var debNameRegExp = regexp.MustCompile(`^[a-z0-9][a-z0-9+.-]+$`)
+
+// The fields of the control file of a Debian package, which the package is indexed by.
+type DebPackage struct {
+	Name         string
+	Version      string
+	Architecture string
+	// The name of the source package, which defaults to the name of the package.
+	Source string
+}
+
+// Reads the control file of the .deb file. The control archive may be uncompressed, or compressed with gzip, xz or zstd.
+// Decompressing zstd requires the zstd executable.
+func ReadDeb(debPath string) (*DebPackage, error) {
+	file, err := os.Open(debPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	magic := make([]byte, len(arMagic))
+	if _, err = io.ReadFull(reader, magic); err != nil || string(magic) != arMagic {
+		return nil, errorutils.CheckError(errors.New(debPath + " is not a Debian package"))
+	}
+	for {
+		header := make([]byte, arHeaderSize)
+		if _, err = io.ReadFull(reader, header); err != nil {
+			if err == io.EOF {
+				return nil, errorutils.CheckError(errors.New("no control archive was found in " + debPath))
+			}
+			return nil, errorutils.CheckError(err)
+		}
+		// GNU ar terminates the member names with a slash.
+		name := strings.TrimSuffix(strings.TrimSpace(string(header[0:16])), "/")
+		size, err := strconv.ParseInt(strings.TrimSpace(string(header[48:58])), 10, 64)
+		if err != nil {
+			return nil, errorutils.CheckError(errors.New("invalid archive member header in " + debPath))
+		}
+		member := io.LimitReader(reader, size)
+		if strings.HasPrefix(name, debControlTarGz) {
+			control, err := readControlFile(name, member)
+			if err != nil {
+				return nil, err
+			}
+			return parseControlFile(control)
+		}
+		// The members are aligned to an even offset.
+		if _, err = io.Copy(ioutil.Discard, io.LimitReader(reader, size+size%2)); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+	}
+}
+
+// Returns the content of the control file in the control archive.
+func readControlFile(archiveName string, archive io.Reader) ([]byte, error) {
+	var tarReader io.Reader
+	switch path.Ext(archiveName) {
+	case ".tar":
+		tarReader = archive
+	case ".gz":
+		gzipReader, err := gzip.NewReader(archive)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		defer gzipReader.Close()
+		tarReader = gzipReader
+	case ".xz":
+		return extractControlFile(archiveName, archive)
+	case ".zst":
+		zstdPath, err := exec.LookPath("zstd")
+		if err != nil {
+			return nil, errorutils.CheckError(errors.New("the control archive of the package is compressed with zstd. Reading it requires the 'zstd' executable in the system PATH"))
+		}
+		cmd := exec.Command(zstdPath, "-dc")
+		cmd.Stdin = archive
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		decompressed, err := cmd.Output()
+		if err != nil {
+			return nil, errorutils.CheckError(errors.New("failed to decompress " + archiveName + ": " + strings.TrimSpace(stderr.String())))
+		}
+		tarReader = bytes.NewReader(decompressed)
+	default:
+		return nil, errorutils.CheckError(errors.New("unsupported control archive " + archiveName))
+	}
+	reader := tar.NewReader(tarReader)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil, errorutils.CheckError(errors.New("no control file was found in " + archiveName))
+		}
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if path.Clean(header.Name) == debControlFile {
+			content, err := ioutil.ReadAll(reader)
+			return content, errorutils.CheckError(err)
+		}
+	}
+}
+
+// Extracts the xz compressed control archive to a temp dir, since xz can't be read as a stream by the available libraries.
+func extractControlFile(archiveName string, archive io.Reader) ([]byte, error) {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer fileutils.RemoveTempDir(tempDir)
+	if err = archiver.TarXZ.Read(archive, tempDir); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to read " + archiveName + ": " + err.Error()))
+	}
+	content, err := ioutil.ReadFile(filepath.Join(tempDir, debControlFile))
+	if os.IsNotExist(err) {
+		return nil, errorutils.CheckError(errors.New("no control file was found in " + archiveName))
+	}
+	return content, errorutils.CheckError(err)
+}
+
+// Parses the fields of the control file. The continuation lines of multi-line fields start with a space or a tab.
+func parseControlFile(content []byte) (*DebPackage, error) {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(string(content), "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		if i := strings.Index(line, ":"); i > 0 {
+			fields[strings.ToLower(line[:i])] = strings.TrimSpace(line[i+1:])
+		}
+	}
+	pkg := &DebPackage{Name: fields["package"], Version: fields["version"], Architecture: fields["architecture"]}
+	// The source field may include the version of the source package, as in "<name> (<version>)".
+	if source := strings.Fields(fields["source"]); len(source) > 0 {
+		pkg.Source = source[0]
+	} else {
+		pkg.Source = pkg.Name
+	}
+	return pkg, nil
+}
+
+func (pkg *DebPackage) Validate() []string {
+	var problems []string
+	if !debNameRegExp.MatchString(pkg.Name) {
+		problems = append(problems, "the Package field of the control file '"+pkg.Name+"' is not a valid package name")
+	}
+	if pkg.Version == "" {
+		problems = append(problems, "the control file has no Version field")
+	}
+	if pkg.Architecture == "" {
+		problems = append(problems, "the control file has no Architecture field")
+	}
+	return problems
+}
+
+// Returns the canonical file name of the package, <name>_<version>_<architecture>.deb, in which the epoch is omitted from the version.
+func (pkg *DebPackage) FileName() string {
+	version := pkg.Version
+	if i := strings.Index(version, ":"); i >= 0 {
+		version = version[i+1:]
+	}
+	return pkg.Name + "_" + version + "_" + pkg.Architecture + ".deb"
+}
+
+// Returns the directory of the package in the pool of the component: pool/<component>/<prefix>/<source>/, where the prefix
+// is the first letter of the source package, or its first four letters for library packages, as in the Debian archive.
+func (pkg *DebPackage) PoolDir(component string) string {
+	prefix := pkg.Source[:1]
+	if strings.HasPrefix(pkg.Source, "lib") && len(pkg.Source) > 3 {
+		prefix = pkg.Source[:4]
+	}
+	return path.Join("pool", component, prefix, pkg.Source) + "/"
+}
+
+func (pkg *DebPackage) Id() string {
+	return pkg.Name + ":" + pkg.Version
+}
+
+// Returns the properties, by which Artifactory indexes the package in the distribution and component.
+func (pkg *DebPackage) Props(distribution, component string) string {
+	return "deb.distribution=" + distribution + ";deb.component=" + component + ";deb.architecture=" + pkg.Architecture +
+		";deb.name=" + pkg.Name + ";deb.version=" + pkg.Version
+}
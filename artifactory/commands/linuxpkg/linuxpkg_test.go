@@ -0,0 +1,107 @@
+package linuxpkg
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadDeb(t *testing.T) {
+	for _, debFile := range []string{"libhello_2.10-2_amd64.deb", "libhello_2.10-2_amd64-xz.deb"} {
+		pkg, err := ReadDeb(filepath.Join("testdata", debFile))
+		if !assert.NoError(t, err, debFile) {
+			continue
+		}
+		// The continuation lines of the description aren't fields.
+		assert.Equal(t, &DebPackage{Name: "libhello", Version: "1:2.10-2", Architecture: "amd64", Source: "hello-src"}, pkg, debFile)
+		assert.Empty(t, pkg.Validate())
+		assert.Equal(t, "libhello_2.10-2_amd64.deb", pkg.FileName())
+		assert.Equal(t, "pool/main/h/hello-src/", pkg.PoolDir("main"))
+		assert.Equal(t, "deb.distribution=focal;deb.component=main;deb.architecture=amd64;deb.name=libhello;deb.version=1:2.10-2", pkg.Props("focal", "main"))
+	}
+
+	pkg := &DebPackage{Name: "libssl3", Source: "libssl3"}
+	assert.Equal(t, "pool/universe/libs/libssl3/", pkg.PoolDir("universe"))
+	assert.Len(t, pkg.Validate(), 2)
+
+	_, err := ReadDeb(filepath.Join("testdata", "hello-2.10-3.el9.x86_64.rpm"))
+	assert.Error(t, err)
+}
+
+func TestReadRpm(t *testing.T) {
+	pkg, err := ReadRpm(filepath.Join("testdata", "hello-2.10-3.el9.x86_64.rpm"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, &RpmPackage{Name: "hello", Version: "2.10", Release: "3.el9", Epoch: "2", Architecture: "x86_64"}, pkg)
+		assert.Empty(t, pkg.Validate())
+		assert.Equal(t, "hello-2.10-3.el9.x86_64.rpm", pkg.FileName())
+		assert.Equal(t, "hello:2:2.10-3.el9", pkg.Id())
+		assert.Equal(t, "rpm.metadata.name=hello;rpm.metadata.version=2.10;rpm.metadata.release=3.el9;rpm.metadata.epoch=2;rpm.metadata.arch=x86_64", pkg.Props())
+	}
+
+	pkg, err = ReadRpm(filepath.Join("testdata", "hello-2.10-3.el9.src.rpm"))
+	if assert.NoError(t, err) {
+		assert.True(t, pkg.SourcePackage)
+		assert.Equal(t, "hello-2.10-3.el9.src.rpm", pkg.FileName())
+	}
+
+	_, err = ReadRpm(filepath.Join("testdata", "libhello_2.10-2_amd64.deb"))
+	assert.Error(t, err)
+}
+
+func TestValidateDebUpload(t *testing.T) {
+	debianRepo := &RepositoryDetails{Key: "debian-local", PackageType: "debian"}
+	tests := []struct {
+		name         string
+		target       string
+		architecture string
+		repoDetails  *RepositoryDetails
+		expectedPath string
+		problems     int
+	}{
+		{"pool", "debian-local", "", debianRepo, "debian-local/pool/main/h/hello-src/libhello_2.10-2_amd64.deb", 0},
+		{"dir", "debian-local/custom/", "", debianRepo, "debian-local/custom/libhello_2.10-2_amd64.deb", 0},
+		{"canonicalName", "debian-local/custom/libhello_2.10-2_amd64.deb", "amd64", debianRepo, "debian-local/custom/libhello_2.10-2_amd64.deb", 0},
+		{"wrongName", "debian-local/custom/hello.deb", "", debianRepo, "debian-local/custom/hello.deb", 1},
+		{"wrongArchitecture", "debian-local", "arm64", debianRepo, "debian-local/pool/main/h/hello-src/libhello_2.10-2_amd64.deb", 1},
+		{"wrongRepo", "generic-local/hello.deb", "", &RepositoryDetails{Key: "generic-local", PackageType: "generic"}, "generic-local/hello.deb", 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			uploadCmd := NewDebUploadCommand().SetPackagePath(filepath.Join("testdata", "libhello_2.10-2_amd64.deb")).
+				SetTarget(test.target).SetDistribution("focal").SetArchitecture(test.architecture)
+			upload, err := uploadCmd.readPackage()
+			assert.NoError(t, err)
+			targetPath, problems := uploadCmd.validate(upload, test.repoDetails)
+			assert.Equal(t, test.expectedPath, targetPath)
+			assert.Len(t, problems, test.problems, problems)
+		})
+	}
+}
+
+func TestValidateRpmUpload(t *testing.T) {
+	rpmRepo := &RepositoryDetails{Key: "rpm-local", PackageType: "rpm", YumRootDepth: 2}
+	tests := []struct {
+		name         string
+		target       string
+		expectedPath string
+		problems     int
+	}{
+		{"depth", "rpm-local/el9/x86_64/", "rpm-local/el9/x86_64/hello-2.10-3.el9.x86_64.rpm", 0},
+		{"aboveDepth", "rpm-local/el9/", "rpm-local/el9/hello-2.10-3.el9.x86_64.rpm", 1},
+		{"noPath", "rpm-local", "rpm-local/hello-2.10-3.el9.x86_64.rpm", 1},
+		{"wrongName", "rpm-local/el9/x86_64/hello.rpm", "rpm-local/el9/x86_64/hello.rpm", 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			uploadCmd := NewRpmUploadCommand().SetPackagePath(filepath.Join("testdata", "hello-2.10-3.el9.x86_64.rpm")).
+				SetTarget(test.target).SetBuildConfiguration(&utils.BuildConfiguration{})
+			upload, err := uploadCmd.readPackage()
+			assert.NoError(t, err)
+			targetPath, problems := uploadCmd.validate(upload, rpmRepo)
+			assert.Equal(t, test.expectedPath, targetPath)
+			assert.Len(t, problems, test.problems, problems)
+		})
+	}
+}
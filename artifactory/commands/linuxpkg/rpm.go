@@ -0,0 +1,194 @@
+package linuxpkg
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	rpmLeadSize = 96
+	// The type of source packages in the lead.
+	rpmLeadSourceType = 1
+
+	rpmTagName      = 1000
+	rpmTagVersion   = 1001
+	rpmTagRelease   = 1002
+	rpmTagEpoch     = 1003
+	rpmTagArch      = 1022
+	rpmTagSourceRpm = 1044
+
+	rpmTypeInt32       = 4
+	rpmTypeString      = 6
+	rpmTypeStringArray = 8
+	rpmTypeI18nString  = 9
+)
+
+var (
+	rpmLeadMagic   = []byte{0xed, 0xab, 0xee, 0xdb}
+	rpmHeaderMagic = []byte{0x8e, 0xad, 0xe8, 0x01}
+)
+
+// The fields of the header of an RPM package, which the package is indexed by.
+type RpmPackage struct {
+	Name         string
+	Version      string
+	Release      string
+	Epoch        string
+	Architecture string
+	// True for source packages, which are indexed with the 'src' architecture.
+	SourcePackage bool
+}
+
+// Reads the header of the .rpm file. The header follows the lead and the signature header of the package.
+func ReadRpm(rpmPath string) (*RpmPackage, error) {
+	file, err := os.Open(rpmPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	lead := make([]byte, rpmLeadSize)
+	if _, err = io.ReadFull(reader, lead); err != nil || !bytes.Equal(lead[:4], rpmLeadMagic) {
+		return nil, errorutils.CheckError(errors.New(rpmPath + " is not an RPM package"))
+	}
+	// The signature header is padded to a multiple of 8 bytes.
+	if _, err = readRpmHeader(reader, true); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to read the signature header of " + rpmPath + ": " + err.Error()))
+	}
+	tags, err := readRpmHeader(reader, false)
+	if err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to read the header of " + rpmPath + ": " + err.Error()))
+	}
+	pkg := &RpmPackage{
+		Name:         tags[rpmTagName],
+		Version:      tags[rpmTagVersion],
+		Release:      tags[rpmTagRelease],
+		Epoch:        tags[rpmTagEpoch],
+		Architecture: tags[rpmTagArch],
+	}
+	// Binary packages reference the source package they were built from.
+	if _, ok := tags[rpmTagSourceRpm]; !ok || binary.BigEndian.Uint16(lead[6:8]) == rpmLeadSourceType {
+		pkg.SourcePackage = true
+	}
+	return pkg, nil
+}
+
+// Reads a header structure and returns the values of the tags, which the package is indexed by.
+func readRpmHeader(reader io.Reader, padded bool) (map[int]string, error) {
+	intro := make([]byte, 16)
+	if _, err := io.ReadFull(reader, intro); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(intro[:4], rpmHeaderMagic) {
+		return nil, errors.New("bad header magic")
+	}
+	entriesCount := binary.BigEndian.Uint32(intro[8:12])
+	storeSize := binary.BigEndian.Uint32(intro[12:16])
+	// Protect against allocating huge buffers for corrupted headers.
+	if entriesCount > 1<<16 || storeSize > 1<<28 {
+		return nil, errors.New("corrupted header")
+	}
+	index := make([]byte, 16*entriesCount)
+	if _, err := io.ReadFull(reader, index); err != nil {
+		return nil, err
+	}
+	store := make([]byte, storeSize)
+	if _, err := io.ReadFull(reader, store); err != nil {
+		return nil, err
+	}
+	if padded && storeSize%8 != 0 {
+		if _, err := io.CopyN(ioutil.Discard, reader, int64(8-storeSize%8)); err != nil {
+			return nil, err
+		}
+	}
+	tags := make(map[int]string)
+	for i := uint32(0); i < entriesCount; i++ {
+		entry := index[16*i : 16*(i+1)]
+		tag := int(binary.BigEndian.Uint32(entry[0:4]))
+		dataType := binary.BigEndian.Uint32(entry[4:8])
+		offset := binary.BigEndian.Uint32(entry[8:12])
+		if offset >= storeSize {
+			return nil, errors.New("corrupted header entry " + strconv.Itoa(tag))
+		}
+		switch tag {
+		case rpmTagName, rpmTagVersion, rpmTagRelease, rpmTagEpoch, rpmTagArch, rpmTagSourceRpm:
+		default:
+			continue
+		}
+		switch dataType {
+		case rpmTypeString, rpmTypeStringArray, rpmTypeI18nString:
+			value := store[offset:]
+			if end := bytes.IndexByte(value, 0); end >= 0 {
+				value = value[:end]
+			}
+			tags[tag] = string(value)
+		case rpmTypeInt32:
+			if offset+4 > storeSize {
+				return nil, errors.New("corrupted header entry " + strconv.Itoa(tag))
+			}
+			tags[tag] = strconv.FormatUint(uint64(binary.BigEndian.Uint32(store[offset:offset+4])), 10)
+		}
+	}
+	return tags, nil
+}
+
+func (pkg *RpmPackage) Validate() []string {
+	var problems []string
+	if pkg.Name == "" {
+		problems = append(problems, "the header of the package has no name")
+	}
+	if pkg.Version == "" {
+		problems = append(problems, "the header of the package has no version")
+	}
+	if pkg.Release == "" {
+		problems = append(problems, "the header of the package has no release")
+	}
+	if pkg.Arch() == "" {
+		problems = append(problems, "the header of the package has no architecture")
+	}
+	return problems
+}
+
+// Returns the architecture, which the package is indexed by. Source packages are indexed with the 'src' architecture.
+func (pkg *RpmPackage) Arch() string {
+	if pkg.SourcePackage {
+		return "src"
+	}
+	return pkg.Architecture
+}
+
+// Returns the canonical file name of the package, <name>-<version>-<release>.<arch>.rpm.
+func (pkg *RpmPackage) FileName() string {
+	return pkg.Name + "-" + pkg.Version + "-" + pkg.Release + "." + pkg.Arch() + ".rpm"
+}
+
+// Returns the full version of the package, [<epoch>:]<version>-<release>.
+func (pkg *RpmPackage) FullVersion() string {
+	version := pkg.Version + "-" + pkg.Release
+	if pkg.Epoch != "" {
+		version = pkg.Epoch + ":" + version
+	}
+	return version
+}
+
+func (pkg *RpmPackage) Id() string {
+	return pkg.Name + ":" + pkg.FullVersion()
+}
+
+// Returns the properties, which Artifactory sets on RPM packages when it calculates their metadata.
+func (pkg *RpmPackage) Props() string {
+	epoch := pkg.Epoch
+	if epoch == "" {
+		epoch = "0"
+	}
+	return "rpm.metadata.name=" + pkg.Name + ";rpm.metadata.version=" + pkg.Version + ";rpm.metadata.release=" + pkg.Release +
+		";rpm.metadata.epoch=" + epoch + ";rpm.metadata.arch=" + pkg.Arch()
+}
@@ -0,0 +1,238 @@
+package linuxpkg
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type PackageType string
+
+const (
+	Debian PackageType = "debian"
+	Rpm    PackageType = "rpm"
+
+	DefaultComponent = "main"
+)
+
+// The layout related configuration of the target repository.
+type RepositoryDetails struct {
+	Key         string `json:"key,omitempty"`
+	Rclass      string `json:"rclass,omitempty"`
+	PackageType string `json:"packageType,omitempty"`
+	// The depth of the repodata directory of RPM repositories. Packages above it aren't indexed.
+	YumRootDepth int `json:"yumRootDepth,omitempty"`
+}
+
+// A package file, which was read and is ready to be deployed to a Debian or an RPM repository.
+type packageUpload struct {
+	id       string
+	fileName string
+	// The directory the package is deployed to, when the target has no path.
+	defaultDir string
+	props      string
+	problems   []string
+}
+
+// Reads the metadata of a .deb or a .rpm file, and deploys it to a Debian or an RPM repository with the properties
+// Artifactory indexes it by. The package is validated against the layout of the repository before it is deployed,
+// and nothing is deployed if any mismatch is found.
+// If the build name and number are set, the package is recorded as a build-info artifact.
+type PackageUploadCommand struct {
+	packageType        PackageType
+	serverDetails      *config.ServerDetails
+	packagePath        string
+	target             string
+	distribution       string
+	component          string
+	architecture       string
+	dryRun             bool
+	buildConfiguration *utils.BuildConfiguration
+}
+
+func NewDebUploadCommand() *PackageUploadCommand {
+	return &PackageUploadCommand{packageType: Debian, component: DefaultComponent}
+}
+
+func NewRpmUploadCommand() *PackageUploadCommand {
+	return &PackageUploadCommand{packageType: Rpm}
+}
+
+func (puc *PackageUploadCommand) SetServerDetails(serverDetails *config.ServerDetails) *PackageUploadCommand {
+	puc.serverDetails = serverDetails
+	return puc
+}
+
+func (puc *PackageUploadCommand) SetPackagePath(packagePath string) *PackageUploadCommand {
+	puc.packagePath = packagePath
+	return puc
+}
+
+// Sets the target in the form of <repo>[/<path>]. If the path ends with a slash, or if there is no path, the canonical
+// file name of the package is used. Debian packages are deployed to the pool of their component if there is no path.
+func (puc *PackageUploadCommand) SetTarget(target string) *PackageUploadCommand {
+	puc.target = target
+	return puc
+}
+
+func (puc *PackageUploadCommand) SetDistribution(distribution string) *PackageUploadCommand {
+	puc.distribution = distribution
+	return puc
+}
+
+func (puc *PackageUploadCommand) SetComponent(component string) *PackageUploadCommand {
+	if component != "" {
+		puc.component = component
+	}
+	return puc
+}
+
+// Sets the expected architecture of the package. A package of a different architecture isn't deployed.
+func (puc *PackageUploadCommand) SetArchitecture(architecture string) *PackageUploadCommand {
+	puc.architecture = architecture
+	return puc
+}
+
+func (puc *PackageUploadCommand) SetDryRun(dryRun bool) *PackageUploadCommand {
+	puc.dryRun = dryRun
+	return puc
+}
+
+func (puc *PackageUploadCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *PackageUploadCommand {
+	puc.buildConfiguration = buildConfiguration
+	return puc
+}
+
+func (puc *PackageUploadCommand) ServerDetails() (*config.ServerDetails, error) {
+	return puc.serverDetails, nil
+}
+
+func (puc *PackageUploadCommand) CommandName() string {
+	if puc.packageType == Debian {
+		return "rt_deb_upload"
+	}
+	return "rt_rpm_upload"
+}
+
+func (puc *PackageUploadCommand) Run() error {
+	if puc.packageType == Debian && puc.distribution == "" {
+		return errorutils.CheckError(errors.New("the --distribution option is mandatory"))
+	}
+	upload, err := puc.readPackage()
+	if err != nil {
+		return err
+	}
+	repo := strings.SplitN(puc.target, "/", 2)[0]
+	repoDetails := &RepositoryDetails{}
+	servicesManager, err := utils.CreateServiceManager(puc.serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	if err = servicesManager.GetRepository(repo, repoDetails); err != nil {
+		return err
+	}
+	targetPath, problems := puc.validate(upload, repoDetails)
+	if len(problems) > 0 {
+		return errorutils.CheckError(fmt.Errorf("%s wasn't uploaded, since it doesn't match the target:\n  - %s",
+			puc.packagePath, strings.Join(problems, "\n  - ")))
+	}
+	if puc.dryRun {
+		log.Info("[Dry run] Would upload", upload.id, "to", targetPath, "with the properties", upload.props)
+		return nil
+	}
+	log.Info("Uploading", upload.id, "to", targetPath+"...")
+	artifacts, err := projectutils.DeployFiles(puc.serverDetails, []projectutils.DeployableFile{{
+		LocalPath:  puc.packagePath,
+		TargetPath: targetPath,
+		Props:      upload.props,
+	}}, puc.buildConfiguration)
+	if err != nil {
+		return err
+	}
+	if puc.buildConfiguration.BuildName != "" && puc.buildConfiguration.BuildNumber != "" {
+		moduleId := puc.buildConfiguration.Module
+		if moduleId == "" {
+			moduleId = upload.id
+		}
+		if err = projectutils.SaveArtifacts(puc.buildConfiguration, buildinfo.ModuleType(puc.packageType), moduleId, artifacts); err != nil {
+			return err
+		}
+	}
+	log.Info(string(puc.packageType), "package upload finished successfully.")
+	return nil
+}
+
+// Reads the metadata of the package file.
+func (puc *PackageUploadCommand) readPackage() (*packageUpload, error) {
+	if puc.packageType == Debian {
+		pkg, err := ReadDeb(puc.packagePath)
+		if err != nil {
+			return nil, err
+		}
+		upload := &packageUpload{id: pkg.Id(), fileName: pkg.FileName(), props: pkg.Props(puc.distribution, puc.component), problems: pkg.Validate()}
+		if pkg.Source != "" {
+			upload.defaultDir = pkg.PoolDir(puc.component)
+		}
+		if puc.architecture != "" && puc.architecture != pkg.Architecture {
+			upload.problems = append(upload.problems, "the architecture of the package is '"+pkg.Architecture+"', but '"+puc.architecture+"' was expected")
+		}
+		return upload, nil
+	}
+	pkg, err := ReadRpm(puc.packagePath)
+	if err != nil {
+		return nil, err
+	}
+	upload := &packageUpload{id: pkg.Id(), fileName: pkg.FileName(), props: pkg.Props(), problems: pkg.Validate()}
+	if puc.architecture != "" && puc.architecture != pkg.Arch() {
+		upload.problems = append(upload.problems, "the architecture of the package is '"+pkg.Arch()+"', but '"+puc.architecture+"' was expected")
+	}
+	return upload, nil
+}
+
+// Returns the target path of the package, in the form of <repo>/<path>/<file name>, and the mismatches between the
+// metadata of the package, the target and the layout of the repository.
+func (puc *PackageUploadCommand) validate(upload *packageUpload, repoDetails *RepositoryDetails) (targetPath string, problems []string) {
+	problems = append(problems, upload.problems...)
+	if localName := filepath.Base(puc.packagePath); localName != upload.fileName {
+		log.Warn("The name of " + localName + " doesn't match its metadata. It is uploaded as " + upload.fileName + ".")
+	}
+	if !strings.EqualFold(repoDetails.PackageType, string(puc.packageType)) {
+		problems = append(problems, "the package type of the repository '"+repoDetails.Key+"' is '"+repoDetails.PackageType+"', not '"+string(puc.packageType)+"'")
+	}
+
+	parts := strings.SplitN(puc.target, "/", 2)
+	repo, dir, fileName := parts[0], "", upload.fileName
+	if len(parts) > 1 && parts[1] != "" {
+		if strings.HasSuffix(parts[1], "/") {
+			dir = parts[1]
+		} else {
+			dir, fileName = path.Split(parts[1])
+			if fileName != upload.fileName {
+				problems = append(problems, "the target file name '"+fileName+"' doesn't match the metadata of the package, by which it is named '"+upload.fileName+"'")
+			}
+		}
+	} else {
+		dir = upload.defaultDir
+	}
+	if puc.packageType == Rpm {
+		depth := 0
+		if trimmed := strings.Trim(dir, "/"); trimmed != "" {
+			depth = len(strings.Split(trimmed, "/"))
+		}
+		if depth < repoDetails.YumRootDepth {
+			problems = append(problems, "the repository indexes packages at depth "+strconv.Itoa(repoDetails.YumRootDepth)+
+				" or deeper, but the target directory '"+dir+"' is above it")
+		}
+	}
+	return repo + "/" + dir + fileName, problems
+}
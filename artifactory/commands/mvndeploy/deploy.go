@@ -0,0 +1,192 @@
+package mvndeploy
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The extensions of more than one part, such as of the tar archives compressed by Maven assemblies, which are
+// the types of the files as a whole.
+var multiPartExtensions = []string{"tar.gz", "tar.bz2", "tar.xz", "tar.zst"}
+
+// Deploys a file to a Maven repository in the Maven 2 layout, together with its POM and their checksum files, without Maven.
+// The coordinates are taken from the --gav option, or from the POM if only a POM is provided.
+// If no POM is provided, a minimal POM is generated, unless the file has a classifier, which means that it is
+// attached to an artifact, whose POM is deployed with it.
+// If the build name and number are set, the file and the POM are recorded as build-info artifacts.
+type MvnDeployFileCommand struct {
+	serverDetails      *config.ServerDetails
+	repo               string
+	filePath           string
+	gav                string
+	pomPath            string
+	buildConfiguration *utils.BuildConfiguration
+}
+
+func NewMvnDeployFileCommand() *MvnDeployFileCommand {
+	return &MvnDeployFileCommand{}
+}
+
+func (mdc *MvnDeployFileCommand) SetServerDetails(serverDetails *config.ServerDetails) *MvnDeployFileCommand {
+	mdc.serverDetails = serverDetails
+	return mdc
+}
+
+func (mdc *MvnDeployFileCommand) SetRepo(repo string) *MvnDeployFileCommand {
+	mdc.repo = repo
+	return mdc
+}
+
+func (mdc *MvnDeployFileCommand) SetFilePath(filePath string) *MvnDeployFileCommand {
+	mdc.filePath = filePath
+	return mdc
+}
+
+// Sets the coordinates in the form of <groupId>:<artifactId>:<version>[:<classifier>].
+func (mdc *MvnDeployFileCommand) SetGav(gav string) *MvnDeployFileCommand {
+	mdc.gav = gav
+	return mdc
+}
+
+func (mdc *MvnDeployFileCommand) SetPomPath(pomPath string) *MvnDeployFileCommand {
+	mdc.pomPath = pomPath
+	return mdc
+}
+
+func (mdc *MvnDeployFileCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *MvnDeployFileCommand {
+	mdc.buildConfiguration = buildConfiguration
+	return mdc
+}
+
+func (mdc *MvnDeployFileCommand) ServerDetails() (*config.ServerDetails, error) {
+	return mdc.serverDetails, nil
+}
+
+func (mdc *MvnDeployFileCommand) CommandName() string {
+	return "rt_mvn_deploy_file"
+}
+
+func (mdc *MvnDeployFileCommand) Run() error {
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer fileutils.RemoveTempDir(tempDir)
+	gav, files, err := mdc.prepareFiles(tempDir)
+	if err != nil {
+		return err
+	}
+	// Artifactory validates the checksum files against the deployed files, and they aren't recorded in the build-info.
+	deployable := append([]projectutils.DeployableFile{}, files...)
+	for _, file := range files {
+		checksumFiles, err := writeChecksumFiles(file, tempDir)
+		if err != nil {
+			return err
+		}
+		deployable = append(deployable, checksumFiles...)
+	}
+	log.Info("Deploying", gav.ModuleId(), "to", mdc.repo+"...")
+	artifacts, err := projectutils.DeployFiles(mdc.serverDetails, deployable, mdc.buildConfiguration)
+	if err != nil {
+		return err
+	}
+	if mdc.buildConfiguration.BuildName != "" && mdc.buildConfiguration.BuildNumber != "" {
+		moduleId := mdc.buildConfiguration.Module
+		if moduleId == "" {
+			moduleId = gav.ModuleId()
+		}
+		if err = projectutils.SaveArtifacts(mdc.buildConfiguration, buildinfo.Maven, moduleId, artifacts[:len(files)]); err != nil {
+			return err
+		}
+	}
+	log.Info("mvn-deploy-file finished successfully.")
+	return nil
+}
+
+// Resolves the coordinates, and returns the file and the POM to deploy. A generated POM is written to the temp dir.
+func (mdc *MvnDeployFileCommand) prepareFiles(tempDir string) (*Gav, []projectutils.DeployableFile, error) {
+	if mdc.repo == "" || mdc.filePath == "" {
+		return nil, nil, errorutils.CheckError(errors.New("the target repository and the file are mandatory"))
+	}
+	extension := fileExtension(mdc.filePath)
+	if !gavPartRegExp.MatchString(extension) {
+		return nil, nil, errorutils.CheckError(errors.New("the file " + mdc.filePath + " has no extension, which its type is determined by"))
+	}
+	var gav *Gav
+	var err error
+	if mdc.gav != "" {
+		if gav, err = ParseGav(mdc.gav); err != nil {
+			return nil, nil, err
+		}
+	}
+	pomPath, packaging := mdc.pomPath, extension
+	if pomPath != "" {
+		pomGav, pomPackaging, err := ReadPom(pomPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if gav == nil {
+			gav = pomGav
+		} else if pomGav.ModuleId() != gav.ModuleId() {
+			return nil, nil, errorutils.CheckError(errors.New("the coordinates of " + pomPath + ", " + pomGav.ModuleId() +
+				", don't match the --gav option, " + gav.ModuleId()))
+		}
+		packaging = pomPackaging
+	}
+	if gav == nil {
+		return nil, nil, errorutils.CheckError(errors.New("either the --gav or the --pom option is mandatory"))
+	}
+	if pomPath == "" && gav.Classifier == "" {
+		pomPath = filepath.Join(tempDir, gav.PomFileName())
+		if err = ioutil.WriteFile(pomPath, GeneratePom(gav, packaging), 0644); err != nil {
+			return nil, nil, errorutils.CheckError(err)
+		}
+	}
+
+	targetDir := mdc.repo + "/" + gav.Dir()
+	files := []projectutils.DeployableFile{{LocalPath: mdc.filePath, TargetPath: targetDir + gav.FileName(extension)}}
+	if pomPath != "" {
+		files = append(files, projectutils.DeployableFile{LocalPath: pomPath, TargetPath: targetDir + gav.PomFileName()})
+	}
+	return gav, files, nil
+}
+
+// Returns the extension of the file, which is its type, without the leading dot. A multi-part extension, such as tar.gz,
+// is returned whole.
+func fileExtension(filePath string) string {
+	name := strings.ToLower(filepath.Base(filePath))
+	for _, extension := range multiPartExtensions {
+		if strings.HasSuffix(name, "."+extension) {
+			return filepath.Base(filePath)[len(name)-len(extension):]
+		}
+	}
+	return strings.TrimPrefix(filepath.Ext(filePath), ".")
+}
+
+// Writes the sha1 and md5 checksum files of the file to the temp dir, and returns them deployable next to the file.
+func writeChecksumFiles(file projectutils.DeployableFile, tempDir string) ([]projectutils.DeployableFile, error) {
+	details, err := fileutils.GetFileDetails(file.LocalPath)
+	if err != nil {
+		return nil, err
+	}
+	var checksumFiles []projectutils.DeployableFile
+	for _, checksum := range []struct{ extension, value string }{{"sha1", details.Checksum.Sha1}, {"md5", details.Checksum.Md5}} {
+		name := filepath.Base(file.TargetPath) + "." + checksum.extension
+		localPath := filepath.Join(tempDir, name)
+		if err = ioutil.WriteFile(localPath, []byte(checksum.value), 0644); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		checksumFiles = append(checksumFiles, projectutils.DeployableFile{LocalPath: localPath, TargetPath: file.TargetPath + "." + checksum.extension})
+	}
+	return checksumFiles, nil
+}
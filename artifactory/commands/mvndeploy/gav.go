@@ -0,0 +1,75 @@
+package mvndeploy
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+var (
+	gavPartRegExp    = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	gavVersionRegExp = regexp.MustCompile(`^[A-Za-z0-9_.+-]+$`)
+)
+
+// The coordinates of a Maven artifact.
+type Gav struct {
+	GroupId    string
+	ArtifactId string
+	Version    string
+	Classifier string
+}
+
+// Parses coordinates in the form of <groupId>:<artifactId>:<version>[:<classifier>].
+func ParseGav(gav string) (*Gav, error) {
+	parts := strings.Split(gav, ":")
+	if len(parts) < 3 || len(parts) > 4 {
+		return nil, errorutils.CheckError(errors.New("'" + gav + "' isn't in the form of <groupId>:<artifactId>:<version>[:<classifier>]"))
+	}
+	coordinates := &Gav{GroupId: parts[0], ArtifactId: parts[1], Version: parts[2]}
+	if len(parts) == 4 {
+		coordinates.Classifier = parts[3]
+	}
+	return coordinates, coordinates.Validate()
+}
+
+func (gav *Gav) Validate() error {
+	for name, value := range map[string]string{"groupId": gav.GroupId, "artifactId": gav.ArtifactId} {
+		if !gavPartRegExp.MatchString(value) {
+			return errorutils.CheckError(errors.New("invalid " + name + " '" + value + "'"))
+		}
+	}
+	if !gavVersionRegExp.MatchString(gav.Version) {
+		return errorutils.CheckError(errors.New("invalid version '" + gav.Version + "'"))
+	}
+	if gav.Classifier != "" && !gavPartRegExp.MatchString(gav.Classifier) {
+		return errorutils.CheckError(errors.New("invalid classifier '" + gav.Classifier + "'"))
+	}
+	return nil
+}
+
+// Returns the directory of the version in the Maven 2 layout: <groupId with slashes>/<artifactId>/<version>/.
+func (gav *Gav) Dir() string {
+	return path.Join(strings.Replace(gav.GroupId, ".", "/", -1), gav.ArtifactId, gav.Version) + "/"
+}
+
+// Returns the name of the artifact file with the extension: <artifactId>-<version>[-<classifier>].<extension>.
+func (gav *Gav) FileName(extension string) string {
+	name := gav.ArtifactId + "-" + gav.Version
+	if gav.Classifier != "" {
+		name += "-" + gav.Classifier
+	}
+	return name + "." + extension
+}
+
+// Returns the name of the POM of the version, which has no classifier.
+func (gav *Gav) PomFileName() string {
+	return gav.ArtifactId + "-" + gav.Version + ".pom"
+}
+
+// Returns the build-info module id, <groupId>:<artifactId>:<version>.
+func (gav *Gav) ModuleId() string {
+	return gav.GroupId + ":" + gav.ArtifactId + ":" + gav.Version
+}
@@ -0,0 +1,92 @@
+package mvndeploy
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGav(t *testing.T) {
+	gav, err := ParseGav("org.acme:commons-x:1.0-SNAPSHOT:sources")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &Gav{GroupId: "org.acme", ArtifactId: "commons-x", Version: "1.0-SNAPSHOT", Classifier: "sources"}, gav)
+		assert.Equal(t, "org/acme/commons-x/1.0-SNAPSHOT/", gav.Dir())
+		assert.Equal(t, "commons-x-1.0-SNAPSHOT-sources.jar", gav.FileName("jar"))
+		assert.Equal(t, "commons-x-1.0-SNAPSHOT.pom", gav.PomFileName())
+	}
+	for _, invalid := range []string{"org.acme:commons-x", "org.acme:commons-x:1.0:a:b", "org/acme:commons-x:1.0", "org.acme:commons-x:1 0"} {
+		_, err = ParseGav(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestReadPom(t *testing.T) {
+	gav, packaging, err := ReadPom(filepath.Join("testdata", "pom.xml"))
+	if assert.NoError(t, err) {
+		// The groupId and version are inherited from the parent.
+		assert.Equal(t, "org.acme:commons-x:1.0", gav.ModuleId())
+		assert.Equal(t, "bundle", packaging)
+	}
+	_, _, err = ReadPom(filepath.Join("testdata", "property-pom.xml"))
+	assert.Error(t, err)
+}
+
+func TestFileExtension(t *testing.T) {
+	assert.Equal(t, "jar", fileExtension(filepath.Join("target", "commons-x-1.0.jar")))
+	assert.Equal(t, "tar.gz", fileExtension(filepath.Join("target", "commons-x-1.0-bin.tar.gz")))
+	assert.Equal(t, "tar.bz2", fileExtension("commons-x-1.0.tar.bz2"))
+	assert.Equal(t, "gz", fileExtension("commons-x-1.0.gz"))
+	assert.Equal(t, "", fileExtension("commons-x"))
+}
+
+func TestPrepareFiles(t *testing.T) {
+	tempDir, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer fileutils.RemoveTempDir(tempDir)
+	jarPath := filepath.Join("testdata", "commons-x-1.0.jar")
+
+	// A minimal POM is generated.
+	gav, files, err := NewMvnDeployFileCommand().SetRepo("libs-release-local").SetFilePath(jarPath).SetGav("org.acme:commons-x:1.0").prepareFiles(tempDir)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "org.acme:commons-x:1.0", gav.ModuleId())
+		assert.Equal(t, []projectutils.DeployableFile{
+			{LocalPath: jarPath, TargetPath: "libs-release-local/org/acme/commons-x/1.0/commons-x-1.0.jar"},
+			{LocalPath: filepath.Join(tempDir, "commons-x-1.0.pom"), TargetPath: "libs-release-local/org/acme/commons-x/1.0/commons-x-1.0.pom"},
+		}, files)
+		pom, err := ioutil.ReadFile(files[1].LocalPath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(pom), "<packaging>jar</packaging>")
+		checksumFiles, err := writeChecksumFiles(files[0], tempDir)
+		assert.NoError(t, err)
+		if assert.Len(t, checksumFiles, 2) {
+			assert.Equal(t, "libs-release-local/org/acme/commons-x/1.0/commons-x-1.0.jar.sha1", checksumFiles[0].TargetPath)
+			details, err := fileutils.GetFileDetails(jarPath)
+			assert.NoError(t, err)
+			sha1, err := ioutil.ReadFile(checksumFiles[0].LocalPath)
+			assert.NoError(t, err)
+			assert.Equal(t, details.Checksum.Sha1, string(sha1))
+		}
+	}
+
+	// No POM is generated for an artifact with a classifier.
+	_, files, err = NewMvnDeployFileCommand().SetRepo("libs-release-local").SetFilePath(jarPath).SetGav("org.acme:commons-x:1.0:sources").prepareFiles(tempDir)
+	if assert.NoError(t, err) && assert.Len(t, files, 1) {
+		assert.Equal(t, "libs-release-local/org/acme/commons-x/1.0/commons-x-1.0-sources.jar", files[0].TargetPath)
+	}
+
+	// The coordinates are read from the provided POM.
+	pomPath := filepath.Join("testdata", "pom.xml")
+	_, files, err = NewMvnDeployFileCommand().SetRepo("libs-release-local").SetFilePath(jarPath).SetPomPath(pomPath).prepareFiles(tempDir)
+	if assert.NoError(t, err) && assert.Len(t, files, 2) {
+		assert.Equal(t, pomPath, files[1].LocalPath)
+	}
+
+	_, _, err = NewMvnDeployFileCommand().SetRepo("libs-release-local").SetFilePath(jarPath).SetPomPath(pomPath).SetGav("org.acme:commons-y:1.0").prepareFiles(tempDir)
+	assert.Error(t, err)
+	_, _, err = NewMvnDeployFileCommand().SetRepo("libs-release-local").SetFilePath(jarPath).prepareFiles(tempDir)
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package mvndeploy
+
+import (
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The coordinates of a POM. The groupId and version are inherited from the parent, if they aren't set.
+type pom struct {
+	GroupId    string `xml:"groupId"`
+	ArtifactId string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Packaging  string `xml:"packaging"`
+	Parent     struct {
+		GroupId string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+}
+
+// Reads the coordinates and the packaging of the POM file. The packaging defaults to jar.
+func ReadPom(pomPath string) (gav *Gav, packaging string, err error) {
+	content, err := ioutil.ReadFile(pomPath)
+	if err != nil {
+		return nil, "", errorutils.CheckError(err)
+	}
+	project := &pom{}
+	if err = xml.Unmarshal(content, project); err != nil {
+		return nil, "", errorutils.CheckError(errors.New("failed to parse " + pomPath + ": " + err.Error()))
+	}
+	gav = &Gav{GroupId: project.GroupId, ArtifactId: project.ArtifactId, Version: project.Version}
+	if gav.GroupId == "" {
+		gav.GroupId = project.Parent.GroupId
+	}
+	if gav.Version == "" {
+		gav.Version = project.Parent.Version
+	}
+	for _, value := range []string{gav.GroupId, gav.ArtifactId, gav.Version} {
+		if strings.Contains(value, "${") {
+			return nil, "", errorutils.CheckError(errors.New("the coordinates of " + pomPath + " reference the property " + value + ", which can't be resolved without Maven. Use the --gav option"))
+		}
+	}
+	if err = gav.Validate(); err != nil {
+		return nil, "", err
+	}
+	packaging = strings.TrimSpace(project.Packaging)
+	if packaging == "" {
+		packaging = "jar"
+	}
+	return gav, packaging, nil
+}
+
+// Returns a minimal POM of the coordinates, which has no dependencies.
+func GeneratePom(gav *Gav, packaging string) []byte {
+	return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+    xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>` + gav.GroupId + `</groupId>
+  <artifactId>` + gav.ArtifactId + `</artifactId>
+  <version>` + gav.Version + `</version>
+  <packaging>` + packaging + `</packaging>
+  <description>POM was generated by JFrog CLI</description>
+</project>
+`)
+}
@@ -0,0 +1,233 @@
+package npmlock
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+const (
+	PackageLockFileName = "package-lock.json"
+	ShrinkwrapFileName  = "npm-shrinkwrap.json"
+	YarnLockFileName    = "yarn.lock"
+	PnpmLockFileName    = "pnpm-lock.yaml"
+	packageJsonFileName = "package.json"
+
+	ProdScope = "prod"
+	DevScope  = "dev"
+)
+
+// A package locked in a lockfile, with the packages it depends on.
+type Package struct {
+	Name    string
+	Version string
+	// The Subresource Integrity of the package tarball, such as sha512-<base64>. Empty if the lockfile doesn't record it.
+	Integrity    string
+	Dependencies []*Package
+}
+
+func (pkg *Package) Id() string {
+	return pkg.Name + ":" + pkg.Version
+}
+
+// Returns the sha1 checksum of the integrity in hex, if the integrity is a sha1 one.
+func (pkg *Package) Sha1() string {
+	for _, hash := range strings.Fields(pkg.Integrity) {
+		if strings.HasPrefix(hash, "sha1-") {
+			if sum, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(hash, "sha1-")); err == nil {
+				return hex.EncodeToString(sum)
+			}
+		}
+	}
+	return ""
+}
+
+// Returns the path of the package tarball in an Artifactory npm repository, and its file name.
+func (pkg *Package) TarballPath() (dir, name string) {
+	baseName := pkg.Name
+	if index := strings.LastIndex(baseName, "/"); index >= 0 {
+		baseName = baseName[index+1:]
+	}
+	return pkg.Name + "/-", baseName + "-" + pkg.Version + ".tgz"
+}
+
+// A workspace of the project, or the project itself, with its direct dependencies.
+type Workspace struct {
+	// The directory of the workspace, relative to the root of the project. The project itself is ".".
+	Path string
+	Prod []*Package
+	Dev  []*Package
+}
+
+// Reads the lockfile in the project directory. package-lock.json or npm-shrinkwrap.json is preferred, then yarn.lock and then pnpm-lock.yaml.
+// Returns the workspaces of the project, with the project itself first.
+func ReadLockfile(projectDir string) ([]*Workspace, error) {
+	for _, lockfile := range []struct {
+		name  string
+		parse func(projectDir string, content []byte) ([]*Workspace, error)
+	}{{PackageLockFileName, parsePackageLock}, {ShrinkwrapFileName, parsePackageLock}, {YarnLockFileName, parseYarnLock}, {PnpmLockFileName, parsePnpmLock}} {
+		lockfilePath := filepath.Join(projectDir, lockfile.name)
+		exists, err := fileutils.IsFileExists(lockfilePath, false)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		content, err := ioutil.ReadFile(lockfilePath)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		return lockfile.parse(projectDir, content)
+	}
+	return nil, errorutils.CheckError(errors.New("no " + PackageLockFileName + ", " + ShrinkwrapFileName + ", " + YarnLockFileName + " or " + PnpmLockFileName + " was found in " + projectDir))
+}
+
+// The dependencies declared in package.json.
+type packageJson struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	Workspaces           json.RawMessage   `json:"workspaces"`
+}
+
+func readPackageJson(dir string) (*packageJson, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, packageJsonFileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	pkg := &packageJson{}
+	if err = json.Unmarshal(content, pkg); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return pkg, nil
+}
+
+// Returns the production dependencies, which include the optional ones, and the development dependencies.
+func (pkg *packageJson) scopes() (prod, dev map[string]string) {
+	prod = make(map[string]string)
+	for name, spec := range pkg.Dependencies {
+		prod[name] = spec
+	}
+	for name, spec := range pkg.OptionalDependencies {
+		prod[name] = spec
+	}
+	return prod, pkg.DevDependencies
+}
+
+// Returns the workspace directories of the project, relative to the project directory, by expanding the patterns
+// of the workspaces field of package.json. The field is either a list of patterns, or an object with a packages list.
+func findWorkspaces(projectDir string, pkg *packageJson) ([]string, error) {
+	if len(pkg.Workspaces) == 0 {
+		return nil, nil
+	}
+	var patterns []string
+	if err := json.Unmarshal(pkg.Workspaces, &patterns); err != nil {
+		var workspaces struct {
+			Packages []string `json:"packages"`
+		}
+		if err = json.Unmarshal(pkg.Workspaces, &workspaces); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		patterns = workspaces.Packages
+	}
+	var dirs []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(projectDir, filepath.FromSlash(pattern), packageJsonFileName))
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for _, match := range matches {
+			dir, err := filepath.Rel(projectDir, filepath.Dir(match))
+			if err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			dirs = append(dirs, filepath.ToSlash(dir))
+		}
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// Creates the build-info dependencies of the workspace, which are its direct dependencies and the packages they
+// depend on. The paths in the requestedBy fields end with the module ID. As in 'npm ls', the dependencies of a package
+// are expanded only the first time the package is reached, so the paths of the other appearances end at the package.
+func CreateDependencies(workspace *Workspace, moduleId string) []buildinfo.Dependency {
+	dependencies := make(map[string]*buildinfo.Dependency)
+	for _, scope := range []struct {
+		name     string
+		packages []*Package
+	}{{ProdScope, workspace.Prod}, {DevScope, workspace.Dev}} {
+		byId := make(map[string]*Package)
+		ids := func(packages []*Package) []string {
+			var ids []string
+			for _, pkg := range packages {
+				byId[pkg.Id()] = pkg
+				ids = append(ids, pkg.Id())
+			}
+			return ids
+		}
+		children := func(id string) []string {
+			return ids(byId[id].Dependencies)
+		}
+		projectutils.WalkDependencyGraph(moduleId, ids(scope.packages), children, func(id string, pathToRoot []string) {
+			dependency, exists := dependencies[id]
+			if !exists {
+				dependency = &buildinfo.Dependency{Id: id, Type: "tgz"}
+				dependencies[id] = dependency
+			}
+			if !projectutils.ContainsString(dependency.Scopes, scope.name) {
+				dependency.Scopes = append(dependency.Scopes, scope.name)
+			}
+			dependency.RequestedBy = projectutils.AppendRequestedBy(dependency.RequestedBy, pathToRoot)
+		})
+	}
+	var result []buildinfo.Dependency
+	for _, dependency := range dependencies {
+		result = append(result, *dependency)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Id < result[j].Id
+	})
+	return result
+}
+
+// Returns all of the packages the workspaces depend on, directly or indirectly, sorted by their IDs.
+func CollectPackages(workspaces []*Workspace) []*Package {
+	found := make(map[string]*Package)
+	var add func(pkg *Package)
+	add = func(pkg *Package) {
+		if _, exists := found[pkg.Id()]; exists {
+			return
+		}
+		found[pkg.Id()] = pkg
+		for _, child := range pkg.Dependencies {
+			add(child)
+		}
+	}
+	for _, workspace := range workspaces {
+		for _, pkg := range append(append([]*Package{}, workspace.Prod...), workspace.Dev...) {
+			add(pkg)
+		}
+	}
+	var packages []*Package
+	for _, pkg := range found {
+		packages = append(packages, pkg)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Id() < packages[j].Id()
+	})
+	return packages
+}
@@ -0,0 +1,153 @@
+package npmlock
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func ids(packages []*Package) []string {
+	var result []string
+	for _, pkg := range packages {
+		result = append(result, pkg.Id())
+	}
+	return result
+}
+
+func readWorkspaces(t *testing.T, dir string) []*Workspace {
+	workspaces, err := ReadLockfile(filepath.Join("testdata", dir))
+	assert.NoError(t, err)
+	return workspaces
+}
+
+func TestReadPackageLock(t *testing.T) {
+	workspaces := readWorkspaces(t, "packagelock")
+	if !assert.Len(t, workspaces, 3) {
+		return
+	}
+	assert.Equal(t, ".", workspaces[0].Path)
+	assert.Nil(t, workspaces[0].Prod)
+	assert.Equal(t, []string{"typescript:5.1.6"}, ids(workspaces[0].Dev))
+
+	// The link to the utils workspace isn't a dependency.
+	assert.Equal(t, "packages/app", workspaces[1].Path)
+	if assert.Equal(t, []string{"express:4.18.2"}, ids(workspaces[1].Prod)) {
+		debug := workspaces[1].Prod[0].Dependencies[0]
+		assert.Equal(t, "debug:2.6.9", debug.Id())
+		// The nested node_modules directory of debug is preferred over the root one.
+		assert.Equal(t, []string{"ms:2.0.0"}, ids(debug.Dependencies))
+	}
+	assert.Equal(t, []string{"ms:2.1.3"}, ids(workspaces[2].Prod))
+}
+
+func TestReadYarnLock(t *testing.T) {
+	workspaces := readWorkspaces(t, "yarn")
+	if !assert.Len(t, workspaces, 1) {
+		return
+	}
+	// The alias is recorded by the name of the package it resolves to.
+	assert.Equal(t, []string{"@babel/code-frame:7.12.13", "wrap-ansi:7.0.0"}, ids(workspaces[0].Prod))
+	assert.Equal(t, "sha512-codeframe", workspaces[0].Prod[0].Integrity)
+	highlight := workspaces[0].Prod[0].Dependencies[0]
+	assert.Equal(t, "@babel/highlight:7.14.0", highlight.Id())
+	// chalk@^2.0.0 is locked by the same entry as chalk@^2.4.2, so the development dependency is the same package.
+	assert.Equal(t, []string{"chalk:2.4.2"}, ids(workspaces[0].Dev))
+	assert.Same(t, highlight.Dependencies[0], workspaces[0].Dev[0])
+}
+
+func TestReadBerryLock(t *testing.T) {
+	workspaces := readWorkspaces(t, "berry")
+	if !assert.Len(t, workspaces, 2) {
+		return
+	}
+	assert.Empty(t, workspaces[0].Prod)
+	assert.Equal(t, "packages/app", workspaces[1].Path)
+	// The workspace dependency is left out.
+	assert.Equal(t, []string{"lodash:4.17.21"}, ids(workspaces[1].Prod))
+}
+
+func TestReadPnpmLock(t *testing.T) {
+	workspaces := readWorkspaces(t, "pnpm6")
+	if assert.Len(t, workspaces, 2) {
+		if assert.Equal(t, []string{"eslint-plugin-react:7.33.2"}, ids(workspaces[0].Dev)) {
+			assert.Equal(t, "sha512-plugin", workspaces[0].Dev[0].Integrity)
+			assert.Equal(t, []string{"eslint:8.50.0"}, ids(workspaces[0].Dev[0].Dependencies))
+		}
+		assert.Equal(t, "packages/web", workspaces[1].Path)
+		assert.Equal(t, []string{"@types/react:18.2.22"}, ids(workspaces[1].Prod))
+	}
+
+	workspaces = readWorkspaces(t, "pnpm9")
+	if assert.Len(t, workspaces, 1) && assert.Equal(t, []string{"react:18.2.0", "react-dom:18.2.0"}, ids(workspaces[0].Prod)) {
+		reactDom := workspaces[0].Prod[1]
+		// The integrity is read from the packages, and the dependencies from the snapshots.
+		assert.Equal(t, "sha512-reactdom", reactDom.Integrity)
+		assert.Equal(t, []string{"loose-envify:1.4.0", "react:18.2.0"}, ids(reactDom.Dependencies))
+	}
+}
+
+func TestParsePnpmPackageKey(t *testing.T) {
+	for key, expected := range map[string][2]string{
+		"/@types/react/18.2.22":          {"@types/react", "18.2.22"},
+		"/react-dom/18.2.0_react@18.2.0": {"react-dom", "18.2.0"},
+		"/@types/react@18.2.22":          {"@types/react", "18.2.22"},
+		"react-dom@18.2.0(react@18.2.0)": {"react-dom", "18.2.0"},
+	} {
+		name, version := parsePnpmPackageKey(key)
+		assert.Equal(t, expected, [2]string{name, version}, key)
+	}
+}
+
+func TestCreateDependencies(t *testing.T) {
+	ms := &Package{Name: "ms", Version: "2.1.3"}
+	debug := &Package{Name: "debug", Version: "4.3.4", Dependencies: []*Package{ms}}
+	workspace := &Workspace{Path: ".", Prod: []*Package{debug, ms}, Dev: []*Package{debug}}
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "debug:4.3.4", Type: "tgz", Scopes: []string{ProdScope, DevScope}, RequestedBy: [][]string{{"app:1.0.0"}}},
+		{Id: "ms:2.1.3", Type: "tgz", Scopes: []string{ProdScope, DevScope}, RequestedBy: [][]string{{"debug:4.3.4", "app:1.0.0"}, {"app:1.0.0"}}},
+	}, CreateDependencies(workspace, "app:1.0.0"))
+}
+
+func TestCreateModules(t *testing.T) {
+	projectDir := filepath.Join("testdata", "packagelock")
+	workspaces, err := ReadLockfile(projectDir)
+	assert.NoError(t, err)
+	checksums := map[string]*buildinfo.Checksum{"express:4.18.2": {Sha1: "1", Md5: "2"}}
+	modules, missing, err := CreateModules(projectDir, workspaces, checksums, &utils.BuildConfiguration{BuildName: "build", Module: "root"})
+	assert.NoError(t, err)
+	if assert.Len(t, modules, 3) {
+		assert.Equal(t, "root", modules[0].Id)
+		assert.Equal(t, "org:app:2.0.0", modules[1].Id)
+		assert.Equal(t, "org:utils:1.0.0", modules[2].Id)
+		app := modules[1]
+		assert.Equal(t, buildinfo.Npm, app.Type)
+		assert.Equal(t, "express:4.18.2", app.Dependencies[1].Id)
+		assert.Equal(t, &buildinfo.Checksum{Sha1: "1", Md5: "2"}, app.Dependencies[1].Checksum)
+		// The checksum of debug is taken from its sha1 integrity.
+		assert.Equal(t, "debug:2.6.9", app.Dependencies[0].Id)
+		assert.Equal(t, "5d128515df134ff327e90a4c93f4e077a536341f", app.Dependencies[0].Checksum.Sha1)
+		assert.Equal(t, "sha512-express", app.Properties.(map[string]string)["integrity.express:4.18.2"])
+	}
+	assert.Equal(t, []string{"typescript:5.1.6", "ms:2.0.0", "ms:2.1.3"}, missing)
+}
+
+func TestTarballPath(t *testing.T) {
+	dir, name := (&Package{Name: "@babel/core", Version: "7.0.0"}).TarballPath()
+	assert.Equal(t, "@babel/core/-", dir)
+	assert.Equal(t, "core-7.0.0.tgz", name)
+}
+
+func TestCreateNpmConfigEnv(t *testing.T) {
+	configList := "; \"user\" config from /home/dev/.npmrc\n\n@acme:registry = \"https://npm.acme.io/\"\nregistry = \"https://registry.npmjs.org/\"\n"
+	registry := "https://acme.jfrog.io/artifactory/api/npm/npm-virtual"
+	env := CreateNpmConfigEnv(configList, registry, "_auth = dXNlcjpwYXNz\nalways-auth = true")
+	assert.Equal(t, []string{
+		"npm_config_registry=" + registry,
+		"npm_config_@acme:registry=" + registry,
+		"npm_config__auth=dXNlcjpwYXNz",
+		"npm_config_always-auth=true",
+	}, env)
+}
@@ -0,0 +1,276 @@
+package npmlock
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/npm"
+	commandUtils "github.com/jfrog/jfrog-cli-core/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	npmutils "github.com/jfrog/jfrog-cli-core/artifactory/utils/npm"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The option of npm-install and npm-ci, which collects the build-info from the lockfile.
+const FromLockfileFlag = "--from-lockfile"
+
+// Runs npm install or npm ci with the packages resolved from Artifactory, and collects the build-info dependencies from
+// the lockfile of the project, rather than by running 'npm ls'. package-lock.json, npm-shrinkwrap.json, yarn.lock and
+// pnpm-lock.yaml are supported. Every workspace of the project is recorded as a build-info module of its own.
+// The registry and the credentials are passed to npm through its environment, so the .npmrc of the project isn't changed.
+type NpmLockfileCommand struct {
+	npmCommand     *npm.NpmInstallOrCiCommand
+	configFilePath string
+	args           []string
+	serverDetails  *config.ServerDetails
+}
+
+func NewNpmLockfileCommand(npmCommand *npm.NpmInstallOrCiCommand) *NpmLockfileCommand {
+	return &NpmLockfileCommand{npmCommand: npmCommand}
+}
+
+func (nlc *NpmLockfileCommand) SetConfigFilePath(configFilePath string) *NpmLockfileCommand {
+	nlc.configFilePath = configFilePath
+	return nlc
+}
+
+func (nlc *NpmLockfileCommand) SetArgs(args []string) *NpmLockfileCommand {
+	nlc.args = args
+	return nlc
+}
+
+func (nlc *NpmLockfileCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nlc.serverDetails, nil
+}
+
+func (nlc *NpmLockfileCommand) CommandName() string {
+	return nlc.npmCommand.CommandName()
+}
+
+func (nlc *NpmLockfileCommand) Run() error {
+	vConfig, err := utils.ReadConfigFile(nlc.configFilePath, utils.YAML)
+	if err != nil {
+		return err
+	}
+	resolverParams, err := utils.GetRepoConfigByPrefix(nlc.configFilePath, utils.ProjectConfigResolverPrefix, vConfig)
+	if err != nil {
+		return err
+	}
+	if nlc.serverDetails, err = resolverParams.ServerDetails(); err != nil {
+		return err
+	}
+	threads, detailedSummary, npmArgs, buildConfiguration, err := commandUtils.ExtractNpmOptionsFromArgs(nlc.args)
+	if err != nil {
+		return err
+	}
+	if detailedSummary {
+		log.Warn("The --detailed-summary option isn't supported by npm " + nlc.getNpmCommand() + ", since no files are deployed. The option is ignored.")
+	}
+	if err = nlc.runNpm(resolverParams.TargetRepo(), npmArgs); err != nil {
+		return err
+	}
+	if buildConfiguration.BuildName == "" || buildConfiguration.BuildNumber == "" {
+		return nil
+	}
+	return nlc.collectBuildInfo(resolverParams.TargetRepo(), threads, buildConfiguration)
+}
+
+// Returns 'install' or 'ci', according to the wrapped npm command of jfrog-cli-core.
+func (nlc *NpmLockfileCommand) getNpmCommand() string {
+	return strings.TrimPrefix(nlc.npmCommand.CommandName(), "rt_npm_")
+}
+
+// Runs npm with the build options removed from the arguments.
+func (nlc *NpmLockfileCommand) runNpm(repo string, npmArgs []string) error {
+	npmPath, err := exec.LookPath("npm")
+	if err != nil {
+		return errorutils.CheckError(errors.New("could not find the 'npm' executable in the system PATH"))
+	}
+	env, err := nlc.createNpmEnv(npmPath, repo, npmArgs)
+	if err != nil {
+		return err
+	}
+	args := append([]string{nlc.getNpmCommand()}, npmArgs...)
+	log.Info("Running npm " + strings.Join(args, " "))
+	cmd := exec.Command(npmPath, args...)
+	cmd.Env = env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
+
+// Returns the environment of npm, which sets the registry and the scoped registries to the Artifactory repository,
+// and sets the credentials of the repository. npm gives its environment precedence over the .npmrc files.
+func (nlc *NpmLockfileCommand) createNpmEnv(npmPath, repo string, npmArgs []string) ([]string, error) {
+	authDetails, err := nlc.serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	npmAuth, registry, err := commandUtils.GetArtifactoryNpmRepoDetails(repo, &authDetails)
+	if err != nil {
+		return nil, err
+	}
+	configList, err := npmutils.GetConfigList(npmArgs, npmPath)
+	if err != nil {
+		return nil, err
+	}
+	return append(os.Environ(), CreateNpmConfigEnv(string(configList), registry, npmAuth)...), nil
+}
+
+// Returns the npm_config_* environment variables, which override the registry and the scoped registries of the
+// configuration listed by 'npm config list', and set the configuration lines of the credentials.
+func CreateNpmConfigEnv(configList, registry, npmAuth string) []string {
+	env := []string{"npm_config_registry=" + registry}
+	for _, line := range strings.Split(configList, "\n") {
+		key := strings.TrimSpace(strings.SplitN(line, "=", 2)[0])
+		if strings.HasPrefix(key, "@") && strings.HasSuffix(key, ":registry") {
+			env = append(env, "npm_config_"+key+"="+registry)
+		}
+	}
+	for _, line := range strings.Split(npmAuth, "\n") {
+		keyValue := strings.SplitN(line, "=", 2)
+		if len(keyValue) == 2 {
+			env = append(env, "npm_config_"+strings.TrimSpace(keyValue[0])+"="+strings.TrimSpace(keyValue[1]))
+		}
+	}
+	return env
+}
+
+func (nlc *NpmLockfileCommand) collectBuildInfo(repo string, threads int, buildConfiguration *utils.BuildConfiguration) error {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	workspaces, err := ReadLockfile(projectDir)
+	if err != nil {
+		return err
+	}
+	return SaveWorkspaceModules(nlc.serverDetails, repo, threads, projectDir, workspaces, buildConfiguration)
+}
+
+// Records every workspace as a build-info module, with the checksums of its dependencies taken from the tarballs
+// in the resolution repository. Without a resolution server, the checksums are taken from the sha1 integrities only.
+func SaveWorkspaceModules(serverDetails *config.ServerDetails, repo string, threads int, projectDir string, workspaces []*Workspace, buildConfiguration *utils.BuildConfiguration) error {
+	checksums := make(map[string]*buildinfo.Checksum)
+	if serverDetails != nil {
+		var err error
+		if checksums, err = FindTarballs(serverDetails, repo, threads, CollectPackages(workspaces)); err != nil {
+			return err
+		}
+	}
+	modules, missing, err := CreateModules(projectDir, workspaces, checksums, buildConfiguration)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		log.Warn("The following packages were not found in Artifactory, and are added to the build-info without checksums:\n" + strings.Join(missing, "\n"))
+	}
+	for _, module := range modules {
+		log.Info("Adding", strconv.Itoa(len(module.Dependencies)), "dependencies to module", module.Id, "of the build-info.")
+	}
+	if err = utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project); err != nil {
+		return err
+	}
+	return utils.SaveBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project, &buildinfo.BuildInfo{Modules: modules})
+}
+
+// Searches the repository for the tarballs of the packages, and returns their checksums by the package IDs.
+func FindTarballs(serverDetails *config.ServerDetails, repo string, threads int, packages []*Package) (map[string]*buildinfo.Checksum, error) {
+	servicesManager, err := utils.CreateServiceManagerWithThreads(serverDetails, false, threads, -1)
+	if err != nil {
+		return nil, err
+	}
+	searchRepo, err := utils.GetRepoNameForDependenciesSearch(repo, servicesManager)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string)
+	var conditions []string
+	for _, pkg := range packages {
+		dir, name := pkg.TarballPath()
+		condition, err := json.Marshal(map[string]string{"path": dir, "name": name})
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		conditions = append(conditions, string(condition))
+		ids[path.Join(dir, name)] = pkg.Id()
+	}
+	items, err := projectutils.FindItems(servicesManager, searchRepo, conditions)
+	if err != nil {
+		return nil, err
+	}
+	checksums := make(map[string]*buildinfo.Checksum)
+	for i := range items {
+		if id, exists := ids[path.Join(items[i].Path, items[i].Name)]; exists {
+			checksums[id] = items[i].Checksum()
+		}
+	}
+	return checksums, nil
+}
+
+// Creates a build-info module for every workspace, which has dependencies, with the integrities recorded in the lockfile
+// as module hashes.
+// The checksums of the packages, which weren't found in Artifactory, are taken from their integrities if they are sha1 ones.
+// The IDs of the packages, which are left without checksums, are returned.
+func CreateModules(projectDir string, workspaces []*Workspace, checksums map[string]*buildinfo.Checksum, buildConfiguration *utils.BuildConfiguration) (modules []buildinfo.Module, missing []string, err error) {
+	packages := make(map[string]*Package)
+	for _, pkg := range CollectPackages(workspaces) {
+		packages[pkg.Id()] = pkg
+	}
+	missingIds := make(map[string]bool)
+	for _, workspace := range workspaces {
+		moduleId, err := getModuleId(projectDir, workspace, buildConfiguration)
+		if err != nil {
+			return nil, nil, err
+		}
+		module := buildinfo.Module{Id: moduleId, Type: buildinfo.Npm, Dependencies: CreateDependencies(workspace, moduleId)}
+		if len(module.Dependencies) == 0 {
+			continue
+		}
+		integrities := make(projectutils.ModuleHashes)
+		for i, dependency := range module.Dependencies {
+			pkg := packages[dependency.Id]
+			integrities.Add("integrity", dependency.Id, pkg.Integrity)
+			if checksum, exists := checksums[dependency.Id]; exists {
+				module.Dependencies[i].Checksum = checksum
+			} else if sha1 := pkg.Sha1(); sha1 != "" {
+				module.Dependencies[i].Checksum = &buildinfo.Checksum{Sha1: sha1}
+			} else if !missingIds[dependency.Id] {
+				missingIds[dependency.Id] = true
+				missing = append(missing, dependency.Id)
+			}
+		}
+		integrities.SetProperties(&module)
+		modules = append(modules, module)
+	}
+	return
+}
+
+// Returns the module ID of the workspace, in the form of [<scope>:]<name>:<version>, as read from its package.json.
+// The module ID of the project itself may be passed with the --module option. If the package.json has no name,
+// the build name is used for the project itself, and the path of the workspace for the other workspaces.
+func getModuleId(projectDir string, workspace *Workspace, buildConfiguration *utils.BuildConfiguration) (string, error) {
+	if workspace.Path == "." && buildConfiguration.Module != "" {
+		return buildConfiguration.Module, nil
+	}
+	packageInfo, err := commandUtils.ReadPackageInfoFromPackageJson(filepath.Join(projectDir, filepath.FromSlash(workspace.Path)))
+	if err != nil {
+		return "", err
+	}
+	if packageInfo.Name != "" {
+		return packageInfo.BuildInfoModuleId(), nil
+	}
+	if workspace.Path == "." {
+		return buildConfiguration.BuildName, nil
+	}
+	return workspace.Path, nil
+}
@@ -0,0 +1,138 @@
+package npmlock
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The package-lock.json of lockfileVersion 2 or 3, which lists the packages by their paths in the project.
+type packageLock struct {
+	LockfileVersion int                          `json:"lockfileVersion"`
+	Packages        map[string]*packageLockEntry `json:"packages"`
+}
+
+type packageLockEntry struct {
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Integrity            string            `json:"integrity"`
+	Link                 bool              `json:"link"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+func parsePackageLock(projectDir string, content []byte) ([]*Workspace, error) {
+	lock := &packageLock{}
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if lock.LockfileVersion < 2 || lock.Packages == nil {
+		return nil, errorutils.CheckError(errors.New("lockfileVersion " + strconv.Itoa(lock.LockfileVersion) + " of " + PackageLockFileName +
+			" isn't supported. Run 'npm install' with npm 7 or above to upgrade it to lockfileVersion 2 or 3"))
+	}
+	rootPackageJson, err := readPackageJson(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	workspaceDirs, err := findWorkspaces(projectDir, rootPackageJson)
+	if err != nil {
+		return nil, err
+	}
+	resolver := &packageLockResolver{lock: lock, packages: make(map[string]*Package)}
+	var workspaces []*Workspace
+	for _, dir := range append([]string{""}, workspaceDirs...) {
+		entry, exists := lock.Packages[dir]
+		if !exists {
+			log.Warn("Workspace " + dir + " isn't listed in " + PackageLockFileName + ", so its dependencies are not added to the build-info.")
+			continue
+		}
+		workspace := &Workspace{Path: dir}
+		if dir == "" {
+			workspace.Path = "."
+		}
+		for _, name := range sortedNames(entry.Dependencies, entry.OptionalDependencies) {
+			if pkg := resolver.resolve(dir, name); pkg != nil {
+				workspace.Prod = append(workspace.Prod, pkg)
+			}
+		}
+		for _, name := range sortedNames(entry.DevDependencies) {
+			if pkg := resolver.resolve(dir, name); pkg != nil {
+				workspace.Dev = append(workspace.Dev, pkg)
+			}
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, nil
+}
+
+// Resolves the dependencies of the packages in package-lock.json, the way Node.js resolves them,
+// by looking for them in the node_modules directories of the package and of its ancestors.
+type packageLockResolver struct {
+	lock *packageLock
+	// The resolved packages by their keys in package-lock.json.
+	packages map[string]*Package
+}
+
+// Returns the package which the package in the location depends on by the name,
+// or nil if the package isn't installed, or if it's a link to a workspace or to a local directory.
+func (resolver *packageLockResolver) resolve(location, name string) *Package {
+	for {
+		key := path.Join(location, "node_modules", name)
+		if entry, exists := resolver.lock.Packages[key]; exists {
+			if entry.Link {
+				return nil
+			}
+			return resolver.getPackage(key, entry)
+		}
+		if location == "" {
+			return nil
+		}
+		if index := strings.LastIndex(location, "node_modules/"); index >= 0 {
+			location = strings.TrimSuffix(location[:index], "/")
+		} else if location = path.Dir(location); location == "." {
+			location = ""
+		}
+	}
+}
+
+func (resolver *packageLockResolver) getPackage(key string, entry *packageLockEntry) *Package {
+	if pkg, exists := resolver.packages[key]; exists {
+		return pkg
+	}
+	pkg := &Package{Name: entry.Name, Version: entry.Version, Integrity: entry.Integrity}
+	if pkg.Name == "" {
+		pkg.Name = key[strings.LastIndex(key, "node_modules/")+len("node_modules/"):]
+	}
+	// The package is registered before its dependencies are resolved, so that cyclic dependencies end.
+	resolver.packages[key] = pkg
+	for _, name := range sortedNames(entry.Dependencies, entry.OptionalDependencies, entry.PeerDependencies) {
+		if dependency := resolver.resolve(key, name); dependency != nil {
+			pkg.Dependencies = append(pkg.Dependencies, dependency)
+		}
+	}
+	return pkg
+}
+
+// Returns the names of the dependencies in the maps, sorted and without duplicates.
+func sortedNames(dependencyMaps ...map[string]string) []string {
+	found := make(map[string]bool)
+	var names []string
+	for _, dependencies := range dependencyMaps {
+		for name := range dependencies {
+			if !found[name] {
+				found[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
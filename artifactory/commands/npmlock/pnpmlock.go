@@ -0,0 +1,218 @@
+package npmlock
+
+import (
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+// The pnpm-lock.yaml of lockfileVersion 5, 6 or 9. The dependencies of the projects of the workspace are listed
+// under the importers. A lockfile of a project, which isn't a workspace, may list them at its root instead.
+type pnpmLock struct {
+	Importers    map[string]*pnpmImporter `yaml:"importers"`
+	pnpmImporter `yaml:",inline"`
+	Packages     map[string]*pnpmPackage `yaml:"packages"`
+	// Since lockfileVersion 9, the dependencies of the packages are listed in the snapshots rather than in the packages.
+	Snapshots map[string]*pnpmPackage `yaml:"snapshots"`
+}
+
+type pnpmImporter struct {
+	Dependencies         pnpmDependencies `yaml:"dependencies"`
+	DevDependencies      pnpmDependencies `yaml:"devDependencies"`
+	OptionalDependencies pnpmDependencies `yaml:"optionalDependencies"`
+}
+
+type pnpmPackage struct {
+	// The name and the version are set only if they can't be derived from the key of the package.
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Resolution struct {
+		Integrity string `yaml:"integrity"`
+	} `yaml:"resolution"`
+	Dependencies         pnpmDependencies `yaml:"dependencies"`
+	OptionalDependencies pnpmDependencies `yaml:"optionalDependencies"`
+}
+
+// The versions of the dependencies by their names. Until lockfileVersion 6, the dependencies of an importer are
+// listed as <name>: <version>, and since then as <name>: {specifier: <range>, version: <version>}.
+// The versions may include the peer dependencies, such as 1.0.0_react@17.0.2 or 1.0.0(react@17.0.2),
+// or be a link to a local directory, such as link:../other.
+type pnpmDependencies map[string]string
+
+func (dependencies *pnpmDependencies) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var versions map[string]string
+	if err := unmarshal(&versions); err == nil {
+		*dependencies = versions
+		return nil
+	}
+	var specifiers map[string]struct {
+		Version string `yaml:"version"`
+	}
+	if err := unmarshal(&specifiers); err != nil {
+		return err
+	}
+	*dependencies = make(pnpmDependencies)
+	for name, specifier := range specifiers {
+		(*dependencies)[name] = specifier.Version
+	}
+	return nil
+}
+
+// Reads the pnpm-lock.yaml in the project directory, regardless of the other lockfiles.
+func ReadPnpmLock(projectDir string) ([]*Workspace, error) {
+	content, err := ioutil.ReadFile(filepath.Join(projectDir, PnpmLockFileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parsePnpmLock(projectDir, content)
+}
+
+// Parses pnpm-lock.yaml. The projects of the workspace are listed in the importers, so pnpm-workspace.yaml isn't read.
+func parsePnpmLock(_ string, content []byte) ([]*Workspace, error) {
+	lock := &pnpmLock{}
+	if err := yaml.Unmarshal(content, lock); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	if lock.Importers == nil {
+		lock.Importers = map[string]*pnpmImporter{".": &lock.pnpmImporter}
+	}
+	resolver := &pnpmLockResolver{lock: lock, packages: make(map[string]*Package)}
+	var dirs []string
+	for dir := range lock.Importers {
+		if dir != "." {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	if _, exists := lock.Importers["."]; exists {
+		dirs = append([]string{"."}, dirs...)
+	}
+	var workspaces []*Workspace
+	for _, dir := range dirs {
+		importer := lock.Importers[dir]
+		workspace := &Workspace{Path: dir}
+		for _, name := range sortedNames(importer.Dependencies, importer.OptionalDependencies) {
+			version, exists := importer.Dependencies[name]
+			if !exists {
+				version = importer.OptionalDependencies[name]
+			}
+			if pkg := resolver.resolve(name, version); pkg != nil {
+				workspace.Prod = append(workspace.Prod, pkg)
+			}
+		}
+		for _, name := range sortedNames(importer.DevDependencies) {
+			if pkg := resolver.resolve(name, importer.DevDependencies[name]); pkg != nil {
+				workspace.Dev = append(workspace.Dev, pkg)
+			}
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, nil
+}
+
+type pnpmLockResolver struct {
+	lock *pnpmLock
+	// The resolved packages by their keys in pnpm-lock.yaml.
+	packages map[string]*Package
+}
+
+// Returns the package which is depended on by the name and the version, or nil if the version is a link or the package isn't locked.
+func (resolver *pnpmLockResolver) resolve(name, version string) *Package {
+	if strings.HasPrefix(version, "link:") || strings.HasPrefix(version, "file:") || strings.HasPrefix(version, "workspace:") {
+		return nil
+	}
+	for _, key := range getPnpmPackageKeys(name, version) {
+		if pkg, exists := resolver.packages[key]; exists {
+			return pkg
+		}
+		if entry, exists := resolver.getEntry(key); exists {
+			return resolver.createPackage(key, entry)
+		}
+	}
+	log.Debug("The dependency " + name + " " + version + " isn't locked in " + PnpmLockFileName + ", and is therefore skipped.")
+	return nil
+}
+
+// Returns the entry of the package, and its integrity, which is listed in the packages rather than in the snapshots.
+func (resolver *pnpmLockResolver) getEntry(key string) (*pnpmPackage, bool) {
+	if resolver.lock.Snapshots != nil {
+		snapshot, exists := resolver.lock.Snapshots[key]
+		if !exists {
+			return nil, false
+		}
+		merged := *snapshot
+		if entry, exists := resolver.lock.Packages[stripPeerSuffix(key)]; exists {
+			merged.Name, merged.Version, merged.Resolution = entry.Name, entry.Version, entry.Resolution
+		}
+		return &merged, true
+	}
+	entry, exists := resolver.lock.Packages[key]
+	return entry, exists
+}
+
+func (resolver *pnpmLockResolver) createPackage(key string, entry *pnpmPackage) *Package {
+	name, version := parsePnpmPackageKey(key)
+	if entry.Name != "" {
+		name = entry.Name
+	}
+	if entry.Version != "" {
+		version = entry.Version
+	}
+	pkg := &Package{Name: name, Version: version, Integrity: entry.Resolution.Integrity}
+	resolver.packages[key] = pkg
+	for _, dependencyName := range sortedNames(entry.Dependencies, entry.OptionalDependencies) {
+		dependencyVersion, exists := entry.Dependencies[dependencyName]
+		if !exists {
+			dependencyVersion = entry.OptionalDependencies[dependencyName]
+		}
+		if dependency := resolver.resolve(dependencyName, dependencyVersion); dependency != nil {
+			pkg.Dependencies = append(pkg.Dependencies, dependency)
+		}
+	}
+	return pkg
+}
+
+// Returns the possible keys of the package in the lockfile. The keys are in the form of /<name>/<version> until
+// lockfileVersion 6, /<name>@<version> in lockfileVersion 6 and <name>@<version> since lockfileVersion 9.
+// A version, which is a key by itself, is of a package which is depended on by an alias.
+func getPnpmPackageKeys(name, version string) []string {
+	if strings.HasPrefix(version, "/") {
+		return []string{version}
+	}
+	keys := []string{name + "@" + version, "/" + name + "@" + version, "/" + name + "/" + version}
+	if index := strings.Index(stripPeerSuffix(version), "@"); index > 0 {
+		keys = append([]string{version}, keys...)
+	}
+	return keys
+}
+
+// Returns the name and the version of the package by its key.
+func parsePnpmPackageKey(key string) (name, version string) {
+	key = strings.TrimPrefix(stripPeerSuffix(key), "/")
+	if index := strings.Index(key[1:], "@"); index >= 0 {
+		return key[:index+1], key[index+2:]
+	}
+	// The key is in the form of <name>/<version>, where a scoped name includes a slash too.
+	return path.Dir(key), path.Base(key)
+}
+
+// Removes the peer dependencies from the version or the key of the package,
+// which are in the form of (peer@1.0.0) since lockfileVersion 6, and _peer@1.0.0 before.
+func stripPeerSuffix(key string) string {
+	if index := strings.Index(key, "("); index >= 0 {
+		key = key[:index]
+	}
+	if strings.HasPrefix(key, "/") {
+		versionStart := strings.LastIndex(key, "/")
+		if index := strings.Index(key[versionStart:], "_"); index >= 0 {
+			key = key[:versionStart+index]
+		}
+	}
+	return key
+}
@@ -0,0 +1,219 @@
+package npmlock
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+// An entry of yarn.lock, which locks the package for one or more descriptors, in the form of <name>@<range>.
+type yarnLockEntry struct {
+	Version   string `yaml:"version"`
+	Integrity string `yaml:"integrity"`
+	// The resolution of the entry in the yarn.lock of Yarn 2 and above, such as name@npm:1.0.0 or name@workspace:packages/name.
+	Resolution           string            `yaml:"resolution"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+	PeerDependencies     map[string]string `yaml:"peerDependencies"`
+}
+
+func parseYarnLock(projectDir string, content []byte) ([]*Workspace, error) {
+	var entries map[string]*yarnLockEntry
+	var err error
+	// The yarn.lock of Yarn 2 and above is a YAML file with a __metadata entry.
+	// The yarn.lock of Yarn 1 has a syntax of its own, which isn't YAML.
+	if bytes.Contains(content, []byte("\n__metadata:")) {
+		err = errorutils.CheckError(yaml.Unmarshal(content, &entries))
+	} else {
+		entries, err = parseYarnV1Lock(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resolver := &yarnLockResolver{entries: make(map[string]*yarnLockEntry), packages: make(map[*yarnLockEntry]*Package)}
+	for key, entry := range entries {
+		if key == "__metadata" {
+			continue
+		}
+		for _, descriptor := range strings.Split(key, ",") {
+			resolver.entries[strings.Trim(strings.TrimSpace(descriptor), `"`)] = entry
+		}
+	}
+
+	rootPackageJson, err := readPackageJson(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	workspaceDirs, err := findWorkspaces(projectDir, rootPackageJson)
+	if err != nil {
+		return nil, err
+	}
+	var workspaces []*Workspace
+	for _, dir := range append([]string{"."}, workspaceDirs...) {
+		pkg := rootPackageJson
+		if dir != "." {
+			if pkg, err = readPackageJson(filepath.Join(projectDir, filepath.FromSlash(dir))); err != nil {
+				return nil, err
+			}
+		}
+		workspace := &Workspace{Path: dir}
+		prod, dev := pkg.scopes()
+		for _, name := range sortedNames(prod) {
+			if dependency := resolver.resolve(name, prod[name]); dependency != nil {
+				workspace.Prod = append(workspace.Prod, dependency)
+			}
+		}
+		for _, name := range sortedNames(dev) {
+			if dependency := resolver.resolve(name, dev[name]); dependency != nil {
+				workspace.Dev = append(workspace.Dev, dependency)
+			}
+		}
+		workspaces = append(workspaces, workspace)
+	}
+	return workspaces, nil
+}
+
+type yarnLockResolver struct {
+	// The entries by their descriptors.
+	entries  map[string]*yarnLockEntry
+	packages map[*yarnLockEntry]*Package
+}
+
+// Returns the package locked for the name and the range, or nil if it isn't locked or if it's a workspace.
+// Yarn 2 and above adds the npm: protocol to the descriptors of the ranges, which are declared without it.
+func (resolver *yarnLockResolver) resolve(name, versionRange string) *Package {
+	entry, exists := resolver.entries[name+"@"+versionRange]
+	if !exists {
+		if entry, exists = resolver.entries[name+"@npm:"+versionRange]; !exists {
+			log.Debug("The dependency " + name + "@" + versionRange + " isn't locked in " + YarnLockFileName + ", and is therefore skipped.")
+			return nil
+		}
+	}
+	if strings.Contains(entry.Resolution, "@workspace:") || strings.Contains(entry.Resolution, "@link:") {
+		return nil
+	}
+	if pkg, exists := resolver.packages[entry]; exists {
+		return pkg
+	}
+	pkg := &Package{Name: getYarnPackageName(name, versionRange, entry), Version: entry.Version, Integrity: entry.Integrity}
+	resolver.packages[entry] = pkg
+	dependencies := make(map[string]string)
+	for _, dependencyMap := range []map[string]string{entry.Dependencies, entry.OptionalDependencies, entry.PeerDependencies} {
+		for dependencyName, dependencyRange := range dependencyMap {
+			dependencies[dependencyName] = dependencyRange
+		}
+	}
+	for _, dependencyName := range sortedNames(dependencies) {
+		if dependency := resolver.resolve(dependencyName, dependencies[dependencyName]); dependency != nil {
+			pkg.Dependencies = append(pkg.Dependencies, dependency)
+		}
+	}
+	return pkg
+}
+
+// Returns the name of the package. The name, which the package is depended on by, is an alias when the package
+// is resolved under another name, as in alias@npm:name@^1.0.0.
+func getYarnPackageName(name, versionRange string, entry *yarnLockEntry) string {
+	if entry.Resolution != "" {
+		return splitDescriptor(entry.Resolution)
+	}
+	if alias := strings.TrimPrefix(versionRange, "npm:"); alias != versionRange && strings.Contains(alias[1:], "@") {
+		return splitDescriptor(alias)
+	}
+	return name
+}
+
+// Returns the name of the descriptor, in the form of <name>@<range>. Scoped names start with @ too.
+func splitDescriptor(descriptor string) string {
+	if index := strings.Index(descriptor[1:], "@"); index >= 0 {
+		return descriptor[:index+1]
+	}
+	return descriptor
+}
+
+// Parses the yarn.lock of Yarn 1. The entries are separated by their indentation, as in the following example:
+//
+//	"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.10.4":
+//	  version "7.12.13"
+//	  integrity sha512-...
+//	  dependencies:
+//	    "@babel/highlight" "^7.12.13"
+func parseYarnV1Lock(content []byte) (map[string]*yarnLockEntry, error) {
+	entries := make(map[string]*yarnLockEntry)
+	var entry *yarnLockEntry
+	var section map[string]string
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		switch {
+		case indent == 0:
+			if !strings.HasSuffix(trimmed, ":") {
+				return nil, errorutils.CheckError(errors.New("unexpected line " + strconv.Itoa(lineNumber) + " of " + YarnLockFileName + ": " + trimmed))
+			}
+			entry = &yarnLockEntry{}
+			entries[strings.TrimSuffix(trimmed, ":")] = entry
+			section = nil
+		case entry == nil:
+			return nil, errorutils.CheckError(errors.New("unexpected indentation in line " + strconv.Itoa(lineNumber) + " of " + YarnLockFileName))
+		case indent <= 2:
+			section = nil
+			if strings.HasSuffix(trimmed, ":") {
+				section = make(map[string]string)
+				switch strings.TrimSuffix(trimmed, ":") {
+				case "dependencies":
+					entry.Dependencies = section
+				case "optionalDependencies":
+					entry.OptionalDependencies = section
+				case "peerDependencies":
+					entry.PeerDependencies = section
+				}
+				continue
+			}
+			key, value := splitYarnV1Field(trimmed)
+			switch key {
+			case "version":
+				entry.Version = value
+			case "integrity":
+				entry.Integrity = value
+			}
+		case section != nil:
+			key, value := splitYarnV1Field(trimmed)
+			section[key] = value
+		}
+	}
+	return entries, errorutils.CheckError(scanner.Err())
+}
+
+// Splits a field of yarn.lock of Yarn 1, in the form of <key> <value>, where both may be quoted.
+func splitYarnV1Field(field string) (key, value string) {
+	if strings.HasPrefix(field, `"`) {
+		if end := strings.Index(field[1:], `"`); end >= 0 {
+			return field[1 : end+1], unquote(strings.TrimSpace(field[end+2:]))
+		}
+	}
+	if index := strings.Index(field, " "); index >= 0 {
+		return field[:index], unquote(strings.TrimSpace(field[index+1:]))
+	}
+	return field, ""
+}
+
+func unquote(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return value
+}
@@ -0,0 +1,199 @@
+package oci
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	manifestFileName     = "manifest.json"
+	fatManifestFileName  = "list.manifest.json"
+	manifestArtifactType = "json"
+)
+
+// Splits an image in the form of <name>[:<tag>] into its name and tag.
+// A colon which is followed by a slash separates a registry port rather than a tag.
+func ParseImage(image string) (name, tag string) {
+	if index := strings.LastIndex(image, ":"); index > strings.LastIndex(image, "/") {
+		return image[:index], image[index+1:]
+	}
+	return image, ""
+}
+
+// A folder in the Artifactory Docker repository, in which a manifest or an index is stored with the blobs it references.
+type ImageFolder struct {
+	// The path of the folder in the repository.
+	Path     string
+	Platform *Platform
+	// The digest of the image config. Empty for the folder of an index.
+	ConfigDigest string
+	Files        []ImageFile
+}
+
+type ImageFile struct {
+	Name       string
+	Descriptor Descriptor
+}
+
+// Returns the folders in which Artifactory stores the pushed image.
+// A single-platform image is stored in the <name>/<tag> folder, with its manifest.json and blobs.
+// The index of a multi-arch image is stored as the list.manifest.json file of the <name>/<tag> folder,
+// and each platform manifest is stored with its blobs in the <name>/sha256__<manifest digest> folder.
+func GetImageFolders(image *Image, name, tag string) []*ImageFolder {
+	tagFolder := path.Join(name, tag)
+	if !image.IsMultiArch() {
+		return []*ImageFolder{newManifestFolder(tagFolder, image.Manifests[0])}
+	}
+	folders := []*ImageFolder{{Path: tagFolder, Files: []ImageFile{{Name: fatManifestFileName, Descriptor: image.Descriptor}}}}
+	for _, imageManifest := range image.Manifests {
+		folders = append(folders, newManifestFolder(path.Join(name, digestToFolderName(imageManifest.Descriptor.Digest)), imageManifest))
+	}
+	return folders
+}
+
+func newManifestFolder(folderPath string, imageManifest *ImageManifest) *ImageFolder {
+	folder := &ImageFolder{Path: folderPath, Platform: imageManifest.Platform, ConfigDigest: imageManifest.Manifest.Config.Digest}
+	folder.Files = append(folder.Files, ImageFile{Name: manifestFileName, Descriptor: imageManifest.Descriptor})
+	for _, blob := range imageManifest.Blobs() {
+		folder.Files = append(folder.Files, ImageFile{Name: digestToFileName(blob.Digest), Descriptor: blob})
+	}
+	return folder
+}
+
+// Artifactory stores the blobs in files named after their digests, with "__" in place of the ":".
+func digestToFileName(digest string) string {
+	return strings.Replace(digest, ":", "__", 1)
+}
+
+// Artifactory stores the manifests, which are referenced by their digests, in folders named after the digests,
+// with "__" in place of the ":", as the blob files.
+func digestToFolderName(digest string) string {
+	return strings.Replace(digest, ":", "__", 1)
+}
+
+// Searches Artifactory for the files of the image folders, and returns them as the artifacts of the build-info modules.
+// The module of a single-platform image is named after the module ID. The modules of a multi-arch image are the module
+// of the index, named after the module ID, and a module per platform, named after the module ID followed by the platform.
+// The found files are returned too, so that the build properties can be set on them.
+func CollectModules(servicesManager artifactory.ArtifactoryServicesManager, repo string, folders []*ImageFolder, imageTag, moduleId string) ([]buildinfo.Module, []serviceutils.ResultItem, error) {
+	searchRepo, err := utils.GetRepoNameForDependenciesSearch(repo, servicesManager)
+	if err != nil {
+		return nil, nil, err
+	}
+	var conditions []string
+	for _, folder := range folders {
+		condition, err := json.Marshal(map[string]string{"path": folder.Path})
+		if err != nil {
+			return nil, nil, errorutils.CheckError(err)
+		}
+		conditions = append(conditions, string(condition))
+	}
+	items, err := projectutils.FindItems(servicesManager, searchRepo, conditions)
+	if err != nil {
+		return nil, nil, err
+	}
+	found := make(map[string]*projectutils.AqlItem)
+	for i := range items {
+		found[path.Join(items[i].Path, items[i].Name)] = &items[i]
+	}
+	var modules []buildinfo.Module
+	var foundItems []serviceutils.ResultItem
+	var missing []string
+	for _, folder := range folders {
+		module := buildinfo.Module{Id: moduleId, Type: buildinfo.Docker, Properties: createModuleProperties(folder, imageTag)}
+		if folder.Platform != nil && len(folders) > 1 {
+			module.Id += "/" + folder.Platform.String()
+		}
+		for _, file := range folder.Files {
+			item, exists := found[path.Join(folder.Path, file.Name)]
+			if !exists {
+				if file.Descriptor.IsNonDistributable() {
+					log.Info("The non-distributable layer " + file.Name + " isn't stored in Artifactory, and is therefore not added to the build-info.")
+					continue
+				}
+				missing = append(missing, path.Join(folder.Path, file.Name))
+				continue
+			}
+			artifact := buildinfo.Artifact{Name: item.Name, Checksum: item.Checksum(), Path: path.Join(item.Repo, item.Path, item.Name)}
+			if file.Descriptor.IsManifest() || file.Descriptor.IsIndex() {
+				artifact.Type = manifestArtifactType
+			}
+			module.Artifacts = append(module.Artifacts, artifact)
+			foundItems = append(foundItems, serviceutils.ResultItem{Repo: item.Repo, Path: item.Path, Name: item.Name, Type: "file"})
+		}
+		modules = append(modules, module)
+	}
+	if len(missing) > 0 {
+		return nil, nil, errorutils.CheckError(errors.New("the following files of image " + imageTag + " were not found in " + repo + ":\n" + strings.Join(missing, "\n")))
+	}
+	return modules, foundItems, nil
+}
+
+func createModuleProperties(folder *ImageFolder, imageTag string) map[string]string {
+	properties := map[string]string{"docker.image.tag": imageTag}
+	if folder.ConfigDigest != "" {
+		properties["docker.image.id"] = folder.ConfigDigest
+	}
+	if folder.Platform != nil {
+		properties["docker.image.os"] = folder.Platform.Os
+		properties["docker.image.architecture"] = folder.Platform.Architecture
+		if folder.Platform.Variant != "" {
+			properties["docker.image.variant"] = folder.Platform.Variant
+		}
+	}
+	return properties
+}
+
+// Sets the build properties on the files of the image.
+func SetBuildProperties(servicesManager artifactory.ArtifactoryServicesManager, items []serviceutils.ResultItem, buildConfiguration *utils.BuildConfiguration) (err error) {
+	props, err := utils.CreateBuildProperties(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project)
+	if err != nil {
+		return err
+	}
+	writer, err := content.NewContentWriter("results", true, false)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		writer.Write(item)
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	_, err = servicesManager.SetProps(services.PropsParams{Reader: reader, Props: props})
+	return err
+}
+
+// Saves the modules of the image in the build-info.
+func SaveModules(buildConfiguration *utils.BuildConfiguration, modules []buildinfo.Module) error {
+	if err := utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project); err != nil {
+		return err
+	}
+	return utils.SaveBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project, &buildinfo.BuildInfo{Modules: modules})
+}
+
+func moduleIds(modules []buildinfo.Module) []string {
+	var ids []string
+	for _, module := range modules {
+		ids = append(ids, module.Id)
+	}
+	return ids
+}
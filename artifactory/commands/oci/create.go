@@ -0,0 +1,77 @@
+package oci
+
+import (
+	"errors"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// Records the images of an OCI image layout, which were pushed to Artifactory by a daemonless builder such as buildah,
+// ko or bazel rules_oci, in the build-info. Every manifest and blob of the images must be found in the repository.
+type BuildOciCreateCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *utils.BuildConfiguration
+	layoutDir          string
+	repo               string
+	image              string
+}
+
+func NewBuildOciCreateCommand() *BuildOciCreateCommand {
+	return &BuildOciCreateCommand{}
+}
+
+func (boc *BuildOciCreateCommand) SetServerDetails(serverDetails *config.ServerDetails) *BuildOciCreateCommand {
+	boc.serverDetails = serverDetails
+	return boc
+}
+
+func (boc *BuildOciCreateCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *BuildOciCreateCommand {
+	boc.buildConfiguration = buildConfiguration
+	return boc
+}
+
+func (boc *BuildOciCreateCommand) SetLayoutDir(layoutDir string) *BuildOciCreateCommand {
+	boc.layoutDir = layoutDir
+	return boc
+}
+
+func (boc *BuildOciCreateCommand) SetRepo(repo string) *BuildOciCreateCommand {
+	boc.repo = repo
+	return boc
+}
+
+// The image name, optionally followed by the tag, in the form of <name>[:<tag>].
+func (boc *BuildOciCreateCommand) SetImage(image string) *BuildOciCreateCommand {
+	boc.image = image
+	return boc
+}
+
+func (boc *BuildOciCreateCommand) ServerDetails() (*config.ServerDetails, error) {
+	return boc.serverDetails, nil
+}
+
+func (boc *BuildOciCreateCommand) CommandName() string {
+	return "rt_build_oci_create"
+}
+
+func (boc *BuildOciCreateCommand) Run() error {
+	if boc.buildConfiguration == nil || boc.buildConfiguration.BuildName == "" || boc.buildConfiguration.BuildNumber == "" {
+		return errorutils.CheckError(errors.New("the build name and number are required"))
+	}
+	layout, err := ReadLayout(boc.layoutDir)
+	if err != nil {
+		return err
+	}
+	name, tag := ParseImage(boc.image)
+	images, err := SelectImages(layout, tag)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(boc.serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	return collectBuildInfo(servicesManager, boc.repo, name, images, boc.buildConfiguration)
+}
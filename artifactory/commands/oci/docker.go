@@ -0,0 +1,208 @@
+package oci
+
+import (
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/commands/container"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	containerutils "github.com/jfrog/jfrog-cli-core/artifactory/utils/container"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Promotes a Docker image from one repository to another. When a multi-arch image is promoted by its tag, Artifactory
+// promotes the folder of the tag, which holds the index only. The folders of the platform manifests, which the index
+// references by their digests, are therefore promoted after it, so that the whole image is promoted. If they fail to be
+// moved, the moved folders and the tag are moved back, so that the image stays whole in the source repository.
+type DockerPromoteCommand struct {
+	serverDetails *config.ServerDetails
+	params        services.DockerPromoteParams
+}
+
+func NewDockerPromoteCommand() *DockerPromoteCommand {
+	return &DockerPromoteCommand{}
+}
+
+func (dp *DockerPromoteCommand) SetServerDetails(serverDetails *config.ServerDetails) *DockerPromoteCommand {
+	dp.serverDetails = serverDetails
+	return dp
+}
+
+func (dp *DockerPromoteCommand) SetParams(params services.DockerPromoteParams) *DockerPromoteCommand {
+	dp.params = params
+	return dp
+}
+
+func (dp *DockerPromoteCommand) ServerDetails() (*config.ServerDetails, error) {
+	return dp.serverDetails, nil
+}
+
+func (dp *DockerPromoteCommand) CommandName() string {
+	return "rt_docker_promote"
+}
+
+func (dp *DockerPromoteCommand) Run() error {
+	servicesManager, err := utils.CreateServiceManager(dp.serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	// Without a tag, the entire image is promoted, including the folders of the platform manifests.
+	var image *Image
+	if dp.params.SourceTag != "" {
+		if image, err = newRegistryClient(servicesManager, dp.params.SourceRepo, dp.params.SourceDockerImage).resolveImage(dp.params.SourceTag); err != nil {
+			return err
+		}
+	}
+	if err = servicesManager.PromoteDocker(dp.params); err != nil {
+		return err
+	}
+	if image == nil || !image.IsMultiArch() {
+		return nil
+	}
+	return dp.promoteManifestFolders(servicesManager, image)
+}
+
+// Copies or moves the folders of the platform manifests and the nested indexes of the image to the target repository.
+// A folder, of which no files are promoted, fails the promotion.
+func (dp *DockerPromoteCommand) promoteManifestFolders(servicesManager artifactory.ArtifactoryServicesManager, image *Image) error {
+	targetImage := dp.getTargetImage()
+	var digests []string
+	for _, imageManifest := range image.Manifests {
+		digests = append(digests, imageManifest.Descriptor.Digest)
+	}
+	for _, index := range image.Indexes[:len(image.Indexes)-1] {
+		digests = append(digests, index.Descriptor.Digest)
+	}
+	action, promote := "Moving", servicesManager.Move
+	if dp.params.Copy {
+		action, promote = "Copying", servicesManager.Copy
+	}
+	log.Info(action, len(digests), "platform manifests of", dp.params.SourceDockerImage+":"+dp.params.SourceTag, "to", dp.params.TargetRepo)
+	var promoted []services.MoveCopyParams
+	for _, digest := range digests {
+		folder := digestToFolderName(digest)
+		params := newFolderMoveCopyParams(path.Join(dp.params.SourceRepo, dp.params.SourceDockerImage, folder), path.Join(dp.params.TargetRepo, targetImage, folder))
+		succeeded, failed, err := promote(params)
+		if err == nil && (failed > 0 || succeeded == 0) {
+			err = errorutils.CheckError(errors.New("failed to promote the platform manifest folder " + params.Pattern))
+		}
+		if err != nil {
+			if !dp.params.Copy {
+				dp.rollback(servicesManager, promoted)
+			}
+			return err
+		}
+		promoted = append(promoted, params)
+	}
+	return nil
+}
+
+// Moves the moved platform manifest folders and the tag of the image back to the source repository.
+// Failures are logged, so that the error of the promotion is returned.
+func (dp *DockerPromoteCommand) rollback(servicesManager artifactory.ArtifactoryServicesManager, movedFolders []services.MoveCopyParams) {
+	log.Warn("Moving the image back to", dp.params.SourceRepo)
+	for _, params := range movedFolders {
+		targetFolder := strings.TrimSuffix(params.Target, "/")
+		sourceFolder := strings.TrimSuffix(params.Pattern, "/*")
+		if _, failed, err := servicesManager.Move(newFolderMoveCopyParams(targetFolder, sourceFolder)); err != nil || failed > 0 {
+			log.Error("Failed to move", targetFolder, "back to", sourceFolder)
+		}
+	}
+	targetTag := dp.params.TargetTag
+	if targetTag == "" {
+		targetTag = dp.params.SourceTag
+	}
+	err := servicesManager.PromoteDocker(services.DockerPromoteParams{
+		SourceRepo:        dp.params.TargetRepo,
+		TargetRepo:        dp.params.SourceRepo,
+		SourceDockerImage: dp.getTargetImage(),
+		TargetDockerImage: dp.params.SourceDockerImage,
+		SourceTag:         targetTag,
+		TargetTag:         dp.params.SourceTag,
+	})
+	if err != nil {
+		log.Error("Failed to move", dp.getTargetImage()+":"+targetTag, "back to", dp.params.SourceRepo+":", err.Error())
+	}
+}
+
+func (dp *DockerPromoteCommand) getTargetImage() string {
+	if dp.params.TargetDockerImage != "" {
+		return dp.params.TargetDockerImage
+	}
+	return dp.params.SourceDockerImage
+}
+
+// Returns the parameters, which copy or move the files of the source folder to the target folder.
+func newFolderMoveCopyParams(sourceFolder, targetFolder string) services.MoveCopyParams {
+	params := services.NewMoveCopyParams()
+	params.Pattern = sourceFolder + "/*"
+	params.Target = targetFolder + "/"
+	params.Flat = true
+	return params
+}
+
+// Adds a Docker image, which was pushed to Artifactory by its tag and digest, to the build-info.
+// When the digest is of a multi-arch image, the index and every platform manifest are added, each as a module
+// with its own layers. Otherwise, the image is added as a single module, as by the build-docker-create command of the core.
+type BuildDockerCreateCommand struct {
+	*container.BuildDockerCreateCommand
+	imageTag       string
+	manifestDigest string
+}
+
+func NewBuildDockerCreateCommand() *BuildDockerCreateCommand {
+	return &BuildDockerCreateCommand{BuildDockerCreateCommand: container.NewBuildDockerCreateCommand()}
+}
+
+// Sets the image tag and the manifest digest from a file which includes one line in the form of <image tag>@sha256:<digest>.
+func (bdc *BuildDockerCreateCommand) SetImageNameWithDigest(filePath string) (err error) {
+	if bdc.imageTag, bdc.manifestDigest, err = containerutils.GetImageTagWithDigest(filePath); err != nil {
+		return
+	}
+	return bdc.BuildDockerCreateCommand.SetImageNameWithDigest(filePath)
+}
+
+func (bdc *BuildDockerCreateCommand) Run() error {
+	serverDetails, err := bdc.ServerDetails()
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	name, tag := ParseImage(bdc.imageTag)
+	var image *Image
+	for _, candidate := range getImageNameCandidates(name) {
+		if image, err = newRegistryClient(servicesManager, bdc.Repo(), candidate).resolveImage(bdc.manifestDigest); err != nil {
+			return err
+		}
+		if image != nil {
+			name = candidate
+			break
+		}
+	}
+	if image == nil || !image.IsMultiArch() {
+		return bdc.BuildDockerCreateCommand.Run()
+	}
+	if tag == "" {
+		tag = "latest"
+	}
+	return collectBuildInfo(servicesManager, bdc.Repo(), name, []*TaggedImage{{Image: image, Tag: tag}}, bdc.BuildConfiguration())
+}
+
+// Returns the possible names of the image in the repository. The image tag starts with the registry host, which
+// is followed by the repository name when Artifactory is accessed by the repository path method.
+func getImageNameCandidates(name string) []string {
+	parts := strings.Split(name, "/")
+	var candidates []string
+	for i := 1; i < len(parts) && i <= 2; i++ {
+		candidates = append(candidates, strings.Join(parts[i:], "/"))
+	}
+	return candidates
+}
@@ -0,0 +1,20 @@
+package oci
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetImageNameCandidates(t *testing.T) {
+	assert.Equal(t, []string{"docker-local/org/app", "org/app"}, getImageNameCandidates("acme.jfrog.io/docker-local/org/app"))
+	assert.Equal(t, []string{"app"}, getImageNameCandidates("docker-local.acme.jfrog.io/app"))
+	assert.Empty(t, getImageNameCandidates("app"))
+}
+
+func TestNewFolderMoveCopyParams(t *testing.T) {
+	params := newFolderMoveCopyParams("docker-dev/org/app/"+digestToFolderName("sha256:abc"), "docker-prod/org/app/"+digestToFolderName("sha256:abc"))
+	assert.Equal(t, "docker-dev/org/app/sha256__abc/*", params.Pattern)
+	assert.Equal(t, "docker-prod/org/app/sha256__abc/", params.Target)
+	assert.True(t, params.Flat)
+}
@@ -0,0 +1,259 @@
+package oci
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+const (
+	MediaTypeImageIndex         = "application/vnd.oci.image.index.v1+json"
+	MediaTypeImageManifest      = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeDockerList         = "application/vnd.docker.distribution.manifest.list.v2+json"
+	RefNameAnnotation           = "org.opencontainers.image.ref.name"
+	supportedImageLayoutVersion = "1.0.0"
+)
+
+type Platform struct {
+	Os           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// Returns the platform in the form of <os>/<architecture>[/<variant>].
+func (platform *Platform) String() string {
+	value := platform.Os + "/" + platform.Architecture
+	if platform.Variant != "" {
+		value += "/" + platform.Variant
+	}
+	return value
+}
+
+// A content descriptor, which references a blob by its digest.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Platform    *Platform         `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func (descriptor *Descriptor) IsIndex() bool {
+	return descriptor.MediaType == MediaTypeImageIndex || descriptor.MediaType == MediaTypeDockerList
+}
+
+func (descriptor *Descriptor) IsManifest() bool {
+	return descriptor.MediaType == MediaTypeImageManifest || descriptor.MediaType == MediaTypeDockerManifest
+}
+
+// Foreign and non-distributable layers aren't pushed to registries, so they're never expected to be found in Artifactory.
+func (descriptor *Descriptor) IsNonDistributable() bool {
+	return strings.Contains(descriptor.MediaType, "foreign") || strings.Contains(descriptor.MediaType, "nondistributable")
+}
+
+type Manifest struct {
+	MediaType string       `json:"mediaType,omitempty"`
+	Config    Descriptor   `json:"config"`
+	Layers    []Descriptor `json:"layers"`
+}
+
+type Index struct {
+	MediaType string       `json:"mediaType,omitempty"`
+	Manifests []Descriptor `json:"manifests"`
+}
+
+// An image manifest read from the layout, with the platform it was built for.
+// The platform is nil for images which aren't part of an index.
+type ImageManifest struct {
+	Descriptor Descriptor
+	Platform   *Platform
+	Content    []byte
+	Manifest   *Manifest
+}
+
+// Returns the descriptors of the config and the layers of the manifest. Layers which appear more than once are returned once.
+func (imageManifest *ImageManifest) Blobs() []Descriptor {
+	blobs := []Descriptor{imageManifest.Manifest.Config}
+	digests := map[string]bool{imageManifest.Manifest.Config.Digest: true}
+	for _, layer := range imageManifest.Manifest.Layers {
+		if !digests[layer.Digest] {
+			digests[layer.Digest] = true
+			blobs = append(blobs, layer)
+		}
+	}
+	return blobs
+}
+
+// An image index read from the layout.
+type ImageIndex struct {
+	Descriptor Descriptor
+	Content    []byte
+}
+
+// An image referenced by the index.json of the layout. A multi-arch image is an index of per-platform manifests,
+// which are resolved recursively. A single-platform image holds its own manifest only.
+type Image struct {
+	Descriptor Descriptor
+	// The indexes of a multi-arch image, with the nested indexes before the indexes which reference them.
+	// The last one is the index of the image itself.
+	Indexes   []*ImageIndex
+	Manifests []*ImageManifest
+}
+
+func (image *Image) IsMultiArch() bool {
+	return image.Descriptor.IsIndex()
+}
+
+// Returns the tag in the org.opencontainers.image.ref.name annotation, which may also hold the full reference of the image.
+func (image *Image) RefName() string {
+	return image.Descriptor.Annotations[RefNameAnnotation]
+}
+
+// An OCI image layout directory, as written by buildah, skopeo, ko, bazel rules_oci and similar tools.
+type Layout struct {
+	dir    string
+	Images []*Image
+}
+
+// Reads the oci-layout and index.json files of the layout directory, and resolves the images referenced by the index.
+func ReadLayout(dir string) (*Layout, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, "oci-layout"))
+	if err != nil {
+		return nil, errorutils.CheckError(fmt.Errorf("%s is not an OCI image layout: %s", dir, err.Error()))
+	}
+	var layoutFile struct {
+		ImageLayoutVersion string `json:"imageLayoutVersion"`
+	}
+	if err = json.Unmarshal(content, &layoutFile); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to parse the oci-layout file: " + err.Error()))
+	}
+	if layoutFile.ImageLayoutVersion != supportedImageLayoutVersion {
+		return nil, errorutils.CheckError(errors.New("unsupported OCI image layout version: " + layoutFile.ImageLayoutVersion))
+	}
+	content, err = ioutil.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var index Index
+	if err = json.Unmarshal(content, &index); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to parse index.json: " + err.Error()))
+	}
+	layout := &Layout{dir: dir}
+	for _, descriptor := range index.Manifests {
+		image, err := ResolveImage(descriptor, layout.ReadBlob)
+		if err != nil {
+			return nil, err
+		}
+		layout.Images = append(layout.Images, image)
+	}
+	return layout, nil
+}
+
+// Reads the content of the blob of a descriptor.
+type BlobReader func(descriptor Descriptor) ([]byte, error)
+
+// Returns the image of the descriptor, with the manifests and indexes it refers to, which are read by the blob reader.
+func ResolveImage(descriptor Descriptor, readBlob BlobReader) (*Image, error) {
+	image := &Image{Descriptor: descriptor}
+	return image, resolve(image, descriptor, descriptor.Platform, readBlob)
+}
+
+// Adds the manifests and indexes the descriptor refers to, either directly or through nested indexes, to the image.
+func resolve(image *Image, descriptor Descriptor, platform *Platform, readBlob BlobReader) error {
+	content, err := readBlob(descriptor)
+	if err != nil {
+		return err
+	}
+	if descriptor.IsManifest() {
+		manifest := &Manifest{}
+		if err = json.Unmarshal(content, manifest); err != nil {
+			return errorutils.CheckError(errors.New("failed to parse the manifest " + descriptor.Digest + ": " + err.Error()))
+		}
+		// The platform of an image which isn't part of an index is read from its config.
+		if platform == nil {
+			if platform, err = readConfigPlatform(manifest.Config, readBlob); err != nil {
+				return err
+			}
+		}
+		image.Manifests = append(image.Manifests, &ImageManifest{Descriptor: descriptor, Platform: platform, Content: content, Manifest: manifest})
+		return nil
+	}
+	if !descriptor.IsIndex() {
+		return errorutils.CheckError(errors.New("unsupported media type " + descriptor.MediaType + " of " + descriptor.Digest))
+	}
+	var index Index
+	if err = json.Unmarshal(content, &index); err != nil {
+		return errorutils.CheckError(errors.New("failed to parse the index " + descriptor.Digest + ": " + err.Error()))
+	}
+	for _, child := range index.Manifests {
+		childPlatform := child.Platform
+		if childPlatform == nil {
+			childPlatform = platform
+		}
+		if err = resolve(image, child, childPlatform, readBlob); err != nil {
+			return err
+		}
+	}
+	image.Indexes = append(image.Indexes, &ImageIndex{Descriptor: descriptor, Content: content})
+	return nil
+}
+
+func readConfigPlatform(config Descriptor, readBlob BlobReader) (*Platform, error) {
+	content, err := readBlob(config)
+	if err != nil {
+		return nil, err
+	}
+	platform := &Platform{}
+	if err = json.Unmarshal(content, platform); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to parse the image config " + config.Digest + ": " + err.Error()))
+	}
+	if platform.Os == "" || platform.Architecture == "" {
+		return nil, nil
+	}
+	return platform, nil
+}
+
+// Returns the path of the blob in the layout, which is blobs/<algorithm>/<encoded digest>.
+func (layout *Layout) BlobPath(digest string) (string, error) {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || strings.ContainsAny(parts[1], `/\.`) {
+		return "", errorutils.CheckError(errors.New("invalid digest: " + digest))
+	}
+	return filepath.Join(layout.dir, "blobs", parts[0], parts[1]), nil
+}
+
+// Reads the blob of the descriptor from the layout, and verifies its digest.
+func (layout *Layout) ReadBlob(descriptor Descriptor) ([]byte, error) {
+	blobPath, err := layout.BlobPath(descriptor.Digest)
+	if err != nil {
+		return nil, err
+	}
+	content, err := ioutil.ReadFile(blobPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return content, verifyDigest(descriptor.Digest, content)
+}
+
+// Verifies the digest of the content, if it's a sha256 one.
+func verifyDigest(digest string, content []byte) error {
+	if strings.HasPrefix(digest, "sha256:") {
+		if sha256Digest(content) != digest {
+			return errorutils.CheckError(errors.New("the content of blob " + digest + " doesn't match its digest"))
+		}
+	}
+	return nil
+}
+
+func sha256Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
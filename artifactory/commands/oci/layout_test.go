@@ -0,0 +1,183 @@
+package oci
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Writes the blob to the layout, and returns its descriptor.
+func writeBlob(t *testing.T, dir, mediaType string, content []byte) Descriptor {
+	sum := sha256.Sum256(content)
+	encoded := hex.EncodeToString(sum[:])
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "blobs", "sha256", encoded), content, 0644))
+	return Descriptor{MediaType: mediaType, Digest: "sha256:" + encoded, Size: int64(len(content))}
+}
+
+func writeJsonBlob(t *testing.T, dir, mediaType string, value interface{}) Descriptor {
+	content, err := json.Marshal(value)
+	assert.NoError(t, err)
+	return writeBlob(t, dir, mediaType, content)
+}
+
+// Creates a layout with a multi-arch image tagged 1.0, whose platform manifests share their base layer,
+// and a single-platform image tagged 1.0-debug.
+func createLayout(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "oci-layout")
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "oci-layout"), []byte(`{"imageLayoutVersion":"1.0.0"}`), 0644))
+
+	baseLayer := writeBlob(t, dir, "application/vnd.oci.image.layer.v1.tar+gzip", []byte("base"))
+	var platformManifests []Descriptor
+	for _, arch := range []string{"amd64", "arm64"} {
+		config := writeJsonBlob(t, dir, "application/vnd.oci.image.config.v1+json", Platform{Os: "linux", Architecture: arch})
+		layer := writeBlob(t, dir, "application/vnd.oci.image.layer.v1.tar+gzip", []byte("app-"+arch))
+		manifest := writeJsonBlob(t, dir, MediaTypeImageManifest, Manifest{Config: config, Layers: []Descriptor{baseLayer, layer, baseLayer}})
+		manifest.Platform = &Platform{Os: "linux", Architecture: arch}
+		platformManifests = append(platformManifests, manifest)
+	}
+	index := writeJsonBlob(t, dir, MediaTypeImageIndex, Index{Manifests: platformManifests})
+	index.Annotations = map[string]string{RefNameAnnotation: "1.0"}
+
+	debugConfig := writeJsonBlob(t, dir, "application/vnd.oci.image.config.v1+json", Platform{Os: "linux", Architecture: "amd64"})
+	debugManifest := writeJsonBlob(t, dir, MediaTypeImageManifest, Manifest{Config: debugConfig, Layers: []Descriptor{baseLayer}})
+	debugManifest.Annotations = map[string]string{RefNameAnnotation: "registry.example.com:5000/app:1.0-debug"}
+
+	content, err := json.Marshal(Index{Manifests: []Descriptor{index, debugManifest}})
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "index.json"), content, 0644))
+	return dir
+}
+
+func TestReadLayout(t *testing.T) {
+	dir := createLayout(t)
+	defer os.RemoveAll(dir)
+	layout, err := ReadLayout(dir)
+	assert.NoError(t, err)
+	if !assert.Len(t, layout.Images, 2) {
+		return
+	}
+
+	multiArch := layout.Images[0]
+	assert.True(t, multiArch.IsMultiArch())
+	assert.Len(t, multiArch.Indexes, 1)
+	if assert.Len(t, multiArch.Manifests, 2) {
+		assert.Equal(t, "linux/arm64", multiArch.Manifests[1].Platform.String())
+		// The base layer is referenced twice, but is returned once.
+		assert.Len(t, multiArch.Manifests[0].Blobs(), 3)
+	}
+
+	single := layout.Images[1]
+	assert.False(t, single.IsMultiArch())
+	if assert.Len(t, single.Manifests, 1) {
+		// The platform of a single-platform image is read from its config.
+		assert.Equal(t, "linux/amd64", single.Manifests[0].Platform.String())
+	}
+}
+
+func TestReadLayoutDigestMismatch(t *testing.T) {
+	dir := createLayout(t)
+	defer os.RemoveAll(dir)
+	layout, err := ReadLayout(dir)
+	assert.NoError(t, err)
+	blobPath, err := layout.BlobPath(layout.Images[1].Descriptor.Digest)
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(blobPath, []byte("{}"), 0644))
+	_, err = ReadLayout(dir)
+	assert.Error(t, err)
+
+	_, err = layout.BlobPath("sha256:../../etc")
+	assert.Error(t, err)
+}
+
+func TestSelectImages(t *testing.T) {
+	dir := createLayout(t)
+	defer os.RemoveAll(dir)
+	layout, err := ReadLayout(dir)
+	assert.NoError(t, err)
+
+	images, err := SelectImages(layout, "")
+	assert.NoError(t, err)
+	if assert.Len(t, images, 2) {
+		assert.Equal(t, "1.0", images[0].Tag)
+		assert.Equal(t, "1.0-debug", images[1].Tag)
+	}
+
+	images, err = SelectImages(layout, "1.0-debug")
+	assert.NoError(t, err)
+	if assert.Len(t, images, 1) {
+		assert.False(t, images[0].IsMultiArch())
+	}
+
+	_, err = SelectImages(layout, "2.0")
+	assert.Error(t, err)
+}
+
+func TestGetImageFolders(t *testing.T) {
+	dir := createLayout(t)
+	defer os.RemoveAll(dir)
+	layout, err := ReadLayout(dir)
+	assert.NoError(t, err)
+
+	folders := GetImageFolders(layout.Images[0], "org/app", "1.0")
+	if assert.Len(t, folders, 3) {
+		assert.Equal(t, "org/app/1.0", folders[0].Path)
+		assert.Equal(t, []ImageFile{{Name: "list.manifest.json", Descriptor: layout.Images[0].Descriptor}}, folders[0].Files)
+		assert.Nil(t, folders[0].Platform)
+		manifest := layout.Images[0].Manifests[0]
+		assert.Equal(t, "org/app/sha256__"+strings.TrimPrefix(manifest.Descriptor.Digest, "sha256:"), folders[1].Path)
+		assert.Equal(t, manifest.Manifest.Config.Digest, folders[1].ConfigDigest)
+		var names []string
+		for _, file := range folders[1].Files {
+			names = append(names, file.Name)
+		}
+		assert.Equal(t, []string{"manifest.json", digestToFileName(manifest.Manifest.Config.Digest),
+			digestToFileName(manifest.Manifest.Layers[0].Digest), digestToFileName(manifest.Manifest.Layers[1].Digest)}, names)
+	}
+
+	folders = GetImageFolders(layout.Images[1], "org/app", "1.0-debug")
+	if assert.Len(t, folders, 1) {
+		assert.Equal(t, "org/app/1.0-debug", folders[0].Path)
+		assert.Len(t, folders[0].Files, 3)
+	}
+}
+
+func TestParseImage(t *testing.T) {
+	for image, expected := range map[string][2]string{
+		"app":                          {"app", ""},
+		"org/app:1.0":                  {"org/app", "1.0"},
+		"localhost:5000/org/app":       {"localhost:5000/org/app", ""},
+		"localhost:5000/org/app:1.0.1": {"localhost:5000/org/app", "1.0.1"},
+	} {
+		name, tag := ParseImage(image)
+		assert.Equal(t, expected, [2]string{name, tag}, image)
+	}
+}
+
+func TestResolveImage(t *testing.T) {
+	dir := createLayout(t)
+	defer os.RemoveAll(dir)
+	layout, err := ReadLayout(dir)
+	assert.NoError(t, err)
+
+	// Resolve the multi-arch image by the descriptor of its index alone, as when it's read from a registry.
+	var read []string
+	descriptor := Descriptor{MediaType: MediaTypeImageIndex, Digest: layout.Images[0].Descriptor.Digest}
+	image, err := ResolveImage(descriptor, func(blob Descriptor) ([]byte, error) {
+		read = append(read, blob.Digest)
+		return layout.ReadBlob(blob)
+	})
+	assert.NoError(t, err)
+	assert.True(t, image.IsMultiArch())
+	assert.Len(t, image.Manifests, 2)
+	// The configs aren't read, since the index holds the platforms of its manifests.
+	assert.Len(t, read, 3)
+}
@@ -0,0 +1,181 @@
+package oci
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Pushes the images of an OCI image layout to an Artifactory Docker repository through the registry API,
+// without a Docker daemon. If the build name and number are set, every manifest and blob of the pushed images
+// is recorded in the build-info.
+type OciPushCommand struct {
+	serverDetails      *config.ServerDetails
+	buildConfiguration *utils.BuildConfiguration
+	layoutDir          string
+	repo               string
+	image              string
+}
+
+func NewOciPushCommand() *OciPushCommand {
+	return &OciPushCommand{}
+}
+
+func (opc *OciPushCommand) SetServerDetails(serverDetails *config.ServerDetails) *OciPushCommand {
+	opc.serverDetails = serverDetails
+	return opc
+}
+
+func (opc *OciPushCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *OciPushCommand {
+	opc.buildConfiguration = buildConfiguration
+	return opc
+}
+
+func (opc *OciPushCommand) SetLayoutDir(layoutDir string) *OciPushCommand {
+	opc.layoutDir = layoutDir
+	return opc
+}
+
+func (opc *OciPushCommand) SetRepo(repo string) *OciPushCommand {
+	opc.repo = repo
+	return opc
+}
+
+// The image name, optionally followed by the tag, in the form of <name>[:<tag>].
+func (opc *OciPushCommand) SetImage(image string) *OciPushCommand {
+	opc.image = image
+	return opc
+}
+
+func (opc *OciPushCommand) ServerDetails() (*config.ServerDetails, error) {
+	return opc.serverDetails, nil
+}
+
+func (opc *OciPushCommand) CommandName() string {
+	return "rt_oci_push"
+}
+
+func (opc *OciPushCommand) Run() error {
+	layout, err := ReadLayout(opc.layoutDir)
+	if err != nil {
+		return err
+	}
+	name, tag := ParseImage(opc.image)
+	images, err := SelectImages(layout, tag)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(opc.serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	client := newRegistryClient(servicesManager, opc.repo, name)
+	for _, image := range images {
+		log.Info("Pushing image " + name + ":" + image.Tag + " to " + opc.repo)
+		if err = pushImage(client, layout, image); err != nil {
+			return err
+		}
+	}
+	if opc.buildConfiguration == nil || opc.buildConfiguration.BuildName == "" || opc.buildConfiguration.BuildNumber == "" {
+		return nil
+	}
+	return collectBuildInfo(servicesManager, opc.repo, name, images, opc.buildConfiguration)
+}
+
+// Pushes the blobs of every manifest, then the platform manifests and nested indexes by their digests,
+// and finally the manifest or the index of the image by its tag.
+func pushImage(client *registryClient, layout *Layout, image *TaggedImage) error {
+	for _, imageManifest := range image.Manifests {
+		for _, blob := range imageManifest.Blobs() {
+			if blob.IsNonDistributable() {
+				continue
+			}
+			blobPath, err := layout.BlobPath(blob.Digest)
+			if err != nil {
+				return err
+			}
+			if err = client.pushBlob(blobPath, blob.Digest); err != nil {
+				return err
+			}
+		}
+		if image.IsMultiArch() {
+			if err := client.pushManifest(imageManifest.Content, imageManifest.Descriptor.MediaType, imageManifest.Descriptor.Digest); err != nil {
+				return err
+			}
+		}
+	}
+	if !image.IsMultiArch() {
+		return client.pushManifest(image.Manifests[0].Content, image.Descriptor.MediaType, image.Tag)
+	}
+	nestedIndexes, index := image.Indexes[:len(image.Indexes)-1], image.Indexes[len(image.Indexes)-1]
+	for _, nestedIndex := range nestedIndexes {
+		if err := client.pushManifest(nestedIndex.Content, nestedIndex.Descriptor.MediaType, nestedIndex.Descriptor.Digest); err != nil {
+			return err
+		}
+	}
+	return client.pushManifest(index.Content, index.Descriptor.MediaType, image.Tag)
+}
+
+// An image of the layout and the tag it is pushed with.
+type TaggedImage struct {
+	*Image
+	Tag string
+}
+
+// Returns the images of the layout which are tagged with the tag, according to their org.opencontainers.image.ref.name
+// annotations. If the tag is empty, all of the images are returned, each with the tag of its annotation.
+// A layout which holds a single image, with no annotation, can be pushed with any tag.
+func SelectImages(layout *Layout, tag string) ([]*TaggedImage, error) {
+	if len(layout.Images) == 0 {
+		return nil, errorutils.CheckError(errors.New("the index.json of the OCI image layout references no images"))
+	}
+	var images []*TaggedImage
+	for _, image := range layout.Images {
+		_, refTag := ParseImage(image.RefName())
+		if refTag == "" {
+			refTag = image.RefName()
+		}
+		if tag == "" && refTag == "" {
+			return nil, errorutils.CheckError(errors.New("image " + image.Descriptor.Digest + " of the OCI image layout has no " + RefNameAnnotation + " annotation. Pass the tag to push it with in the form of <name>:<tag>"))
+		}
+		if tag == "" || refTag == tag {
+			images = append(images, &TaggedImage{Image: image, Tag: refTag})
+		}
+	}
+	if len(images) == 0 && len(layout.Images) == 1 && layout.Images[0].RefName() == "" {
+		images = append(images, &TaggedImage{Image: layout.Images[0], Tag: tag})
+	}
+	if len(images) == 0 {
+		return nil, errorutils.CheckError(errors.New("no image tagged " + tag + " was found in the OCI image layout"))
+	}
+	return images, nil
+}
+
+// Records the pushed images in the build-info, and sets the build properties on their files in Artifactory.
+func collectBuildInfo(servicesManager artifactory.ArtifactoryServicesManager, repo, name string, images []*TaggedImage, buildConfiguration *utils.BuildConfiguration) error {
+	for _, image := range images {
+		imageTag := name + ":" + image.Tag
+		moduleId := buildConfiguration.Module
+		if moduleId == "" {
+			moduleId = imageTag
+		}
+		modules, items, err := CollectModules(servicesManager, repo, GetImageFolders(image.Image, name, image.Tag), imageTag, moduleId)
+		if err != nil {
+			return err
+		}
+		if err = SetBuildProperties(servicesManager, items, buildConfiguration); err != nil {
+			return err
+		}
+		log.Info("Adding", strconv.Itoa(len(items)), "artifacts of image", imageTag, "to modules", strings.Join(moduleIds(modules), ", "), "of the build-info.")
+		if err = SaveModules(buildConfiguration, modules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
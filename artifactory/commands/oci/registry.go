@@ -0,0 +1,191 @@
+package oci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/httputils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Pushes blobs and manifests to an Artifactory Docker repository, through the Docker Registry HTTP API V2 of the repository.
+type registryClient struct {
+	servicesManager artifactory.ArtifactoryServicesManager
+	// The URL of the image in the registry API, in the form of <artifactory url>/api/docker/<repo>/v2/<image>/.
+	imageUrl string
+}
+
+func newRegistryClient(servicesManager artifactory.ArtifactoryServicesManager, repo, imageName string) *registryClient {
+	artifactoryUrl := servicesManager.GetConfig().GetServiceDetails().GetUrl()
+	return &registryClient{servicesManager: servicesManager, imageUrl: artifactoryUrl + "api/docker/" + repo + "/v2/" + imageName + "/"}
+}
+
+func (rc *registryClient) httpClientDetails() *httputils.HttpClientDetails {
+	details := rc.servicesManager.GetConfig().GetServiceDetails().CreateHttpClientDetails()
+	return &details
+}
+
+func (rc *registryClient) blobExists(digest string) (bool, error) {
+	resp, _, err := rc.servicesManager.Client().SendHead(rc.imageUrl+"blobs/"+digest, rc.httpClientDetails())
+	if err != nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	}
+	return false, errorutils.CheckError(errors.New("failed to check the existence of blob " + digest + ": " + resp.Status))
+}
+
+// Uploads the blob in a monolithic upload, unless the registry already has it.
+func (rc *registryClient) pushBlob(blobPath, digest string) error {
+	exists, err := rc.blobExists(digest)
+	if err != nil || exists {
+		if exists {
+			log.Debug("Blob " + digest + " already exists.")
+		}
+		return err
+	}
+	resp, body, err := rc.servicesManager.Client().SendPost(rc.imageUrl+"blobs/uploads/", nil, rc.httpClientDetails())
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		return responseError("failed to start the upload of blob "+digest, resp, body)
+	}
+	uploadUrl, err := rc.resolveLocation(resp.Header.Get("Location"))
+	if err != nil {
+		return err
+	}
+	separator := "?"
+	if strings.Contains(uploadUrl, "?") {
+		separator = "&"
+	}
+	log.Info("Uploading blob " + digest)
+	resp, body, err = rc.servicesManager.Client().UploadFile(blobPath, uploadUrl+separator+"digest="+url.QueryEscape(digest), "", rc.httpClientDetails(), nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return responseError("failed to upload blob "+digest, resp, body)
+	}
+	return nil
+}
+
+// The Location header of the upload may be relative to the registry host.
+func (rc *registryClient) resolveLocation(location string) (string, error) {
+	if location == "" {
+		return "", errorutils.CheckError(errors.New("the registry didn't return the location of the blob upload"))
+	}
+	base, err := url.Parse(rc.imageUrl)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	locationUrl, err := base.Parse(location)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return locationUrl.String(), nil
+}
+
+// Uploads a manifest or an index, by its digest or by a tag.
+func (rc *registryClient) pushManifest(content []byte, mediaType, reference string) error {
+	details := rc.httpClientDetails()
+	if details.Headers == nil {
+		details.Headers = make(map[string]string)
+	}
+	details.Headers["Content-Type"] = mediaType
+	log.Info("Uploading manifest " + reference)
+	resp, body, err := rc.servicesManager.Client().SendPut(rc.imageUrl+"manifests/"+reference, content, details)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return responseError("failed to upload manifest "+reference, resp, body)
+	}
+	return nil
+}
+
+// Returns the manifest or the index of the reference, which is either a tag or a digest, and its descriptor.
+// If the registry has no such reference, a nil descriptor is returned.
+func (rc *registryClient) getManifest(reference string) (*Descriptor, []byte, error) {
+	details := rc.httpClientDetails()
+	if details.Headers == nil {
+		details.Headers = make(map[string]string)
+	}
+	details.Headers["Accept"] = strings.Join([]string{MediaTypeImageIndex, MediaTypeImageManifest, MediaTypeDockerList, MediaTypeDockerManifest}, ",")
+	resp, body, _, err := rc.servicesManager.Client().SendGet(rc.imageUrl+"manifests/"+reference, true, details)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, responseError("failed to get manifest "+reference, resp, body)
+	}
+	descriptor := &Descriptor{MediaType: resp.Header.Get("Content-Type"), Digest: resp.Header.Get("Docker-Content-Digest"), Size: int64(len(body))}
+	if descriptor.Digest == "" {
+		descriptor.Digest = sha256Digest(body)
+	}
+	if err = verifyDigest(descriptor.Digest, body); err != nil {
+		return nil, nil, err
+	}
+	// The media type of the content takes precedence over the Content-Type header.
+	var mediaType struct {
+		MediaType string `json:"mediaType"`
+	}
+	if json.Unmarshal(body, &mediaType) == nil && mediaType.MediaType != "" {
+		descriptor.MediaType = mediaType.MediaType
+	}
+	return descriptor, body, nil
+}
+
+// Reads manifests and indexes through the manifests API, and other blobs through the blobs API.
+func (rc *registryClient) readBlob(descriptor Descriptor) ([]byte, error) {
+	if descriptor.IsManifest() || descriptor.IsIndex() {
+		found, content, err := rc.getManifest(descriptor.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if found == nil {
+			return nil, errorutils.CheckError(errors.New("manifest " + descriptor.Digest + " was not found"))
+		}
+		return content, nil
+	}
+	resp, body, _, err := rc.servicesManager.Client().SendGet(rc.imageUrl+"blobs/"+descriptor.Digest, true, rc.httpClientDetails())
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError("failed to get blob "+descriptor.Digest, resp, body)
+	}
+	return body, verifyDigest(descriptor.Digest, body)
+}
+
+// Returns the image of the reference, with all of its platform manifests if it's a multi-arch image.
+// If the registry has no such reference, nil is returned.
+func (rc *registryClient) resolveImage(reference string) (*Image, error) {
+	descriptor, content, err := rc.getManifest(reference)
+	if err != nil || descriptor == nil {
+		return nil, err
+	}
+	return ResolveImage(*descriptor, func(blob Descriptor) ([]byte, error) {
+		if blob.Digest == descriptor.Digest {
+			return content, nil
+		}
+		return rc.readBlob(blob)
+	})
+}
+
+func responseError(message string, resp *http.Response, body []byte) error {
+	return errorutils.CheckError(fmt.Errorf("%s: %s %s", message, resp.Status, string(body)))
+}
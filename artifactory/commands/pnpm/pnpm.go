@@ -0,0 +1,279 @@
+package pnpm
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/npmlock"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The pnpm commands, which resolve the dependencies and update pnpm-lock.yaml.
+var installCommands = map[string]bool{
+	"install": true, "i": true, "add": true, "update": true, "up": true, "upgrade": true,
+	"remove": true, "rm": true, "uninstall": true, "un": true, "dedupe": true,
+}
+
+// Runs pnpm with the dependencies resolved from an Artifactory npm repository. The registry is passed to pnpm through
+// environment variables and a temporary user config file, so the npm configuration of the user is never changed.
+// 'pnpm publish' is handled by the CLI, which packs the package with 'pnpm pack' and deploys it to the deployment repository.
+// 'pnpm publish' supports the options listed in publishOptions only.
+// If the build name and number are passed, the packages locked in pnpm-lock.yaml are recorded as the build-info dependencies,
+// with a module for every project of the workspace, and the published packages as the build-info artifacts.
+type PnpmCommand struct {
+	resolverDetails *config.ServerDetails
+	resolverRepo    string
+	deployerDetails *config.ServerDetails
+	deployerRepo    string
+	args            []string
+}
+
+func NewPnpmCommand() *PnpmCommand {
+	return &PnpmCommand{}
+}
+
+func (pc *PnpmCommand) SetResolver(serverDetails *config.ServerDetails, repo string) *PnpmCommand {
+	pc.resolverDetails, pc.resolverRepo = serverDetails, repo
+	return pc
+}
+
+func (pc *PnpmCommand) SetDeployer(serverDetails *config.ServerDetails, repo string) *PnpmCommand {
+	pc.deployerDetails, pc.deployerRepo = serverDetails, repo
+	return pc
+}
+
+func (pc *PnpmCommand) SetArgs(args []string) *PnpmCommand {
+	pc.args = args
+	return pc
+}
+
+func (pc *PnpmCommand) ServerDetails() (*config.ServerDetails, error) {
+	if pc.resolverDetails != nil {
+		return pc.resolverDetails, nil
+	}
+	return pc.deployerDetails, nil
+}
+
+func (pc *PnpmCommand) CommandName() string {
+	return "rt_pnpm"
+}
+
+func (pc *PnpmCommand) Run() error {
+	args, buildConfiguration, err := utils.ExtractBuildDetailsFromArgs(pc.args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errorutils.CheckError(errors.New("a pnpm command is required"))
+	}
+	collectBuildInfo := buildConfiguration.BuildName != "" && buildConfiguration.BuildNumber != ""
+	command := getPnpmCommand(args)
+	if command == "publish" {
+		return pc.publish(args, buildConfiguration, collectBuildInfo)
+	}
+	if err = pc.runPnpm(args, ""); err != nil {
+		return err
+	}
+	if !collectBuildInfo {
+		return nil
+	}
+	if !installCommands[command] {
+		log.Debug("No build-info is collected for 'pnpm " + command + "'.")
+		return nil
+	}
+	return pc.collectDependencies(buildConfiguration)
+}
+
+// Returns the pnpm command, which is the first argument that isn't an option or the value of an option.
+func getPnpmCommand(args []string) string {
+	valueOptions := map[string]bool{"--filter": true, "-F": true, "--dir": true, "-C": true, "--workspace-dir": true, "--reporter": true, "--loglevel": true}
+	for i := 0; i < len(args); i++ {
+		switch {
+		case valueOptions[args[i]]:
+			i++
+		case !strings.HasPrefix(args[i], "-"):
+			return args[i]
+		}
+	}
+	return ""
+}
+
+func (pc *PnpmCommand) collectDependencies(buildConfiguration *utils.BuildConfiguration) error {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	exists, err := fileutils.IsFileExists(filepath.Join(projectDir, npmlock.PnpmLockFileName), false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Info("No", npmlock.PnpmLockFileName, "file was found. No dependencies are added to the build-info.")
+		return nil
+	}
+	workspaces, err := npmlock.ReadPnpmLock(projectDir)
+	if err != nil {
+		return err
+	}
+	return npmlock.SaveWorkspaceModules(pc.resolverDetails, pc.resolverRepo, 0, projectDir, workspaces, buildConfiguration)
+}
+
+// Runs pnpm in the directory, or in the working directory if the directory is empty.
+func (pc *PnpmCommand) runPnpm(args []string, dir string) error {
+	pnpmPath, err := exec.LookPath("pnpm")
+	if err != nil {
+		return errorutils.CheckError(errors.New("could not find the 'pnpm' executable in the system PATH"))
+	}
+	cmd := exec.Command(pnpmPath, args...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	if pc.resolverDetails != nil {
+		configEnv, err := pc.createNpmConfigEnv(pnpmPath, dir)
+		if err != nil {
+			return err
+		}
+		cmd.Env = append(cmd.Env, configEnv...)
+	}
+	log.Info("Running pnpm " + strings.Join(args, " "))
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
+
+// Returns the npm_config_* environment variables, which set the resolution registry and its credentials, as in
+// 'jfrog rt npm-ci'. pnpm gives its environment precedence over the .npmrc files, so the rest of their configuration is kept.
+func (pc *PnpmCommand) createNpmConfigEnv(pnpmPath, dir string) ([]string, error) {
+	registry := pc.resolverDetails.GetArtifactoryUrl() + "api/npm/" + pc.resolverRepo + "/"
+	registryUrl, err := url.Parse(registry)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	// The credentials are scoped to the registry, in the form of //<host>/<path>/:<key>=<value>.
+	authPrefix := "//" + registryUrl.Host + registryUrl.Path + ":"
+	var authLine string
+	if pc.resolverDetails.GetAccessToken() != "" {
+		authLine = authPrefix + "_authToken=" + pc.resolverDetails.GetAccessToken()
+	} else {
+		username, password, err := projectutils.GetBasicAuthCredentials(pc.resolverDetails)
+		if err != nil {
+			return nil, err
+		}
+		authLine = authPrefix + "_auth=" + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	}
+	// The scoped registries of the configuration are set to the resolution registry too.
+	cmd := exec.Command(pnpmPath, "config", "list")
+	cmd.Dir = dir
+	configList, err := cmd.Output()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return npmlock.CreateNpmConfigEnv(string(configList), registry, authLine), nil
+}
+
+// The options of 'pnpm publish', which are supported. The git checks of pnpm aren't run by the CLI, so --no-git-checks has no effect.
+var publishOptions = map[string]bool{"-r": true, "--recursive": true, "--dry-run": true, "--no-git-checks": true}
+
+// Packs the package in the working directory, or every public package of the workspace when published with the -r option,
+// and deploys the tarballs to the deployment repository in the <name>/-/<name>-<version>.tgz layout of npm repositories.
+// The workspace root is published with the -r option only if it matches the packages of pnpm-workspace.yaml.
+// With the --dry-run option, the packages are packed, but aren't deployed.
+func (pc *PnpmCommand) publish(args []string, buildConfiguration *utils.BuildConfiguration, collectBuildInfo bool) error {
+	if pc.deployerDetails == nil {
+		return errorutils.CheckError(errors.New("no deployment repository is configured. Run 'jfrog rt pnpm-config' with the deployer options"))
+	}
+	if err := validatePublishArgs(args); err != nil {
+		return err
+	}
+	dryRun := projectutils.HasOption(args, "--dry-run")
+	dirs := []string{"."}
+	if projectutils.HasOption(args, "-r", "--recursive") {
+		var err error
+		if dirs, err = FindWorkspacePackages("."); err != nil {
+			return err
+		}
+	}
+	for _, dir := range dirs {
+		packageInfo, err := commandUtils.ReadPackageInfoFromPackageJson(dir)
+		if err != nil {
+			return err
+		}
+		isPrivate, err := isPrivatePackage(dir)
+		if err != nil {
+			return err
+		}
+		if isPrivate {
+			log.Info("Skipping the private package " + packageInfo.FullName() + ".")
+			continue
+		}
+		artifacts, err := pc.packAndDeploy(dir, packageInfo, buildConfiguration, dryRun)
+		if err != nil {
+			return err
+		}
+		if !collectBuildInfo || dryRun {
+			continue
+		}
+		moduleId := packageInfo.BuildInfoModuleId()
+		if buildConfiguration.Module != "" && len(dirs) == 1 {
+			moduleId = buildConfiguration.Module
+		}
+		if err = projectutils.SaveArtifacts(buildConfiguration, buildinfo.Npm, moduleId, artifacts); err != nil {
+			return err
+		}
+	}
+	log.Info("pnpm publish finished successfully.")
+	return nil
+}
+
+// Returns an error if the arguments of 'pnpm publish' include options, which aren't supported, or the package to publish.
+func validatePublishArgs(args []string) error {
+	commandFound := false
+	for _, arg := range args {
+		if arg == "publish" && !commandFound {
+			commandFound = true
+			continue
+		}
+		option := strings.SplitN(arg, "=", 2)[0]
+		if !publishOptions[option] {
+			return errorutils.CheckError(errors.New("'" + arg + "' isn't supported by 'jfrog rt pnpm publish', which supports the -r, --recursive, --dry-run and --no-git-checks options only"))
+		}
+	}
+	return nil
+}
+
+func (pc *PnpmCommand) packAndDeploy(dir string, packageInfo *commandUtils.PackageInfo, buildConfiguration *utils.BuildConfiguration, dryRun bool) ([]buildinfo.Artifact, error) {
+	packDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return nil, err
+	}
+	defer fileutils.RemoveTempDir(packDir)
+	if err = pc.runPnpm([]string{"pack", "--pack-destination", packDir}, dir); err != nil {
+		return nil, err
+	}
+	files, err := fileutils.ListFiles(packDir, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) != 1 || filepath.Ext(files[0]) != ".tgz" {
+		return nil, errorutils.CheckError(errors.New("'pnpm pack' didn't create the tarball of " + packageInfo.FullName()))
+	}
+	if dryRun {
+		log.Info("[Dry run] Would publish", packageInfo.FullName()+"@"+packageInfo.Version, "to", pc.deployerRepo+"/"+packageInfo.GetDeployPath())
+		return nil, nil
+	}
+	log.Info("Publishing", packageInfo.FullName()+"@"+packageInfo.Version, "to", pc.deployerRepo+"...")
+	return projectutils.DeployFiles(pc.deployerDetails, []projectutils.DeployableFile{{
+		LocalPath:  files[0],
+		TargetPath: pc.deployerRepo + "/" + packageInfo.GetDeployPath(),
+	}}, buildConfiguration)
+}
@@ -0,0 +1,53 @@
+package pnpm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindWorkspacePackages(t *testing.T) {
+	workspaceDir := filepath.Join("testdata", "workspace")
+	dirs, err := FindWorkspacePackages(workspaceDir)
+	assert.NoError(t, err)
+	// The node_modules directories are skipped.
+	assert.Equal(t, []string{"packages/app", "tools/cli", "tools/cli/nested", "tools/cli/nested/a/b/c/deep"}, dirs)
+
+	isPrivate, err := isPrivatePackage(workspaceDir)
+	assert.NoError(t, err)
+	assert.True(t, isPrivate)
+}
+
+func TestValidatePublishArgs(t *testing.T) {
+	assert.NoError(t, validatePublishArgs([]string{"-r", "publish", "--dry-run", "--no-git-checks"}))
+	assert.NoError(t, validatePublishArgs([]string{"publish", "--recursive"}))
+	for _, args := range [][]string{
+		{"publish", "--filter", "app"},
+		{"publish", "--tag=next"},
+		{"publish", "--access", "public"},
+		{"publish", "packages/app"},
+	} {
+		assert.Error(t, validatePublishArgs(args), args)
+	}
+}
+
+func TestGetPnpmCommand(t *testing.T) {
+	for expected, args := range map[string][]string{
+		"install": {"install", "--frozen-lockfile"},
+		"add":     {"--filter", "app", "add", "lodash"},
+		"publish": {"-r", "publish", "--no-git-checks"},
+		"":        {"--version"},
+	} {
+		assert.Equal(t, expected, getPnpmCommand(args), args)
+	}
+}
+
+func TestMatchSegments(t *testing.T) {
+	assert.True(t, matchSegments(splitPath("packages/**/lib"), splitPath("packages/lib")))
+	assert.True(t, matchSegments(splitPath("packages/**/lib"), splitPath("packages/a/b/lib")))
+	assert.False(t, matchSegments(splitPath("packages/**/lib"), splitPath("packages/a/b")))
+	assert.True(t, matchSegments(splitPath("**"), splitPath(".")))
+	assert.True(t, matchSegments(splitPath("."), splitPath(".")))
+	assert.False(t, matchSegments(splitPath("packages/*"), splitPath("packages/a/b")))
+}
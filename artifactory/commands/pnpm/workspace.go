@@ -0,0 +1,149 @@
+package pnpm
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"gopkg.in/yaml.v2"
+)
+
+const workspaceFileName = "pnpm-workspace.yaml"
+
+// Returns the directories of the packages of the workspace, relative to the workspace directory, by expanding the patterns
+// listed in pnpm-workspace.yaml. Patterns, which start with '!', exclude the matching directories. The root package is
+// included only if a pattern matches it, as 'pnpm publish -r' does.
+func FindWorkspacePackages(workspaceDir string) ([]string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(workspaceDir, workspaceFileName))
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var workspace struct {
+		Packages []string `yaml:"packages"`
+	}
+	if err = yaml.Unmarshal(content, &workspace); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	included := make(map[string]bool)
+	var excludes []string
+	for _, pattern := range workspace.Packages {
+		if strings.HasPrefix(pattern, "!") {
+			excludes = append(excludes, strings.TrimPrefix(pattern, "!"))
+			continue
+		}
+		dirs, err := globPackages(workspaceDir, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			included[dir] = true
+		}
+	}
+	for _, pattern := range excludes {
+		dirs, err := globPackages(workspaceDir, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			delete(included, dir)
+		}
+	}
+	var dirs []string
+	if included["."] {
+		dirs = append(dirs, ".")
+	}
+	var packageDirs []string
+	for dir := range included {
+		if dir != "." {
+			packageDirs = append(packageDirs, dir)
+		}
+	}
+	sort.Strings(packageDirs)
+	return append(dirs, packageDirs...), nil
+}
+
+// Returns the directories matching the pattern, which include package.json. A '**' segment of the pattern matches
+// any number of directories. The node_modules directories are skipped, as pnpm does.
+func globPackages(workspaceDir, pattern string) ([]string, error) {
+	patternSegments := splitPath(path.Clean(pattern))
+	for _, segment := range patternSegments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, errorutils.CheckError(errors.New("the pattern " + pattern + " of " + workspaceFileName + " is invalid: " + err.Error()))
+		}
+	}
+	var dirs []string
+	err := filepath.Walk(workspaceDir, func(walkedPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if info.Name() == "node_modules" {
+			return filepath.SkipDir
+		}
+		dir, err := filepath.Rel(workspaceDir, walkedPath)
+		if err != nil {
+			return err
+		}
+		dir = filepath.ToSlash(dir)
+		if !matchSegments(patternSegments, splitPath(dir)) {
+			return nil
+		}
+		exists, err := fileutils.IsFileExists(filepath.Join(walkedPath, "package.json"), false)
+		if exists {
+			dirs = append(dirs, dir)
+		}
+		return err
+	})
+	return dirs, errorutils.CheckError(err)
+}
+
+func splitPath(slashPath string) []string {
+	if slashPath == "." {
+		return nil
+	}
+	return strings.Split(slashPath, "/")
+}
+
+// Returns true if the path segments match the pattern segments, where a '**' segment matches any number of segments.
+func matchSegments(patternSegments, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+	if patternSegments[0] == "**" {
+		for i := 0; i <= len(pathSegments); i++ {
+			if matchSegments(patternSegments[1:], pathSegments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(pathSegments) == 0 {
+		return false
+	}
+	matched, _ := path.Match(patternSegments[0], pathSegments[0])
+	return matched && matchSegments(patternSegments[1:], pathSegments[1:])
+}
+
+// Returns true if the package.json in the directory is marked as private, so the package must not be published.
+func isPrivatePackage(dir string) (bool, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	var packageJson struct {
+		Private bool `json:"private"`
+	}
+	if err = json.Unmarshal(content, &packageJson); err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	return packageJson.Private, nil
+}
@@ -0,0 +1,229 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/common/commands"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The option of the native commands, which sets the policy file.
+const PolicyFlag = "--policy"
+
+// The name of the build, which collects the dependencies if the command has no build.
+const tempBuildName = "jfrog-cli-policy"
+
+// A dependency of the build-info, with the type of its module.
+type ResolvedDependency struct {
+	buildinfo.Dependency
+	ModuleType buildinfo.ModuleType
+}
+
+// Checks the dependencies of a command against a policy file before the command runs. The dependencies are first resolved
+// by a resolve command, which downloads them without building the project or running their install scripts, and collects
+// them to a temporary build, which is removed afterwards. If any of the dependencies violates the policy, the command
+// fails with a report of all the violations, and the command itself doesn't run.
+type PolicyCommand struct {
+	commands.Command
+	resolveCommand commands.Command
+	policyPath     string
+	tempBuild      *utils.BuildConfiguration
+}
+
+func NewPolicyCommand(command commands.Command) *PolicyCommand {
+	return &PolicyCommand{Command: command, tempBuild: &utils.BuildConfiguration{
+		BuildName: tempBuildName, BuildNumber: strconv.FormatInt(time.Now().UnixNano(), 10)}}
+}
+
+func (pc *PolicyCommand) SetPolicyPath(policyPath string) *PolicyCommand {
+	pc.policyPath = policyPath
+	return pc
+}
+
+// Sets the command, which resolves the dependencies of the command and collects them to the temporary build.
+func (pc *PolicyCommand) SetResolveCommand(resolveCommand commands.Command) *PolicyCommand {
+	pc.resolveCommand = resolveCommand
+	return pc
+}
+
+// Returns the build configuration of the temporary build, to which the resolve command collects the dependencies.
+func (pc *PolicyCommand) TempBuildConfiguration() *utils.BuildConfiguration {
+	return pc.tempBuild
+}
+
+// Returns the args of a resolve command, which reads its build configuration from its args. The build options of the args
+// are replaced by the temporary build, so that the build of the command isn't changed by the resolve command.
+func (pc *PolicyCommand) ResolveArgs(args []string) ([]string, error) {
+	resolveArgs, _, err := utils.ExtractBuildDetailsFromArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return append(resolveArgs, "--build-name="+pc.tempBuild.BuildName, "--build-number="+pc.tempBuild.BuildNumber), nil
+}
+
+func (pc *PolicyCommand) Run() error {
+	policy, err := ReadPolicy(pc.policyPath)
+	if err != nil {
+		return err
+	}
+	if pc.resolveCommand == nil {
+		return errorutils.CheckError(errors.New("the dependencies of the " + pc.Command.CommandName() + " command can't be checked against a policy"))
+	}
+	defer func() {
+		if e := utils.RemoveBuildDir(pc.tempBuild.BuildName, pc.tempBuild.BuildNumber, pc.tempBuild.Project); e != nil {
+			log.Warn("Failed to remove the temporary build of the policy check: " + e.Error())
+		}
+	}()
+	log.Info("Resolving the dependencies of the command, to check them against the policy " + pc.policyPath + "...")
+	if err = pc.resolveCommand.Run(); err != nil {
+		return err
+	}
+	dependencies, err := readDependencies(pc.tempBuild)
+	if err != nil {
+		return err
+	}
+	licenses, err := pc.readLicenses(policy, dependencies)
+	if err != nil {
+		return err
+	}
+	violations := policy.Check(dependencies, licenses)
+	if len(violations) > 0 {
+		return errorutils.CheckError(errors.New(createReport(pc.policyPath, violations)))
+	}
+	log.Info("All", strconv.Itoa(len(dependencies)), "resolved dependencies comply with the policy", pc.policyPath+".")
+	return pc.Command.Run()
+}
+
+// Reads the licenses of the dependencies, if the policy checks them. The licenses are read from the properties of
+// the dependencies in Artifactory, and if they aren't set, from the metadata of the installed packages.
+func (pc *PolicyCommand) readLicenses(policy *Policy, dependencies []ResolvedDependency) (map[string][]string, error) {
+	if !policy.ChecksLicenses() {
+		return nil, nil
+	}
+	projectDir, err := filepath.Abs(".")
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	licenses := readNpmLicenses(projectDir, dependencies)
+	serverDetails, err := pc.resolveCommand.ServerDetails()
+	if err != nil || serverDetails == nil {
+		log.Warn("The licenses of the dependencies can't be read from Artifactory, since the server of the command is unknown.")
+		return licenses, nil
+	}
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, false)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := findLicenseProperties(servicesManager, dependencies, policy.Licenses.Properties)
+	if err != nil {
+		return nil, err
+	}
+	for id, propertyLicenses := range properties {
+		licenses[id] = propertyLicenses
+	}
+	return licenses, nil
+}
+
+func createReport(policyPath string, violations []Violation) string {
+	var report strings.Builder
+	report.WriteString("The resolved dependencies violate the policy " + policyPath + ":")
+	for _, violation := range violations {
+		report.WriteString("\n  " + violation.String())
+	}
+	return report.String()
+}
+
+// Returns the sorted paths of the build-info files of the build, including its partials.
+func listBuildFiles(buildConfiguration *utils.BuildConfiguration) ([]string, error) {
+	buildDir, err := utils.GetBuildDir(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, dir := range []string{buildDir, filepath.Join(buildDir, "partials")} {
+		exists, err := fileutils.IsDirExists(dir, false)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		infos, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for _, info := range infos {
+			if !info.IsDir() && info.Name() != utils.BuildInfoDetails {
+				files = append(files, filepath.Join(dir, info.Name()))
+			}
+		}
+	}
+	return files, nil
+}
+
+// Reads the dependencies of the build-info files and partials of the build.
+// The dependencies are deduplicated by their id and sha1 checksum.
+func readDependencies(buildConfiguration *utils.BuildConfiguration) ([]ResolvedDependency, error) {
+	files, err := listBuildFiles(buildConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	var dependencies []ResolvedDependency
+	added := make(map[string]bool)
+	add := func(moduleType buildinfo.ModuleType, moduleDependencies []buildinfo.Dependency) {
+		for _, dependency := range moduleDependencies {
+			key := dependency.Id
+			if dependency.Checksum != nil {
+				key += "@" + dependency.Checksum.Sha1
+			}
+			if !added[key] {
+				added[key] = true
+				dependencies = append(dependencies, ResolvedDependency{Dependency: dependency, ModuleType: moduleType})
+			}
+		}
+	}
+	for _, path := range files {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		if filepath.Base(filepath.Dir(path)) == "partials" {
+			partial := &buildinfo.Partial{}
+			if err = json.Unmarshal(content, partial); err != nil {
+				return nil, errorutils.CheckError(err)
+			}
+			add(partial.ModuleType, partial.Dependencies)
+			continue
+		}
+		buildInfo := &buildinfo.BuildInfo{}
+		if err = json.Unmarshal(content, buildInfo); err != nil {
+			return nil, errorutils.CheckError(err)
+		}
+		for _, module := range buildInfo.Modules {
+			add(module.Type, module.Dependencies)
+		}
+	}
+	return dependencies, nil
+}
+
+// Removes the --policy option from the args of a native command, and returns its value.
+func ExtractPolicyFromArgs(args []string) (cleanArgs []string, policyPath string, err error) {
+	cleanArgs = append([]string(nil), args...)
+	flagIndex, valueIndex, policyPath, err := coreutils.FindFlag(PolicyFlag, cleanArgs)
+	if err != nil {
+		return nil, "", err
+	}
+	coreutils.RemoveFlagFromCommand(&cleanArgs, flagIndex, valueIndex)
+	return
+}
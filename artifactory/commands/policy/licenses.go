@@ -0,0 +1,212 @@
+package policy
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The number of checksums searched by a single AQL query.
+const aqlChecksumsBatchSize = 100
+
+// Splits SPDX license expressions, such as (MIT OR Apache-2.0), to their licenses.
+var licenseExpressionSeparator = regexp.MustCompile(`[()]|\s+(?i:OR|AND|WITH)\s+`)
+
+// A file returned by findLicenseProperties.
+type aqlPropertiesItem struct {
+	Sha1       string `json:"actual_sha1"`
+	Properties []struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	} `json:"properties"`
+}
+
+// Searches Artifactory for the files of the dependencies by their sha1 checksums, and returns the licenses,
+// which are set by the properties of the files, by dependency id.
+func findLicenseProperties(servicesManager artifactory.ArtifactoryServicesManager, dependencies []ResolvedDependency, properties []string) (map[string][]string, error) {
+	idsBySha1 := make(map[string][]string)
+	var sha1s []string
+	for _, dependency := range dependencies {
+		if dependency.Checksum == nil || dependency.Checksum.Sha1 == "" {
+			continue
+		}
+		sha1 := strings.ToLower(dependency.Checksum.Sha1)
+		if _, ok := idsBySha1[sha1]; !ok {
+			sha1s = append(sha1s, sha1)
+		}
+		idsBySha1[sha1] = append(idsBySha1[sha1], dependency.Id)
+	}
+	licenses := make(map[string][]string)
+	for start := 0; start < len(sha1s); start += aqlChecksumsBatchSize {
+		end := start + aqlChecksumsBatchSize
+		if end > len(sha1s) {
+			end = len(sha1s)
+		}
+		items, err := findPropertiesBatch(servicesManager, sha1s[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			itemLicenses := licensesFromProperties(item, properties)
+			if len(itemLicenses) == 0 {
+				continue
+			}
+			for _, id := range idsBySha1[strings.ToLower(item.Sha1)] {
+				licenses[id] = appendUnique(licenses[id], itemLicenses...)
+			}
+		}
+	}
+	return licenses, nil
+}
+
+func findPropertiesBatch(servicesManager artifactory.ArtifactoryServicesManager, sha1s []string) ([]aqlPropertiesItem, error) {
+	var conditions []string
+	for _, sha1 := range sha1s {
+		conditions = append(conditions, `{"actual_sha1":"`+sha1+`"}`)
+	}
+	query := `items.find({"$or":[` + strings.Join(conditions, ",") + `]}).include("actual_sha1","property")`
+	stream, err := servicesManager.Aql(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	content, err := ioutil.ReadAll(stream)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	var result struct {
+		Results []aqlPropertiesItem `json:"results"`
+	}
+	if err = json.Unmarshal(content, &result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return result.Results, nil
+}
+
+// Returns the licenses of the first property, which is set on the item. Multiple licenses are separated by commas.
+func licensesFromProperties(item aqlPropertiesItem, properties []string) []string {
+	for _, property := range properties {
+		var licenses []string
+		for _, itemProperty := range item.Properties {
+			if itemProperty.Key == property {
+				for _, value := range strings.Split(itemProperty.Value, ",") {
+					licenses = appendUnique(licenses, splitLicenseExpression(value)...)
+				}
+			}
+		}
+		if len(licenses) > 0 {
+			return licenses
+		}
+	}
+	return nil
+}
+
+// The license fields of package.json. Old packages set the licenses as objects, or as an array of objects.
+type npmPackage struct {
+	Version  string          `json:"version"`
+	License  json.RawMessage `json:"license"`
+	Licenses json.RawMessage `json:"licenses"`
+}
+
+type npmLicense struct {
+	Type string `json:"type"`
+}
+
+// Returns the licenses of the installed npm dependencies, which are declared by their package.json under
+// node_modules, by dependency id. Packages, whose installed version differs from the dependency, are skipped.
+func readNpmLicenses(projectDir string, dependencies []ResolvedDependency) map[string][]string {
+	licenses := make(map[string][]string)
+	for _, dependency := range dependencies {
+		if dependency.ModuleType != buildinfo.Npm {
+			continue
+		}
+		separator := strings.LastIndex(dependency.Id, ":")
+		if separator <= 0 {
+			continue
+		}
+		name, version := dependency.Id[:separator], dependency.Id[separator+1:]
+		packageJsonPath := filepath.Join(projectDir, "node_modules", filepath.FromSlash(name), "package.json")
+		if exists, _ := fileutils.IsFileExists(packageJsonPath, false); !exists {
+			continue
+		}
+		content, err := ioutil.ReadFile(packageJsonPath)
+		if err != nil {
+			log.Debug("Couldn't read " + packageJsonPath + ": " + err.Error())
+			continue
+		}
+		pkg := &npmPackage{}
+		if err = json.Unmarshal(content, pkg); err != nil {
+			log.Debug("Couldn't parse " + packageJsonPath + ": " + err.Error())
+			continue
+		}
+		if pkg.Version != version {
+			continue
+		}
+		if packageLicenses := pkg.licenses(); len(packageLicenses) > 0 {
+			licenses[dependency.Id] = packageLicenses
+		}
+	}
+	return licenses
+}
+
+func (pkg *npmPackage) licenses() []string {
+	var licenses []string
+	for _, field := range []json.RawMessage{pkg.License, pkg.Licenses} {
+		if len(field) == 0 {
+			continue
+		}
+		var expression string
+		if json.Unmarshal(field, &expression) == nil {
+			licenses = appendUnique(licenses, splitLicenseExpression(expression)...)
+			continue
+		}
+		var licenseObjects []npmLicense
+		if json.Unmarshal(field, &licenseObjects) != nil {
+			var licenseObject npmLicense
+			if json.Unmarshal(field, &licenseObject) != nil {
+				continue
+			}
+			licenseObjects = []npmLicense{licenseObject}
+		}
+		for _, licenseObject := range licenseObjects {
+			licenses = appendUnique(licenses, splitLicenseExpression(licenseObject.Type)...)
+		}
+	}
+	return licenses
+}
+
+// Splits an SPDX license expression to its licenses. All the licenses of the expression are checked,
+// even if the expression allows choosing one of them.
+func splitLicenseExpression(expression string) []string {
+	var licenses []string
+	for _, license := range licenseExpressionSeparator.Split(expression, -1) {
+		if license = strings.TrimSpace(license); license != "" {
+			licenses = append(licenses, license)
+		}
+	}
+	return licenses
+}
+
+func appendUnique(values []string, newValues ...string) []string {
+	for _, newValue := range newValues {
+		exists := false
+		for _, value := range values {
+			if value == newValue {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			values = append(values, newValue)
+		}
+	}
+	return values
+}
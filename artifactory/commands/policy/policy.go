@@ -0,0 +1,170 @@
+package policy
+
+import (
+	"errors"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"gopkg.in/yaml.v2"
+)
+
+// The properties, which the licenses of the dependencies are read from by default.
+var defaultLicenseProperties = []string{"artifactory.licenses", "license", "licenses"}
+
+// The policy, which the resolved dependencies are checked against:
+//
+//	licenses:
+//	  blocked: ["GPL-3.0*", "AGPL-*"]
+//	  allowed: ["MIT", "Apache-2.0", "BSD-*"]
+//	  failOnUnknown: true
+//	checksums:
+//	  required: true
+//	  pinned:
+//	    "lodash:4.17.21": 679591c564c3bffaae8454cf0b3df370c3d6911c
+//	banned:
+//	  - "org.apache.logging.log4j:log4j-core:2.14.*"
+//
+// The license, coordinates and pinned dependency patterns may include * wildcards, and are matched against the
+// dependency ids of the build-info, such as <name>:<version> for npm, Go and NuGet, and <groupId>:<artifactId>:<version> for Maven.
+type Policy struct {
+	Licenses  LicensePolicy  `yaml:"licenses,omitempty"`
+	Checksums ChecksumPolicy `yaml:"checksums,omitempty"`
+	Banned    []string       `yaml:"banned,omitempty"`
+
+	blocked []*wildcardPattern
+	allowed []*wildcardPattern
+	banned  []*wildcardPattern
+}
+
+type LicensePolicy struct {
+	Blocked []string `yaml:"blocked,omitempty"`
+	// If set, the licenses, which match none of the patterns, are blocked too.
+	Allowed []string `yaml:"allowed,omitempty"`
+	// Fail if the license of a dependency is unknown. Unknown licenses are allowed by default.
+	FailOnUnknown bool `yaml:"failOnUnknown,omitempty"`
+	// The Artifactory properties, which the licenses are read from. Multiple licenses are separated by commas.
+	Properties []string `yaml:"properties,omitempty"`
+}
+
+type ChecksumPolicy struct {
+	// Fail if the sha1 checksum of a dependency is missing from the build-info.
+	Required bool `yaml:"required,omitempty"`
+	// The expected sha1 checksums of dependency ids.
+	Pinned map[string]string `yaml:"pinned,omitempty"`
+}
+
+// A dependency, which violates the policy.
+type Violation struct {
+	DependencyId string
+	Reason       string
+}
+
+func (violation Violation) String() string {
+	return violation.DependencyId + ": " + violation.Reason
+}
+
+func ReadPolicy(policyPath string) (*Policy, error) {
+	content, err := ioutil.ReadFile(policyPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	policy := &Policy{}
+	if err = yaml.UnmarshalStrict(content, policy); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to parse the policy file " + policyPath + ": " + err.Error()))
+	}
+	policy.blocked = newWildcardPatterns(policy.Licenses.Blocked)
+	policy.allowed = newWildcardPatterns(policy.Licenses.Allowed)
+	policy.banned = newWildcardPatterns(policy.Banned)
+	if len(policy.Licenses.Properties) == 0 {
+		policy.Licenses.Properties = defaultLicenseProperties
+	}
+	return policy, nil
+}
+
+// Returns true if the policy includes license rules, for which the licenses of the dependencies should be read.
+func (policy *Policy) ChecksLicenses() bool {
+	return len(policy.blocked) > 0 || len(policy.allowed) > 0 || policy.Licenses.FailOnUnknown
+}
+
+// Checks the dependencies against the policy, and returns the violations sorted by dependency id.
+// The licenses are mapped by dependency id.
+func (policy *Policy) Check(dependencies []ResolvedDependency, licenses map[string][]string) []Violation {
+	var violations []Violation
+	for _, dependency := range dependencies {
+		add := func(reason string) {
+			violations = append(violations, Violation{DependencyId: dependency.Id, Reason: reason})
+		}
+		if pattern := matchAny(policy.banned, dependency.Id); pattern != "" {
+			add("banned by the pattern '" + pattern + "'")
+		}
+		sha1 := ""
+		if dependency.Checksum != nil {
+			sha1 = dependency.Checksum.Sha1
+		}
+		if sha1 == "" && policy.Checksums.Required {
+			add("the sha1 checksum is missing")
+		}
+		for pinnedId, expected := range policy.Checksums.Pinned {
+			if newWildcardPattern(pinnedId).regExp.MatchString(dependency.Id) && !strings.EqualFold(expected, sha1) {
+				add("the sha1 checksum '" + sha1 + "' doesn't match the pinned checksum '" + expected + "'")
+			}
+		}
+		if policy.ChecksLicenses() {
+			for _, reason := range policy.checkLicenses(licenses[dependency.Id]) {
+				add(reason)
+			}
+		}
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].DependencyId < violations[j].DependencyId
+	})
+	return violations
+}
+
+func (policy *Policy) checkLicenses(licenses []string) (reasons []string) {
+	if len(licenses) == 0 {
+		if policy.Licenses.FailOnUnknown {
+			reasons = append(reasons, "the license is unknown")
+		}
+		return
+	}
+	for _, license := range licenses {
+		if pattern := matchAny(policy.blocked, license); pattern != "" {
+			reasons = append(reasons, "the license '"+license+"' is blocked by the pattern '"+pattern+"'")
+		} else if len(policy.allowed) > 0 && matchAny(policy.allowed, license) == "" {
+			reasons = append(reasons, "the license '"+license+"' isn't allowed")
+		}
+	}
+	return
+}
+
+// A pattern with * wildcards, with its case insensitive regular expression, which matches the whole value.
+type wildcardPattern struct {
+	pattern string
+	regExp  *regexp.Regexp
+}
+
+func newWildcardPattern(pattern string) *wildcardPattern {
+	return &wildcardPattern{pattern: pattern, regExp: regexp.MustCompile("(?i)^" + strings.Replace(regexp.QuoteMeta(pattern), `\*`, ".*", -1) + "$")}
+}
+
+func newWildcardPatterns(patterns []string) []*wildcardPattern {
+	var wildcardPatterns []*wildcardPattern
+	for _, pattern := range patterns {
+		wildcardPatterns = append(wildcardPatterns, newWildcardPattern(pattern))
+	}
+	return wildcardPatterns
+}
+
+// Returns the first pattern, which matches the value, or an empty string if none of them matches.
+func matchAny(patterns []*wildcardPattern, value string) string {
+	for _, pattern := range patterns {
+		if pattern.regExp.MatchString(value) {
+			return pattern.pattern
+		}
+	}
+	return ""
+}
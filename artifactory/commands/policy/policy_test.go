@@ -0,0 +1,168 @@
+package policy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	policy, err := ReadPolicy(filepath.Join("testdata", "policy.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, defaultLicenseProperties, policy.Licenses.Properties)
+	dependencies := []ResolvedDependency{
+		newDependency("lodash:4.17.21", "679591C564C3BFFAAE8454CF0B3DF370C3D6911C"),
+		newDependency("lodash:4.17.20", "0000000000000000000000000000000000000000"),
+		newDependency("left-pad:1.3.0", ""),
+		newDependency("org.apache.logging.log4j:log4j-core:2.14.1", "1111111111111111111111111111111111111111"),
+		newDependency("org.apache.logging.log4j:log4j-core:2.17.1", "2222222222222222222222222222222222222222"),
+		newDependency("@acme/util:2.0.1", "3333333333333333333333333333333333333333"),
+	}
+	licenses := map[string][]string{
+		"lodash:4.17.21":   {"MIT"},
+		"lodash:4.17.20":   {"mit"},
+		"left-pad:1.3.0":   {"WTFPL", "MIT"},
+		"@acme/util:2.0.1": {"GPL-3.0"},
+	}
+	assert.Equal(t, []Violation{
+		{"@acme/util:2.0.1", "the license 'GPL-3.0' is blocked by the pattern 'GPL-*'"},
+		{"left-pad:1.3.0", "the sha1 checksum is missing"},
+		{"left-pad:1.3.0", "the license 'WTFPL' isn't allowed"},
+		{"org.apache.logging.log4j:log4j-core:2.14.1", "banned by the pattern 'org.apache.logging.log4j:log4j-core:2.14.*'"},
+	}, policy.Check(dependencies, licenses))
+
+	// Unknown licenses are allowed, unless the policy fails on them.
+	policy.Licenses.FailOnUnknown = true
+	assert.Equal(t, []Violation{{"lodash:4.17.20", "the license is unknown"}},
+		policy.Check([]ResolvedDependency{dependencies[1]}, nil))
+
+	_, err = ReadPolicy(filepath.Join("testdata", "invalid-policy.yaml"))
+	assert.Error(t, err)
+}
+
+func TestReadNpmLicenses(t *testing.T) {
+	dependencies := []ResolvedDependency{
+		newDependency("left-pad:1.3.0", ""),
+		newDependency("@acme/util:2.0.1", ""),
+		newDependency("old-lib:0.9.0", ""),
+		// The installed version differs from the dependency.
+		newDependency("old-lib:1.0.0", ""),
+		newDependency("missing:1.0.0", ""),
+	}
+	dependencies = append(dependencies, ResolvedDependency{Dependency: buildinfo.Dependency{Id: "left-pad:1.3.0"}, ModuleType: buildinfo.Go})
+	assert.Equal(t, map[string][]string{
+		"left-pad:1.3.0":   {"WTFPL", "MIT"},
+		"@acme/util:2.0.1": {"GPL-3.0"},
+		"old-lib:0.9.0":    {"BSD-3-Clause"},
+	}, readNpmLicenses(filepath.Join("testdata", "project"), dependencies))
+}
+
+func TestLicensesFromProperties(t *testing.T) {
+	item := aqlPropertiesItem{Sha1: "abc"}
+	for _, property := range [][2]string{{"license", "MIT"}, {"artifactory.licenses", "Apache-2.0,(MIT OR BSD-2-Clause)"}} {
+		item.Properties = append(item.Properties, struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		}{property[0], property[1]})
+	}
+	assert.Equal(t, []string{"Apache-2.0", "MIT", "BSD-2-Clause"}, licensesFromProperties(item, defaultLicenseProperties))
+	assert.Equal(t, []string{"MIT"}, licensesFromProperties(item, []string{"license"}))
+	assert.Empty(t, licensesFromProperties(item, []string{"licenses"}))
+}
+
+func TestExtractPolicyFromArgs(t *testing.T) {
+	args, policyPath, err := ExtractPolicyFromArgs([]string{"install", "--policy=policy.yaml", "--build-name=a"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"install", "--build-name=a"}, args)
+	assert.Equal(t, "policy.yaml", policyPath)
+}
+
+func TestResolveGoals(t *testing.T) {
+	assert.Equal(t, []string{"-P", "release", "-Dskip=true", "dependency:resolve"}, MavenResolveGoals([]string{"clean", "-P", "release", "install", "-Dskip=true"}))
+	assert.Equal(t, []string{"dependency:resolve"}, MavenResolveGoals([]string{"deploy"}))
+	assert.Equal(t, []string{"app.sln"}, DotnetRestoreArgs("build", []string{"app.sln", "-c", "Release"}))
+	assert.Empty(t, DotnetRestoreArgs("build", []string{"-c", "Release"}))
+	assert.Equal(t, []string{"--locked-mode"}, DotnetRestoreArgs("restore", []string{"--locked-mode"}))
+}
+
+func TestRun(t *testing.T) {
+	command := &buildCommand{}
+	resolve := &resolveCommand{dependencies: []buildinfo.Dependency{
+		{Id: "lodash:4.17.21", Checksum: &buildinfo.Checksum{Sha1: "679591c564c3bffaae8454cf0b3df370c3d6911c"}},
+	}}
+	policyCmd := NewPolicyCommand(command).SetPolicyPath(filepath.Join("testdata", "checksums-policy.yaml")).SetResolveCommand(resolve)
+	resolve.buildConfiguration = policyCmd.TempBuildConfiguration()
+	assert.NoError(t, policyCmd.Run())
+	assert.True(t, command.ran)
+
+	// The command doesn't run if the resolved dependencies violate the policy.
+	command.ran = false
+	resolve.dependencies = append(resolve.dependencies, buildinfo.Dependency{Id: "lodash:4.17.20"})
+	err := policyCmd.Run()
+	if assert.Error(t, err) {
+		assert.Equal(t, "The resolved dependencies violate the policy "+filepath.Join("testdata", "checksums-policy.yaml")+":\n"+
+			"  lodash:4.17.20: the sha1 checksum is missing", err.Error())
+	}
+	assert.False(t, command.ran)
+
+	// The temporary build is removed after the check.
+	tempBuild := policyCmd.TempBuildConfiguration()
+	buildDir, err := utils.GetBuildDir(tempBuild.BuildName, tempBuild.BuildNumber, "")
+	assert.NoError(t, err)
+	files, err := fileutils.ListFiles(buildDir, true)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+	assert.NoError(t, utils.RemoveBuildDir(tempBuild.BuildName, tempBuild.BuildNumber, ""))
+}
+
+func newDependency(id, sha1 string) ResolvedDependency {
+	dependency := ResolvedDependency{Dependency: buildinfo.Dependency{Id: id}, ModuleType: buildinfo.Npm}
+	if sha1 != "" {
+		dependency.Checksum = &buildinfo.Checksum{Sha1: sha1}
+	}
+	return dependency
+}
+
+// A command, which runs after the check.
+type buildCommand struct {
+	ran bool
+}
+
+func (bc *buildCommand) Run() error {
+	bc.ran = true
+	return nil
+}
+
+func (bc *buildCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (bc *buildCommand) CommandName() string {
+	return "build"
+}
+
+// A command, which collects its dependencies to the build-info, as the commands of the package managers do.
+type resolveCommand struct {
+	buildConfiguration *utils.BuildConfiguration
+	dependencies       []buildinfo.Dependency
+}
+
+func (rc *resolveCommand) Run() error {
+	return projectutils.SaveDependencies(rc.buildConfiguration, buildinfo.Npm, "resolve", rc.dependencies)
+}
+
+func (rc *resolveCommand) ServerDetails() (*config.ServerDetails, error) {
+	return nil, nil
+}
+
+func (rc *resolveCommand) CommandName() string {
+	return "resolve"
+}
@@ -0,0 +1,44 @@
+package policy
+
+import "strings"
+
+// The goal of Maven, which resolves the dependencies of the project without building it.
+const mavenResolveGoal = "dependency:resolve"
+
+// The options of Maven, which take their value from the next argument.
+var mavenValueOptions = map[string]bool{
+	"-f": true, "--file": true, "-P": true, "--activate-profiles": true, "-pl": true, "--projects": true,
+	"-s": true, "--settings": true, "-gs": true, "--global-settings": true, "-rf": true, "--resume-from": true,
+	"-T": true, "--threads": true,
+}
+
+// Returns the Maven arguments, which resolve the dependencies of a Maven command without building the project.
+// The options of the command, such as its profiles and properties, are kept, and its goals and phases are replaced
+// by the dependency:resolve goal.
+func MavenResolveGoals(goals []string) []string {
+	var resolveGoals []string
+	for i := 0; i < len(goals); i++ {
+		if !strings.HasPrefix(goals[i], "-") {
+			continue
+		}
+		resolveGoals = append(resolveGoals, goals[i])
+		if mavenValueOptions[goals[i]] && i+1 < len(goals) {
+			i++
+			resolveGoals = append(resolveGoals, goals[i])
+		}
+	}
+	return append(resolveGoals, mavenResolveGoal)
+}
+
+// Returns the arguments of 'dotnet restore', which restores the packages of a .NET command without building the project.
+// The arguments of a restore command are kept. Of the arguments of other commands, only the path of the solution or
+// the project is kept, if it's the first argument, since their other options may not be supported by 'dotnet restore'.
+func DotnetRestoreArgs(command string, argAndFlags []string) []string {
+	if command == "restore" {
+		return argAndFlags
+	}
+	if len(argAndFlags) > 0 && !strings.HasPrefix(argAndFlags[0], "-") {
+		return argAndFlags[:1]
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package projectsetup
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/general/cisetup"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// A project type and the files, which indicate that a directory includes a project of the type.
+type indicator struct {
+	projectType string
+	// File names or glob patterns, which are matched against the base names of the files.
+	patterns []string
+	// Optionally checks the content of a matching file.
+	matches func(filePath string) bool
+}
+
+// The project types of the technologies detected by 'jfrog ci-setup', whose indicators are checked first,
+// so that both commands detect these technologies the same way.
+var projectTypesByTechnology = map[cisetup.Technology]string{
+	cisetup.Maven:  "maven",
+	cisetup.Gradle: "gradle",
+	cisetup.Npm:    "npm",
+}
+
+// The indicators of the other project types are checked in order, and a file indicates the first project type it matches.
+var indicators = []indicator{
+	{projectType: projectutils.Pnpm, patterns: []string{"pnpm-lock.yaml", "pnpm-workspace.yaml"}},
+	{projectType: "yarn", patterns: []string{"yarn.lock", ".yarnrc.yml"}},
+	{projectType: "go", patterns: []string{"go.mod"}},
+	{projectType: projectutils.Poetry, patterns: []string{"poetry.lock"}},
+	{projectType: projectutils.Poetry, patterns: []string{"pyproject.toml"}, matches: isPoetryProject},
+	{projectType: "pip", patterns: []string{"requirements.txt", "requirements-*.txt", "setup.py", "setup.cfg", "pyproject.toml"}},
+	{projectType: "dotnet", patterns: []string{"*.csproj", "*.fsproj", "*.vbproj", "*.sln", "packages.config"}},
+	{projectType: projectutils.Helm, patterns: []string{"Chart.yaml"}},
+	{projectType: projectutils.Cargo, patterns: []string{"Cargo.toml"}},
+	{projectType: projectutils.Conan, patterns: []string{"conanfile.txt", "conanfile.py"}},
+	{projectType: projectutils.Terraform, patterns: []string{"*.tf"}},
+}
+
+// Directories, which include dependencies, build outputs or tool state rather than project files.
+var skippedDirs = map[string]bool{
+	".git": true, ".jfrog": true, ".idea": true, ".terraform": true, "node_modules": true, "vendor": true,
+	"target": true, "build": true, "bin": true, "obj": true, ".gradle": true, "venv": true, ".venv": true, "__pycache__": true,
+}
+
+func isPoetryProject(filePath string) bool {
+	content, err := ioutil.ReadFile(filePath)
+	return err == nil && strings.Contains(string(content), "[tool.poetry]")
+}
+
+// Scans the directory tree for project files, and returns the detected project types with the files, which indicate them,
+// relative to the root directory.
+func DetectProjects(rootDir string) (map[string][]string, error) {
+	detected := make(map[string][]string)
+	err := filepath.Walk(rootDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if filePath != rootDir && skippedDirs[info.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		projectType := detectFile(filePath, info.Name())
+		if projectType == "" {
+			return nil
+		}
+		relativePath, err := filepath.Rel(rootDir, filePath)
+		if err != nil {
+			return err
+		}
+		detected[projectType] = append(detected[projectType], filepath.ToSlash(relativePath))
+		return nil
+	})
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	removePackageManagerProjects(detected)
+	for _, files := range detected {
+		sort.Strings(files)
+	}
+	return detected, nil
+}
+
+// The package.json files of pnpm and yarn projects don't indicate npm projects.
+func removePackageManagerProjects(detected map[string][]string) {
+	managedDirs := make(map[string]bool)
+	for _, projectType := range []string{projectutils.Pnpm, "yarn"} {
+		for _, file := range detected[projectType] {
+			managedDirs[path.Dir(file)] = true
+		}
+	}
+	var npmFiles []string
+	for _, file := range detected["npm"] {
+		if !managedDirs[path.Dir(file)] {
+			npmFiles = append(npmFiles, file)
+		}
+	}
+	if len(npmFiles) == 0 {
+		delete(detected, "npm")
+		return
+	}
+	detected["npm"] = npmFiles
+}
+
+func detectFile(filePath, name string) string {
+	for _, techIndicator := range cisetup.GetTechIndicators() {
+		if techIndicator.Indicates(name) {
+			return projectTypesByTechnology[techIndicator.GetTechnology()]
+		}
+	}
+	for _, indicator := range indicators {
+		for _, pattern := range indicator.patterns {
+			if matched, _ := filepath.Match(pattern, name); matched && (indicator.matches == nil || indicator.matches(filePath)) {
+				return indicator.projectType
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package projectsetup
+
+import (
+	"path/filepath"
+	"testing"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectProjects(t *testing.T) {
+	detected, err := DetectProjects(filepath.Join("testdata", "repo"))
+	assert.NoError(t, err)
+	// The package.json of the pnpm project and the files under node_modules and target aren't detected.
+	assert.Equal(t, map[string][]string{
+		"maven":     {"backend/pom.xml"},
+		"pnpm":      {"frontend/pnpm-lock.yaml"},
+		"go":        {"tools/cli/go.mod"},
+		"terraform": {"infra/main.tf"},
+		"poetry":    {"scripts/pyproject.toml"},
+		"pip":       {"scripts/requirements.txt"},
+	}, detected)
+}
+
+func TestDetectFile(t *testing.T) {
+	// The Maven, Gradle and npm projects are detected by the 'jfrog ci-setup' indicators.
+	assert.Equal(t, "maven", detectFile("pom.xml", "pom.xml"))
+	assert.Equal(t, "gradle", detectFile("build.gradle.kts", "build.gradle.kts"))
+	assert.Equal(t, "gradle", detectFile("dependencies.gradle", "dependencies.gradle"))
+	assert.Equal(t, "npm", detectFile("package.json", "package.json"))
+	assert.Equal(t, "yarn", detectFile("yarn.lock", "yarn.lock"))
+	assert.Equal(t, "", detectFile("README.md", "README.md"))
+}
+
+func TestCreateConfigFiles(t *testing.T) {
+	detected := map[string][]string{"maven": {"pom.xml"}, "pip": {"requirements.txt"}, "terraform": {"main.tf"}, "gradle": {"build.gradle"}}
+	configFiles := CreateConfigFiles(detected, &Mapping{}, "acme", filepath.Join("testdata", "repo"))
+	if assert.Len(t, configFiles, 4) {
+		assert.Equal(t, &commandUtils.ConfigFile{
+			Version:    commandUtils.BUILD_CONF_VERSION,
+			ConfigType: "gradle",
+			Resolver:   utils.Repository{ServerId: "acme", Repo: "gradle-virtual"},
+			Deployer: utils.Repository{ServerId: "acme", Repo: "gradle-local", DeployMavenDesc: true, DeployIvyDesc: true,
+				IvyPattern: "[organization]/[module]/ivy-[revision].xml", ArtifactsPattern: "[organization]/[module]/[revision]/[artifact]-[revision](-[classifier]).[ext]"},
+		}, configFiles[0])
+		assert.Equal(t, utils.Repository{ServerId: "acme", ReleaseRepo: "maven-virtual", SnapshotRepo: "maven-virtual"}, configFiles[1].Resolver)
+		assert.Equal(t, utils.Repository{ServerId: "acme", ReleaseRepo: "maven-local", SnapshotRepo: "maven-local"}, configFiles[1].Deployer)
+		assert.Equal(t, utils.Repository{ServerId: "acme", Repo: "pypi-virtual"}, configFiles[2].Resolver)
+		assert.Equal(t, utils.Repository{ServerId: "acme", Repo: "pypi-local"}, configFiles[2].Deployer)
+		assert.Equal(t, "terraform", configFiles[3].ConfigType)
+	}
+
+	mapping, err := ReadMapping(filepath.Join("testdata", "mapping.yaml"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	configFiles = CreateConfigFiles(detected, mapping, "acme", filepath.Join("testdata", "repo"))
+	if assert.Len(t, configFiles, 3) {
+		assert.Equal(t, utils.Repository{ServerId: "acme", ReleaseRepo: "libs-virtual", SnapshotRepo: "libs-virtual"}, configFiles[1].Resolver)
+		assert.Equal(t, utils.Repository{ServerId: "acme", ReleaseRepo: "libs-release-local", SnapshotRepo: "libs-snapshot-local"}, configFiles[1].Deployer)
+		assert.Equal(t, "pip", configFiles[2].ConfigType)
+	}
+
+	_, err = ReadMapping(filepath.Join("testdata", "unknown-mapping.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,261 @@
+package projectsetup
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	commandUtils "github.com/jfrog/jfrog-cli-core/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+// The package type of the repositories of each project type, by which the repositories are named by convention:
+// dependencies are resolved from <package type>-virtual, and artifacts are deployed to <package type>-local.
+var packageTypes = map[string]string{
+	"maven":                "maven",
+	"gradle":               "gradle",
+	"npm":                  "npm",
+	"yarn":                 "npm",
+	projectutils.Pnpm:      "npm",
+	"go":                   "go",
+	"pip":                  "pypi",
+	projectutils.Poetry:    "pypi",
+	"dotnet":               "nuget",
+	projectutils.Helm:      "helm",
+	projectutils.Cargo:     "cargo",
+	projectutils.Conan:     "conan",
+	projectutils.Terraform: "terraform",
+}
+
+// The project types, whose configuration includes no deployer, as the configuration written by their config commands.
+var resolveOnlyTypes = map[string]bool{"yarn": true, "dotnet": true, projectutils.Poetry: true}
+
+// The repositories of a project type in the mapping file. Maven projects may also set separate snapshot repositories.
+type RepositoryMapping struct {
+	Resolve          string `yaml:"resolve,omitempty"`
+	Deploy           string `yaml:"deploy,omitempty"`
+	ResolveSnapshots string `yaml:"resolveSnapshots,omitempty"`
+	DeploySnapshots  string `yaml:"deploySnapshots,omitempty"`
+	// Skips writing the configuration of the project type, even if it is detected.
+	Skip bool `yaml:"skip,omitempty"`
+}
+
+// The mapping file, which overrides the naming conventions of the repositories by project type:
+//
+//	repositories:
+//	  maven:
+//	    resolve: libs-virtual
+//	    deploy: libs-release-local
+//	    deploySnapshots: libs-snapshot-local
+//	  go:
+//	    skip: true
+type Mapping struct {
+	Repositories map[string]*RepositoryMapping `yaml:"repositories,omitempty"`
+}
+
+func ReadMapping(mappingPath string) (*Mapping, error) {
+	content, err := ioutil.ReadFile(mappingPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	mapping := &Mapping{}
+	if err = yaml.UnmarshalStrict(content, mapping); err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to parse " + mappingPath + ": " + err.Error()))
+	}
+	for projectType := range mapping.Repositories {
+		if _, ok := packageTypes[projectType]; !ok {
+			return nil, errorutils.CheckError(errors.New("unknown project type '" + projectType + "' in " + mappingPath))
+		}
+	}
+	return mapping, nil
+}
+
+// Scans the working directory for project files, and writes the configuration of every detected project type
+// to .jfrog/projects/<type>.yaml, as the config command of the type does, without prompting.
+// The repositories are named by convention, unless they are set in the mapping file.
+type SetupCommand struct {
+	serverId    string
+	mappingPath string
+	overwrite   bool
+	dryRun      bool
+}
+
+func NewSetupCommand() *SetupCommand {
+	return &SetupCommand{}
+}
+
+func (sc *SetupCommand) SetServerId(serverId string) *SetupCommand {
+	sc.serverId = serverId
+	return sc
+}
+
+func (sc *SetupCommand) SetMappingPath(mappingPath string) *SetupCommand {
+	sc.mappingPath = mappingPath
+	return sc
+}
+
+// Sets whether to overwrite existing configuration files. They are kept by default.
+func (sc *SetupCommand) SetOverwrite(overwrite bool) *SetupCommand {
+	sc.overwrite = overwrite
+	return sc
+}
+
+func (sc *SetupCommand) SetDryRun(dryRun bool) *SetupCommand {
+	sc.dryRun = dryRun
+	return sc
+}
+
+func (sc *SetupCommand) ServerDetails() (*config.ServerDetails, error) {
+	return config.GetSpecificConfig(sc.serverId, false, true)
+}
+
+func (sc *SetupCommand) CommandName() string {
+	return "rt_setup"
+}
+
+func (sc *SetupCommand) Run() error {
+	if _, err := sc.ServerDetails(); err != nil {
+		return err
+	}
+	mapping := &Mapping{}
+	if sc.mappingPath != "" {
+		var err error
+		if mapping, err = ReadMapping(sc.mappingPath); err != nil {
+			return err
+		}
+	}
+	workingDir, err := filepath.Abs(".")
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	detected, err := DetectProjects(workingDir)
+	if err != nil {
+		return err
+	}
+	if len(detected) == 0 {
+		log.Info("No project files were found in " + workingDir + ".")
+		return nil
+	}
+	projectDir, err := utils.GetProjectDir(false)
+	if err != nil {
+		return err
+	}
+	for _, configFile := range CreateConfigFiles(detected, mapping, sc.serverId, workingDir) {
+		log.Info("Detected", configFile.ConfigType, "by", strings.Join(detected[configFile.ConfigType], ", "))
+		configFilePath := filepath.Join(projectDir, configFile.ConfigType+".yaml")
+		exists, err := fileutils.IsFileExists(configFilePath, false)
+		if err != nil {
+			return err
+		}
+		if exists && !sc.overwrite {
+			log.Info("Skipping", configFile.ConfigType+",", "since", configFilePath, "already exists. Use --overwrite to replace it.")
+			continue
+		}
+		if sc.dryRun {
+			content, err := yaml.Marshal(configFile)
+			if err != nil {
+				return errorutils.CheckError(err)
+			}
+			log.Info("[Dry run] Would write " + configFilePath + ":\n" + string(content))
+			continue
+		}
+		if err = projectutils.WriteConfigFile(configFile, configFile.ConfigType, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Creates the configuration of every detected project type, sorted by type.
+// The project types, which are skipped by the mapping, are omitted.
+func CreateConfigFiles(detected map[string][]string, mapping *Mapping, serverId, rootDir string) []*commandUtils.ConfigFile {
+	var projectTypes []string
+	for projectType := range detected {
+		projectTypes = append(projectTypes, projectType)
+	}
+	sort.Strings(projectTypes)
+	var configFiles []*commandUtils.ConfigFile
+	for _, projectType := range projectTypes {
+		repos := conventionalRepositories(projectType)
+		if mapped, ok := mapping.Repositories[projectType]; ok {
+			if mapped.Skip {
+				log.Info("Skipping", projectType, "by the mapping file.")
+				continue
+			}
+			repos.merge(mapped)
+		}
+		configFiles = append(configFiles, createConfigFile(projectType, repos, serverId, rootDir))
+	}
+	return configFiles
+}
+
+func conventionalRepositories(projectType string) *RepositoryMapping {
+	repos := &RepositoryMapping{Resolve: packageTypes[projectType] + "-virtual"}
+	if !resolveOnlyTypes[projectType] {
+		repos.Deploy = packageTypes[projectType] + "-local"
+	}
+	return repos
+}
+
+func (repos *RepositoryMapping) merge(mapped *RepositoryMapping) {
+	if mapped.Resolve != "" {
+		repos.Resolve = mapped.Resolve
+	}
+	if mapped.Deploy != "" {
+		repos.Deploy = mapped.Deploy
+	}
+	repos.ResolveSnapshots = mapped.ResolveSnapshots
+	repos.DeploySnapshots = mapped.DeploySnapshots
+}
+
+func createConfigFile(projectType string, repos *RepositoryMapping, serverId, rootDir string) *commandUtils.ConfigFile {
+	configFile := &commandUtils.ConfigFile{
+		Version:    commandUtils.BUILD_CONF_VERSION,
+		ConfigType: projectType,
+		Resolver:   utils.Repository{ServerId: serverId},
+	}
+	deploy := repos.Deploy != "" && !resolveOnlyTypes[projectType]
+	if deploy {
+		configFile.Deployer.ServerId = serverId
+	}
+	switch projectType {
+	case "maven":
+		// Maven resolves and deploys releases and snapshots separately.
+		configFile.Resolver.ReleaseRepo, configFile.Resolver.SnapshotRepo = repos.Resolve, firstNonEmpty(repos.ResolveSnapshots, repos.Resolve)
+		if deploy {
+			configFile.Deployer.ReleaseRepo, configFile.Deployer.SnapshotRepo = repos.Deploy, firstNonEmpty(repos.DeploySnapshots, repos.Deploy)
+		}
+		return configFile
+	case "gradle":
+		// The defaults of the gradle-config command.
+		configFile.Deployer.DeployMavenDesc = deploy
+		configFile.Deployer.DeployIvyDesc = deploy
+		if deploy {
+			configFile.Deployer.IvyPattern = "[organization]/[module]/ivy-[revision].xml"
+			configFile.Deployer.ArtifactsPattern = "[organization]/[module]/[revision]/[artifact]-[revision](-[classifier]).[ext]"
+		}
+		configFile.UseWrapper, _ = fileutils.IsFileExists(filepath.Join(rootDir, "gradlew"), false)
+	}
+	configFile.Resolver.Repo = repos.Resolve
+	if deploy {
+		configFile.Deployer.Repo = repos.Deploy
+	}
+	return configFile
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
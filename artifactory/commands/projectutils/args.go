@@ -0,0 +1,15 @@
+package projectutils
+
+import "strings"
+
+// Returns true if any of the options is passed in the arguments, either as '--option' or as '--option=value'.
+func HasOption(args []string, options ...string) bool {
+	for _, option := range options {
+		for _, arg := range args {
+			if arg == option || strings.HasPrefix(arg, option+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
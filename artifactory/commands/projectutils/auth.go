@@ -0,0 +1,24 @@
+package projectutils
+
+import (
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/auth"
+)
+
+// Returns the username and password to use for basic authentication with Artifactory,
+// for package managers which don't support the other authentication methods.
+// The password is the access token or API key, if one is configured.
+func GetBasicAuthCredentials(serverDetails *config.ServerDetails) (username, password string, err error) {
+	username, password = serverDetails.GetUser(), serverDetails.GetPassword()
+	if serverDetails.GetApiKey() != "" {
+		password = serverDetails.GetApiKey()
+	}
+	if serverDetails.GetAccessToken() != "" {
+		password = serverDetails.GetAccessToken()
+		// An access token may be used without the username.
+		if username == "" {
+			username, err = auth.ExtractUsernameFromAccessToken(serverDetails.GetAccessToken())
+		}
+	}
+	return
+}
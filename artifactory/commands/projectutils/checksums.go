@@ -0,0 +1,117 @@
+package projectutils
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The number of conditions searched by a single AQL query.
+const aqlConditionsBatchSize = 100
+
+// The hashes of the dependencies and artifacts of a build-info module, which have no field in the build-info checksums,
+// such as sha256 checksums or the integrities of packages. The build-info checksums have sha1 and md5 fields only,
+// so the hashes are stored in the module properties, as <hash type>.<dependency ID or artifact path>.
+type ModuleHashes map[string]string
+
+// Adds the hash of a dependency or an artifact. Empty hashes are ignored.
+func (mh ModuleHashes) Add(hashType, id, hash string) {
+	if hash != "" {
+		mh[hashType+"."+id] = hash
+	}
+}
+
+// Sets the hashes as the properties of the module, if there are any.
+func (mh ModuleHashes) SetProperties(module *buildinfo.Module) {
+	if len(mh) > 0 {
+		module.Properties = map[string]string(mh)
+	}
+}
+
+// A file found in Artifactory by its sha256 checksum.
+type FoundFile struct {
+	Name     string
+	Checksum *buildinfo.Checksum
+}
+
+// A file returned by FindItems.
+type AqlItem struct {
+	Repo   string `json:"repo"`
+	Path   string `json:"path"`
+	Name   string `json:"name"`
+	Sha256 string `json:"sha256"`
+	Sha1   string `json:"actual_sha1"`
+	Md5    string `json:"actual_md5"`
+}
+
+func (item *AqlItem) Checksum() *buildinfo.Checksum {
+	return &buildinfo.Checksum{Sha1: item.Sha1, Md5: item.Md5}
+}
+
+// Searches the repository for files by their sha256 checksums, and returns the found files by their sha256.
+func FindFilesBySha256(servicesManager artifactory.ArtifactoryServicesManager, repo string, sha256s []string) (map[string]*FoundFile, error) {
+	var conditions []string
+	for _, sha256 := range sha256s {
+		conditions = append(conditions, `{"sha256":"`+sha256+`"}`)
+	}
+	items, err := FindItems(servicesManager, repo, conditions)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]*FoundFile)
+	for _, item := range items {
+		found[item.Sha256] = &FoundFile{Name: item.Name, Checksum: item.Checksum()}
+	}
+	return found, nil
+}
+
+// Searches the repository for the files matching any of the AQL conditions, such as {"path":"a/b","name":"c"}.
+// If the repository is empty, all the repositories are searched.
+// The conditions are searched in batches, to keep the queries short.
+func FindItems(servicesManager artifactory.ArtifactoryServicesManager, repo string, conditions []string) ([]AqlItem, error) {
+	var items []AqlItem
+	for start := 0; start < len(conditions); start += aqlConditionsBatchSize {
+		end := start + aqlConditionsBatchSize
+		if end > len(conditions) {
+			end = len(conditions)
+		}
+		batch, err := findItemsBatch(servicesManager, repo, conditions[start:end])
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, batch...)
+	}
+	return items, nil
+}
+
+func findItemsBatch(servicesManager artifactory.ArtifactoryServicesManager, repo string, conditions []string) ([]AqlItem, error) {
+	repoCondition := ""
+	if repo != "" {
+		repoJson, err := json.Marshal(repo)
+		if errorutils.CheckError(err) != nil {
+			return nil, err
+		}
+		repoCondition = `"repo":` + string(repoJson) + `,`
+	}
+	query := `items.find({` + repoCondition + `"$or":[` + strings.Join(conditions, ",") + `]}).include("repo","path","name","sha256","actual_sha1","actual_md5")`
+	stream, err := servicesManager.Aql(query)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+	content, err := ioutil.ReadAll(stream)
+	if errorutils.CheckError(err) != nil {
+		return nil, err
+	}
+	var result struct {
+		Results []AqlItem `json:"results"`
+	}
+	if err = json.Unmarshal(content, &result); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return result.Results, nil
+}
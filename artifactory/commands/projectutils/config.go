@@ -0,0 +1,257 @@
+package projectutils
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codegangsta/cli"
+	commandUtils "github.com/jfrog/jfrog-cli-core/artifactory/commands/utils"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli-core/utils/coreutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+	"gopkg.in/yaml.v2"
+)
+
+// Project types, which are not supported by the project configuration of jfrog-cli-core.
+// Their configuration files are stored next to the configuration files of the other project types, in .jfrog/projects/<type>.yaml.
+const (
+	Poetry    = "poetry"
+	Helm      = "helm"
+	Cargo     = "cargo"
+	Conan     = "conan"
+	Pnpm      = "pnpm"
+	Terraform = "terraform"
+)
+
+// If the configuration file of the project type exists in the working dir or in one of its parent dirs, return its path.
+// Otherwise, return the path of the global configuration file.
+// The project types of jfrog-cli-core are looked up by utils.GetProjectConfFilePath.
+func GetProjectConfFilePath(projectType string) (confFilePath string, exists bool, err error) {
+	for i, coreProjectType := range utils.ProjectTypes {
+		if coreProjectType == projectType {
+			return utils.GetProjectConfFilePath(utils.ProjectType(i))
+		}
+	}
+	confFileName := filepath.Join("projects", projectType+".yaml")
+	projectDir, exists, err := fileutils.FindUpstream(".jfrog", fileutils.Dir)
+	if err != nil {
+		return "", false, err
+	}
+	if exists {
+		confFilePath = filepath.Join(projectDir, ".jfrog", confFileName)
+		exists, err = fileutils.IsFileExists(confFilePath, false)
+		if err != nil || exists {
+			return
+		}
+	}
+	jfrogHomeDir, err := coreutils.GetJfrogHomeDir()
+	if err != nil {
+		return "", false, err
+	}
+	confFilePath = filepath.Join(jfrogHomeDir, confFileName)
+	exists, err = fileutils.IsFileExists(confFilePath, false)
+	return
+}
+
+// Creates the configuration of the project type from the resolver and deployer flags of the config command.
+func NewConfigFile(projectType string, c *cli.Context) *commandUtils.ConfigFile {
+	return &commandUtils.ConfigFile{
+		Interactive: isInteractive(c),
+		Version:     commandUtils.BUILD_CONF_VERSION,
+		ConfigType:  projectType,
+		Resolver:    utils.Repository{ServerId: c.String(commandUtils.ResolutionServerId), Repo: c.String(commandUtils.ResolutionRepo)},
+		Deployer:    utils.Repository{ServerId: c.String(commandUtils.DeploymentServerId), Repo: c.String(commandUtils.DeploymentRepo)},
+	}
+}
+
+// Creates the configuration file of the project type, from the flags of the config command.
+// If none of the flags is set, the resolver and the deployer (if the project type supports deployment) are read interactively.
+func CreateBuildConfig(c *cli.Context, projectType string, withDeployer bool) error {
+	configFile := NewConfigFile(projectType, c)
+	if configFile.Interactive {
+		if err := askRepository(&configFile.Resolver, "Resolve dependencies from Artifactory?", "Set repository for dependencies resolution", utils.REMOTE); err != nil {
+			return err
+		}
+		if withDeployer {
+			if err := askRepository(&configFile.Deployer, "Deploy project artifacts to Artifactory?", "Set repository for artifacts deployment", utils.LOCAL); err != nil {
+				return err
+			}
+		}
+	}
+	if err := ValidateConfigFile(configFile); err != nil {
+		return err
+	}
+	return WriteConfigFile(configFile, projectType, c.Bool(commandUtils.Global))
+}
+
+func isInteractive(c *cli.Context) bool {
+	if strings.ToLower(os.Getenv(coreutils.CI)) == "true" {
+		return false
+	}
+	for _, flagName := range []string{commandUtils.ResolutionServerId, commandUtils.ResolutionRepo, commandUtils.DeploymentServerId, commandUtils.DeploymentRepo} {
+		if c.IsSet(flagName) {
+			return false
+		}
+	}
+	return true
+}
+
+func askRepository(repository *utils.Repository, useArtifactoryQuestion, repoPromptPrefix string, repoType utils.RepoType) error {
+	serverIds, defaultServerId, err := getServerIdsAndDefault()
+	if err != nil {
+		return err
+	}
+	if !coreutils.AskYesNo(useArtifactoryQuestion, true) {
+		return nil
+	}
+	repository.ServerId = commandUtils.AskFromList("", "Set Artifactory server ID", false, commandUtils.ConvertToSuggests(serverIds), defaultServerId)
+	repos, err := getRepositories(repository.ServerId, repoType, utils.VIRTUAL)
+	if err != nil {
+		log.Error("failed getting repositories list: " + err.Error())
+	}
+	if len(repos) > 0 {
+		repository.Repo = commandUtils.AskFromListWithMismatchConfirmation(repoPromptPrefix+commandUtils.PressTabMsg, "Repository not found.", commandUtils.ConvertToSuggests(repos))
+	} else {
+		repository.Repo = commandUtils.AskString("", repoPromptPrefix+":", false, false)
+	}
+	return nil
+}
+
+func getServerIdsAndDefault() (serverIds []string, defaultServerId string, err error) {
+	allConfigs, err := config.GetAllServersConfigs()
+	if err != nil {
+		return
+	}
+	for _, serverDetails := range allConfigs {
+		if serverDetails.IsDefault {
+			defaultServerId = serverDetails.ServerId
+		}
+		serverIds = append(serverIds, serverDetails.ServerId)
+	}
+	if len(serverIds) == 0 {
+		err = errorutils.CheckError(errors.New("no Artifactory servers configured. Use the 'jfrog c add' command to set the Artifactory server details"))
+	}
+	return
+}
+
+func getRepositories(serverId string, repoTypes ...utils.RepoType) ([]string, error) {
+	serverDetails, err := config.GetSpecificConfig(serverId, false, true)
+	if err != nil {
+		return nil, err
+	}
+	artAuth, err := serverDetails.CreateArtAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	return utils.GetRepositories(artAuth, repoTypes...)
+}
+
+// Verifies that the resolver and deployer are either fully set or not set at all, and that their servers are configured.
+func ValidateConfigFile(configFile *commandUtils.ConfigFile) error {
+	if configFile.Resolver.ServerId == "" && configFile.Deployer.ServerId == "" {
+		return errorutils.CheckError(fmt.Errorf("the --%s or --%s option is required", commandUtils.ResolutionServerId, commandUtils.DeploymentServerId))
+	}
+	for _, repository := range []struct {
+		flagsPrefix string
+		utils.Repository
+	}{{"resolve", configFile.Resolver}, {"deploy", configFile.Deployer}} {
+		if repository.ServerId == "" && repository.Repo == "" {
+			continue
+		}
+		if repository.ServerId == "" || repository.Repo == "" {
+			return errorutils.CheckError(fmt.Errorf("the --server-id-%s and --repo-%s options must be used together", repository.flagsPrefix, repository.flagsPrefix))
+		}
+		if _, err := config.GetSpecificConfig(repository.ServerId, false, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Writes the configuration to .jfrog/projects/<type>.yaml, under the working directory or under the JFrog home directory if global.
+func WriteConfigFile(configFile interface{}, projectType string, global bool) error {
+	projectDir, err := utils.GetProjectDir(global)
+	if err != nil {
+		return err
+	}
+	if err = fileutils.CreateDirIfNotExist(projectDir); err != nil {
+		return err
+	}
+	content, err := yaml.Marshal(configFile)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	configFilePath := filepath.Join(projectDir, projectType+".yaml")
+	if err = ioutil.WriteFile(configFilePath, content, 0644); err != nil {
+		return errorutils.CheckError(err)
+	}
+	log.Info(projectType + " build config successfully created.")
+	return nil
+}
+
+// Returns the resolver configuration of the project type.
+func GetResolverConfig(projectType string) (*utils.RepositoryConfig, error) {
+	return getRepoConfig(projectType, utils.ProjectConfigResolverPrefix)
+}
+
+// Returns the deployer configuration of the project type.
+func GetDeployerConfig(projectType string) (*utils.RepositoryConfig, error) {
+	return getRepoConfig(projectType, utils.ProjectConfigDeployerPrefix)
+}
+
+func getRepoConfig(projectType, prefix string) (*utils.RepositoryConfig, error) {
+	confFilePath, exists, err := GetProjectConfFilePath(projectType)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, configFileNotFoundError(projectType)
+	}
+	return ReadRepoConfig(confFilePath, prefix)
+}
+
+func ReadRepoConfig(confFilePath, prefix string) (*utils.RepositoryConfig, error) {
+	log.Debug("Preparing to read the config file", confFilePath)
+	vConfig, err := utils.ReadConfigFile(confFilePath, utils.YAML)
+	if err != nil {
+		return nil, err
+	}
+	return utils.GetRepoConfigByPrefix(confFilePath, prefix, vConfig)
+}
+
+// Returns the resolver and the deployer configurations of the project type. Each of them is nil if it isn't configured.
+func GetResolverAndDeployerConfig(projectType string) (resolver, deployer *utils.RepositoryConfig, err error) {
+	confFilePath, exists, err := GetProjectConfFilePath(projectType)
+	if err != nil {
+		return
+	}
+	if !exists {
+		err = configFileNotFoundError(projectType)
+		return
+	}
+	vConfig, err := utils.ReadConfigFile(confFilePath, utils.YAML)
+	if err != nil {
+		return
+	}
+	if vConfig.IsSet(utils.ProjectConfigResolverPrefix) {
+		if resolver, err = utils.GetRepoConfigByPrefix(confFilePath, utils.ProjectConfigResolverPrefix, vConfig); err != nil {
+			return
+		}
+	}
+	if vConfig.IsSet(utils.ProjectConfigDeployerPrefix) {
+		deployer, err = utils.GetRepoConfigByPrefix(confFilePath, utils.ProjectConfigDeployerPrefix, vConfig)
+	}
+	return
+}
+
+func configFileNotFoundError(projectType string) error {
+	return errorutils.CheckError(errors.New(fmt.Sprintf("JFrog CLI's %s configuration file was not found.\n"+
+		"Run 'jfrog rt %s-config' command to create it.", projectType, projectType)))
+}
@@ -0,0 +1,108 @@
+package projectutils
+
+import (
+	"errors"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// A local file and the path in Artifactory it is deployed to.
+type DeployableFile struct {
+	LocalPath string
+	// The target path, including the repository, in the form of <repo>/<path>/<file name>.
+	TargetPath string
+	// Properties in the form of "key1=value1;key2=value2;...", which are set on the deployed file.
+	Props string
+}
+
+// Deploys the files to Artifactory, and returns them as build-info artifacts.
+// If the build name and number are set, the build properties are set on the deployed files.
+func DeployFiles(serverDetails *config.ServerDetails, files []DeployableFile, buildConfiguration *utils.BuildConfiguration) ([]buildinfo.Artifact, error) {
+	servicesManager, err := utils.CreateServiceManager(serverDetails, -1, false)
+	if err != nil {
+		return nil, err
+	}
+	buildProps := ""
+	if buildConfiguration != nil && buildConfiguration.BuildName != "" && buildConfiguration.BuildNumber != "" {
+		if buildProps, err = utils.CreateBuildProperties(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project); err != nil {
+			return nil, err
+		}
+	}
+	var uploadParams []services.UploadParams
+	var artifacts []buildinfo.Artifact
+	for _, file := range files {
+		params := services.NewUploadParams()
+		params.Pattern = file.LocalPath
+		params.Target = file.TargetPath
+		params.Flat = true
+		params.BuildProps = buildProps
+		if file.Props != "" {
+			if params.TargetProps, err = serviceutils.ParseProperties(file.Props); err != nil {
+				return nil, err
+			}
+		}
+		uploadParams = append(uploadParams, params)
+
+		details, err := fileutils.GetFileDetails(file.LocalPath)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, buildinfo.Artifact{
+			Name:     path.Base(file.TargetPath),
+			Type:     strings.TrimPrefix(filepath.Ext(file.LocalPath), "."),
+			Path:     targetPathInRepo(file.TargetPath),
+			Checksum: &buildinfo.Checksum{Sha1: details.Checksum.Sha1, Md5: details.Checksum.Md5},
+		})
+	}
+	uploaded, failed, err := servicesManager.UploadFiles(uploadParams...)
+	if err != nil {
+		return nil, err
+	}
+	if failed > 0 || uploaded < len(files) {
+		return nil, errorutils.CheckError(errors.New("failed to deploy " + strconv.Itoa(len(files)-uploaded) + " of " + strconv.Itoa(len(files)) + " files"))
+	}
+	return artifacts, nil
+}
+
+func targetPathInRepo(targetPath string) string {
+	if index := strings.Index(targetPath, "/"); index >= 0 {
+		return targetPath[index+1:]
+	}
+	return targetPath
+}
+
+// Saves the artifacts of the module as a build-info partial.
+func SaveArtifacts(buildConfiguration *utils.BuildConfiguration, moduleType buildinfo.ModuleType, moduleId string, artifacts []buildinfo.Artifact) error {
+	return savePartial(buildConfiguration, func(partial *buildinfo.Partial) {
+		partial.ModuleType = moduleType
+		partial.ModuleId = moduleId
+		partial.Artifacts = artifacts
+	})
+}
+
+// Saves the dependencies of the module as a build-info partial.
+func SaveDependencies(buildConfiguration *utils.BuildConfiguration, moduleType buildinfo.ModuleType, moduleId string, dependencies []buildinfo.Dependency) error {
+	return savePartial(buildConfiguration, func(partial *buildinfo.Partial) {
+		partial.ModuleType = moduleType
+		partial.ModuleId = moduleId
+		partial.Dependencies = dependencies
+	})
+}
+
+// Build-publish reads either the artifacts or the dependencies of each partial, so they are always saved in separate partials.
+func savePartial(buildConfiguration *utils.BuildConfiguration, populateFunc func(partial *buildinfo.Partial)) error {
+	if err := utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project); err != nil {
+		return err
+	}
+	return utils.SavePartialBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project, populateFunc)
+}
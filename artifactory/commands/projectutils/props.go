@@ -0,0 +1,30 @@
+package projectutils
+
+import (
+	"github.com/jfrog/jfrog-client-go/artifactory"
+	"github.com/jfrog/jfrog-client-go/artifactory/services"
+	serviceutils "github.com/jfrog/jfrog-client-go/artifactory/services/utils"
+	"github.com/jfrog/jfrog-client-go/utils/io/content"
+)
+
+// Sets the properties, in the form of "key1=value1;key2=value2;...", on the files returned by FindItems.
+func SetProps(servicesManager artifactory.ArtifactoryServicesManager, items []AqlItem, props string) (err error) {
+	writer, err := content.NewContentWriter(content.DefaultKey, true, false)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		writer.Write(serviceutils.ResultItem{Repo: item.Repo, Path: item.Path, Name: item.Name, Type: "file"})
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	reader := content.NewContentReader(writer.GetFilePath(), content.DefaultKey)
+	defer func() {
+		if closeErr := reader.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	_, err = servicesManager.SetProps(services.PropsParams{Reader: reader, Props: props})
+	return err
+}
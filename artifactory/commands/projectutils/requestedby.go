@@ -0,0 +1,69 @@
+package projectutils
+
+import "strings"
+
+// The maximal number of requestedBy paths recorded for a dependency. A dependency required by many packages,
+// which are required by many others, is reached through a lot of paths, so the rest of the paths are dropped.
+const MaxRequestedByPaths = 10
+
+// Walks the dependency graph from the direct dependencies of the module, and calls visit with each dependency and
+// the path through which it was reached. A path starts with the direct dependent and ends with the module ID,
+// as expected by the requestedBy field of the build-info dependencies. As in 'npm ls', the dependencies of a package
+// are expanded only the first time the package is reached, so the paths of its other appearances end at it.
+// Circular dependencies are cut.
+func WalkDependencyGraph(moduleId string, direct []string, children func(id string) []string, visit func(id string, pathToRoot []string)) {
+	expanded := make(map[string]bool)
+	var walk func(id string, pathToRoot []string)
+	walk = func(id string, pathToRoot []string) {
+		visit(id, pathToRoot)
+		if expanded[id] {
+			return
+		}
+		expanded[id] = true
+		childPath := append([]string{id}, pathToRoot...)
+		for _, child := range children(id) {
+			if !ContainsString(childPath, child) {
+				walk(child, childPath)
+			}
+		}
+	}
+	for _, id := range direct {
+		walk(id, []string{moduleId})
+	}
+}
+
+// Returns the requestedBy paths of the dependencies of the module, by dependency ID.
+// At most MaxRequestedByPaths paths are recorded for each dependency.
+func RequestedBy(moduleId string, direct []string, children func(id string) []string) map[string][][]string {
+	requestedBy := make(map[string][][]string)
+	WalkDependencyGraph(moduleId, direct, children, func(id string, pathToRoot []string) {
+		requestedBy[id] = AppendRequestedBy(requestedBy[id], pathToRoot)
+	})
+	return requestedBy
+}
+
+// Appends the path to the requestedBy paths, unless it's listed already or there are MaxRequestedByPaths paths.
+func AppendRequestedBy(paths [][]string, path []string) [][]string {
+	if len(paths) >= MaxRequestedByPaths || ContainsPath(paths, path) {
+		return paths
+	}
+	return append(paths, path)
+}
+
+func ContainsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func ContainsPath(paths [][]string, path []string) bool {
+	for _, p := range paths {
+		if strings.Join(p, "\x00") == strings.Join(path, "\x00") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,275 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+type contextKey string
+
+// The context key of the forwarded request, which the response is modified by.
+const forwardedRequestKey contextKey = "forwardedRequest"
+
+// The details of a request forwarded to Artifactory.
+type forwardedRequest struct {
+	packageType string
+	route       *route
+	// The path of the requested file, relative to the repository API.
+	filePath string
+	// The URLs of the repository API in Artifactory and in the proxy.
+	remoteUrl string
+	localUrl  string
+}
+
+// Serves a local HTTP proxy, which forwards the requests of package managers to repositories in Artifactory,
+// with the credentials of the configured server. The proxy listens on the loopback interface only.
+// If the build name and number are set, the dependencies downloaded through the proxy are recorded as build-info
+// partials when the proxy is stopped.
+type ProxyServeCommand struct {
+	serverDetails      *config.ServerDetails
+	port               int
+	repos              map[string]string
+	buildConfiguration *utils.BuildConfiguration
+	recorder           *dependencyRecorder
+	reverseProxy       *httputil.ReverseProxy
+}
+
+func NewProxyServeCommand() *ProxyServeCommand {
+	return &ProxyServeCommand{repos: make(map[string]string)}
+}
+
+func (psc *ProxyServeCommand) SetServerDetails(serverDetails *config.ServerDetails) *ProxyServeCommand {
+	psc.serverDetails = serverDetails
+	return psc
+}
+
+func (psc *ProxyServeCommand) SetPort(port int) *ProxyServeCommand {
+	psc.port = port
+	return psc
+}
+
+// Sets the repository the requests of the package type are forwarded to. Package types without a repository aren't served.
+func (psc *ProxyServeCommand) SetRepo(packageType, repo string) *ProxyServeCommand {
+	if repo != "" {
+		psc.repos[packageType] = repo
+	}
+	return psc
+}
+
+func (psc *ProxyServeCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *ProxyServeCommand {
+	psc.buildConfiguration = buildConfiguration
+	return psc
+}
+
+func (psc *ProxyServeCommand) ServerDetails() (*config.ServerDetails, error) {
+	return psc.serverDetails, nil
+}
+
+func (psc *ProxyServeCommand) CommandName() string {
+	return "rt_proxy_serve"
+}
+
+func (psc *ProxyServeCommand) Run() error {
+	if len(psc.repos) == 0 {
+		return errorutils.CheckError(errors.New("no repository is set. Set the repository of at least one package type"))
+	}
+	if psc.buildConfiguration.BuildName != "" && psc.buildConfiguration.BuildNumber != "" {
+		psc.recorder = newDependencyRecorder()
+	}
+	psc.reverseProxy = &httputil.ReverseProxy{
+		Director:       func(req *http.Request) { req.Host = req.URL.Host },
+		ModifyResponse: psc.modifyResponse,
+	}
+	server := &http.Server{Addr: "127.0.0.1:" + strconv.Itoa(psc.port), Handler: psc}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	psc.logUsage()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	select {
+	case err := <-serverErr:
+		return errorutils.CheckError(err)
+	case <-signals:
+	}
+	log.Info("Stopping the proxy...")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		return errorutils.CheckError(err)
+	}
+	if psc.recorder == nil {
+		return nil
+	}
+	return psc.recorder.save(psc.buildConfiguration)
+}
+
+func (psc *ProxyServeCommand) logUsage() {
+	baseUrl := "http://localhost:" + strconv.Itoa(psc.port) + "/"
+	usage := map[string]string{
+		Go:    "GOPROXY=" + baseUrl + Go,
+		Npm:   "npm config set registry " + baseUrl + Npm + "/",
+		Pypi:  "pip install --index-url " + baseUrl + Pypi + "/simple",
+		Maven: "A mirror of * in settings.xml with the URL " + baseUrl + Maven + "/",
+	}
+	var lines []string
+	for packageType, repo := range psc.repos {
+		lines = append(lines, fmt.Sprintf("%s (%s): %s", packageType, repo, usage[packageType]))
+	}
+	sort.Strings(lines)
+	log.Info("Serving the Artifactory repositories on " + baseUrl + ". Press Ctrl+C to stop.\n" + strings.Join(lines, "\n"))
+}
+
+// Forwards /<package type>/<file path> to the repository of the package type.
+// Only downloads are forwarded, and only requests addressed to the proxy on the loopback interface are served,
+// so that other sites in the browser can't reach Artifactory with the credentials of the server through the proxy.
+func (psc *ProxyServeCommand) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodHead)
+		http.Error(w, "Method "+req.Method+" is not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !psc.isLocalHost(req.Host) {
+		http.Error(w, "Host "+req.Host+" is not served by the proxy", http.StatusForbidden)
+		return
+	}
+	escapedPath := strings.TrimPrefix(req.URL.EscapedPath(), "/")
+	packageType, escapedFilePath := escapedPath, ""
+	if i := strings.Index(escapedPath, "/"); i >= 0 {
+		packageType, escapedFilePath = escapedPath[:i], escapedPath[i+1:]
+	}
+	repo, exists := psc.repos[packageType]
+	if !exists {
+		http.Error(w, "No repository is served under /"+packageType+"/", http.StatusNotFound)
+		return
+	}
+	filePath, err := url.PathUnescape(escapedFilePath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if hasParentSegment(filePath) {
+		http.Error(w, "The path must not contain '..' segments", http.StatusBadRequest)
+		return
+	}
+	route := routes[packageType]
+	remoteUrl := psc.serverDetails.GetArtifactoryUrl() + route.repoPath(repo)
+	target, err := url.Parse(remoteUrl + escapedFilePath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	log.Debug(req.Method, req.URL.Path, "->", target.String())
+	target.RawQuery = req.URL.RawQuery
+	req.URL = target
+	if err = psc.setAuth(req); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if route.rewriteUrls {
+		// Let the transport decompress the responses, so that their URLs can be rewritten.
+		req.Header.Del("Accept-Encoding")
+	}
+	forwarded := &forwardedRequest{
+		packageType: packageType,
+		route:       route,
+		filePath:    filePath,
+		remoteUrl:   remoteUrl,
+		localUrl:    "http://" + req.Host + "/" + packageType + "/",
+	}
+	psc.reverseProxy.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), forwardedRequestKey, forwarded)))
+}
+
+// Returns true if the host is localhost or 127.0.0.1 with the port of the proxy.
+func (psc *ProxyServeCommand) isLocalHost(host string) bool {
+	port := strconv.Itoa(psc.port)
+	return host == "localhost:"+port || host == "127.0.0.1:"+port
+}
+
+// Returns true if the path contains a '..' segment, which could escape the repository of the package type.
+func hasParentSegment(path string) bool {
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// Replaces the credentials of the client, if any, with the credentials of the server.
+func (psc *ProxyServeCommand) setAuth(req *http.Request) error {
+	req.Header.Del("Authorization")
+	if psc.serverDetails.GetAccessToken() != "" && psc.serverDetails.GetUser() == "" {
+		req.Header.Set("Authorization", "Bearer "+psc.serverDetails.GetAccessToken())
+		return nil
+	}
+	username, password, err := projectutils.GetBasicAuthCredentials(psc.serverDetails)
+	if err != nil {
+		return err
+	}
+	if username != "" || password != "" {
+		req.SetBasicAuth(username, password)
+	}
+	return nil
+}
+
+func (psc *ProxyServeCommand) modifyResponse(resp *http.Response) error {
+	forwarded, ok := resp.Request.Context().Value(forwardedRequestKey).(*forwardedRequest)
+	if !ok {
+		return nil
+	}
+	if forwarded.route.rewriteUrls && strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		if err := rewriteUrls(resp, forwarded.remoteUrl, forwarded.localUrl); err != nil {
+			return err
+		}
+	}
+	if psc.recorder != nil && resp.Request.Method == http.MethodGet && resp.StatusCode == http.StatusOK {
+		if id, fileType := forwarded.route.parseDependency(forwarded.filePath); id != "" {
+			dependency := buildinfo.Dependency{Id: id, Type: fileType}
+			if sha1 := resp.Header.Get("X-Checksum-Sha1"); sha1 != "" {
+				dependency.Checksum = &buildinfo.Checksum{Sha1: sha1, Md5: resp.Header.Get("X-Checksum-Md5")}
+			}
+			psc.recorder.record(forwarded.packageType, dependency)
+		}
+	}
+	return nil
+}
+
+// Replaces the URLs of the repository in the response body with the URLs of the proxy.
+func rewriteUrls(resp *http.Response, remoteUrl, localUrl string) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	if err = resp.Body.Close(); err != nil {
+		return errorutils.CheckError(err)
+	}
+	body = bytes.Replace(body, []byte(remoteUrl), []byte(localUrl), -1)
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return nil
+}
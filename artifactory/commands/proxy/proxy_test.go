@@ -0,0 +1,140 @@
+package proxy
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDependency(t *testing.T) {
+	for _, test := range []struct {
+		packageType, filePath, id, fileType string
+	}{
+		{Go, "github.com/!burnt!sushi/toml/@v/v1.2.0.zip", "github.com/BurntSushi/toml:v1.2.0", "zip"},
+		{Go, "github.com/!burnt!sushi/toml/@v/v1.2.0.mod", "", ""},
+		{Npm, "@babel/core/-/core-7.22.0.tgz", "@babel/core:7.22.0", "tgz"},
+		{Npm, "@babel/core", "", ""},
+		{Pypi, "packages/packages/ab/cd/requests-2.31.0-py3-none-any.whl", "requests-2.31.0-py3-none-any.whl", "whl"},
+		{Pypi, "simple/requests/", "", ""},
+		{Maven, "org/apache/commons/commons-lang3/3.12.0/commons-lang3-3.12.0.jar", "org.apache.commons:commons-lang3:3.12.0", "jar"},
+		{Maven, "org/apache/commons/commons-lang3/3.12.0/commons-lang3-3.12.0.pom", "", ""},
+		{Maven, "org/apache/commons/commons-lang3/maven-metadata.xml", "", ""},
+	} {
+		id, fileType := routes[test.packageType].parseDependency(test.filePath)
+		assert.Equal(t, test.id, id, test.filePath)
+		assert.Equal(t, test.fileType, fileType, test.filePath)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	var artifactoryUrl string
+	artifactory := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		username, password, _ := req.BasicAuth()
+		if username != "admin" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch req.URL.EscapedPath() {
+		case "/artifactory/api/npm/npm-virtual/@scope%2fpkg":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"dist":{"tarball":"` + artifactoryUrl + `api/npm/npm-virtual/@scope/pkg/-/pkg-1.0.0.tgz"}}`))
+		case "/artifactory/api/npm/npm-virtual/@scope/pkg/-/pkg-1.0.0.tgz":
+			w.Header().Set("X-Checksum-Sha1", "sha1")
+			w.Header().Set("X-Checksum-Md5", "md5")
+			w.Write([]byte("tarball"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer artifactory.Close()
+	artifactoryUrl = artifactory.URL + "/artifactory/"
+
+	psc := NewProxyServeCommand().SetRepo(Npm, "npm-virtual").SetServerDetails(&config.ServerDetails{ArtifactoryUrl: artifactoryUrl, User: "admin", Password: "password"})
+	psc.recorder = newDependencyRecorder()
+	psc.reverseProxy = &httputil.ReverseProxy{Director: func(req *http.Request) { req.Host = req.URL.Host }, ModifyResponse: psc.modifyResponse}
+	proxy := httptest.NewServer(psc)
+	defer proxy.Close()
+	proxyUrl, err := url.Parse(proxy.URL)
+	assert.NoError(t, err)
+	psc.port, err = strconv.Atoi(proxyUrl.Port())
+	assert.NoError(t, err)
+
+	// The credentials of the client are replaced, and the tarball URL points to the proxy.
+	req, err := http.NewRequest(http.MethodGet, proxy.URL+"/npm/@scope%2fpkg", nil)
+	assert.NoError(t, err)
+	req.SetBasicAuth("client", "secret")
+	body := getBody(t, req, http.StatusOK)
+	assert.Equal(t, `{"dist":{"tarball":"`+proxy.URL+`/npm/@scope/pkg/-/pkg-1.0.0.tgz"}}`, body)
+
+	req, err = http.NewRequest(http.MethodGet, proxy.URL+"/npm/@scope/pkg/-/pkg-1.0.0.tgz", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "tarball", getBody(t, req, http.StatusOK))
+	assert.Equal(t, map[string]buildinfo.Dependency{
+		"@scope/pkg:1.0.0/tgz": {Id: "@scope/pkg:1.0.0", Type: "tgz", Checksum: &buildinfo.Checksum{Sha1: "sha1", Md5: "md5"}},
+	}, psc.recorder.dependencies[Npm])
+
+	// Package types without a repository aren't served.
+	req, err = http.NewRequest(http.MethodGet, proxy.URL+"/go/github.com/pkg/errors/@v/list", nil)
+	assert.NoError(t, err)
+	getBody(t, req, http.StatusNotFound)
+
+	// Only downloads are forwarded.
+	req, err = http.NewRequest(http.MethodPut, proxy.URL+"/npm/@scope/pkg/-/pkg-1.0.0.tgz", nil)
+	assert.NoError(t, err)
+	getBody(t, req, http.StatusMethodNotAllowed)
+
+	// Paths escaping the repository are rejected, also when escaped.
+	for _, path := range []string{"/npm/../api/security/token", "/npm/%2e%2e/api/security/token", "/npm/@scope/%2E%2E%2f..%2fapi"} {
+		req, err = http.NewRequest(http.MethodGet, proxy.URL+path, nil)
+		assert.NoError(t, err)
+		getBody(t, req, http.StatusBadRequest)
+	}
+
+	// Requests addressed to other hosts, such as rebound DNS names, are rejected.
+	req, err = http.NewRequest(http.MethodGet, proxy.URL+"/npm/@scope/pkg/-/pkg-1.0.0.tgz", nil)
+	assert.NoError(t, err)
+	req.Host = "attacker.example:" + proxyUrl.Port()
+	getBody(t, req, http.StatusForbidden)
+	req.Host = "localhost:" + proxyUrl.Port()
+	assert.Equal(t, "tarball", getBody(t, req, http.StatusOK))
+}
+
+func TestSaveRecordedDependencies(t *testing.T) {
+	recorder := newDependencyRecorder()
+	recorder.record(Go, buildinfo.Dependency{Id: "b:v1", Type: "zip"})
+	recorder.record(Go, buildinfo.Dependency{Id: "a:v1", Type: "zip"})
+	recorder.record(Go, buildinfo.Dependency{Id: "a:v1", Type: "zip"})
+	assert.Len(t, recorder.dependencies[Go], 2)
+
+	buildConfiguration := &utils.BuildConfiguration{BuildName: "proxy-test", BuildNumber: "1"}
+	assert.NoError(t, recorder.save(buildConfiguration))
+	defer utils.RemoveBuildDir(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project)
+	partials, err := utils.ReadPartialBuildInfoFiles(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project)
+	assert.NoError(t, err)
+	if assert.Len(t, partials, 1) {
+		assert.Equal(t, "proxy-test:go", partials[0].ModuleId)
+		assert.Equal(t, buildinfo.Go, partials[0].ModuleType)
+		assert.Equal(t, "a:v1", partials[0].Dependencies[0].Id)
+	}
+}
+
+func getBody(t *testing.T, req *http.Request, expectedStatus int) string {
+	resp, err := http.DefaultClient.Do(req)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, expectedStatus, resp.StatusCode)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
@@ -0,0 +1,66 @@
+package proxy
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// Records the dependencies downloaded through the proxy, for every package type. The requests are served concurrently.
+type dependencyRecorder struct {
+	mutex        sync.Mutex
+	dependencies map[string]map[string]buildinfo.Dependency
+}
+
+func newDependencyRecorder() *dependencyRecorder {
+	return &dependencyRecorder{dependencies: make(map[string]map[string]buildinfo.Dependency)}
+}
+
+func (dr *dependencyRecorder) record(packageType string, dependency buildinfo.Dependency) {
+	dr.mutex.Lock()
+	defer dr.mutex.Unlock()
+	if dr.dependencies[packageType] == nil {
+		dr.dependencies[packageType] = make(map[string]buildinfo.Dependency)
+	}
+	key := dependency.Id + "/" + dependency.Type
+	if _, exists := dr.dependencies[packageType][key]; !exists {
+		log.Debug("Recording the", packageType, "dependency", dependency.Id)
+		dr.dependencies[packageType][key] = dependency
+	}
+}
+
+// Saves the dependencies of every package type as a build-info partial of its own module, named <module>:<package type>.
+// The module defaults to the build name.
+func (dr *dependencyRecorder) save(buildConfiguration *utils.BuildConfiguration) error {
+	dr.mutex.Lock()
+	defer dr.mutex.Unlock()
+	module := buildConfiguration.Module
+	if module == "" {
+		module = buildConfiguration.BuildName
+	}
+	var packageTypes []string
+	for packageType := range dr.dependencies {
+		packageTypes = append(packageTypes, packageType)
+	}
+	sort.Strings(packageTypes)
+	for _, packageType := range packageTypes {
+		var dependencies []buildinfo.Dependency
+		for _, dependency := range dr.dependencies[packageType] {
+			dependencies = append(dependencies, dependency)
+		}
+		sort.Slice(dependencies, func(i, j int) bool {
+			return dependencies[i].Id+dependencies[i].Type < dependencies[j].Id+dependencies[j].Type
+		})
+		moduleId := module + ":" + packageType
+		log.Info("Adding", strconv.Itoa(len(dependencies)), "dependencies to module", moduleId, "of the build-info.")
+		if err := projectutils.SaveDependencies(buildConfiguration, routes[packageType].moduleType, moduleId, dependencies); err != nil {
+			return err
+		}
+	}
+	return nil
+}
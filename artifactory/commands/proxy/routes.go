@@ -0,0 +1,135 @@
+package proxy
+
+import (
+	"path"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+)
+
+// The package types served by the proxy. The requests of every package type are served under /<package type>/.
+const (
+	Go    = "go"
+	Npm   = "npm"
+	Pypi  = "pypi"
+	Maven = "maven"
+)
+
+// Forwards the requests of a package type to a repository in Artifactory.
+type route struct {
+	moduleType buildinfo.ModuleType
+	// Returns the path of the repository API, relative to the Artifactory URL.
+	repoPath func(repo string) string
+	// Returns the ID and the type of the dependency downloaded from the path, relative to the repository API.
+	// An empty ID is returned for metadata files and indexes, which aren't dependencies.
+	parseDependency func(filePath string) (id, fileType string)
+	// True if the responses include URLs of the repository, which are rewritten to URLs of the proxy,
+	// so that the client keeps downloading through the proxy.
+	rewriteUrls bool
+}
+
+var routes = map[string]*route{
+	Go: {
+		moduleType:      buildinfo.Go,
+		repoPath:        func(repo string) string { return "api/go/" + repo + "/" },
+		parseDependency: parseGoDependency,
+	},
+	Npm: {
+		moduleType:      buildinfo.Npm,
+		repoPath:        func(repo string) string { return "api/npm/" + repo + "/" },
+		parseDependency: parseNpmDependency,
+		rewriteUrls:     true,
+	},
+	Pypi: {
+		moduleType:      buildinfo.Pip,
+		repoPath:        func(repo string) string { return "api/pypi/" + repo + "/" },
+		parseDependency: parsePypiDependency,
+	},
+	Maven: {
+		moduleType:      buildinfo.Maven,
+		repoPath:        func(repo string) string { return repo + "/" },
+		parseDependency: parseMavenDependency,
+	},
+}
+
+// Go modules are downloaded as <module>/@v/<version>.zip, with the upper case letters of the module path escaped as '!' and the lower case letter.
+func parseGoDependency(filePath string) (id, fileType string) {
+	i := strings.LastIndex(filePath, "/@v/")
+	if i < 0 || !strings.HasSuffix(filePath, ".zip") {
+		return "", ""
+	}
+	return unescapeGoPath(filePath[:i]) + ":" + unescapeGoPath(strings.TrimSuffix(filePath[i+len("/@v/"):], ".zip")), "zip"
+}
+
+func unescapeGoPath(escaped string) string {
+	var unescaped strings.Builder
+	upper := false
+	for _, r := range escaped {
+		switch {
+		case r == '!':
+			upper = true
+		case upper:
+			unescaped.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			unescaped.WriteRune(r)
+		}
+	}
+	return unescaped.String()
+}
+
+// npm packages are downloaded as [@<scope>/]<name>/-/<name>-<version>.tgz.
+func parseNpmDependency(filePath string) (id, fileType string) {
+	i := strings.LastIndex(filePath, "/-/")
+	if i < 0 || !strings.HasSuffix(filePath, ".tgz") {
+		return "", ""
+	}
+	name := filePath[:i]
+	fileName := filePath[i+len("/-/"):]
+	version := strings.TrimSuffix(strings.TrimPrefix(fileName, path.Base(name)+"-"), ".tgz")
+	return name + ":" + version, "tgz"
+}
+
+// Python distributions are downloaded from the packages path of the repository. The simple index pages aren't dependencies.
+func parsePypiDependency(filePath string) (id, fileType string) {
+	if !strings.HasPrefix(filePath, "packages/") {
+		return "", ""
+	}
+	fileName := path.Base(filePath)
+	fileType = fileExtension(fileName)
+	switch fileType {
+	case "whl", "tar.gz", "zip", "egg", "tar.bz2":
+		return fileName, fileType
+	}
+	return "", ""
+}
+
+// Maven files are downloaded as <group path>/<artifact>/<version>/<artifact>-<version>[-<classifier>].<extension>.
+// The POMs, the metadata files and the checksum and signature files aren't recorded.
+func parseMavenDependency(filePath string) (id, fileType string) {
+	parts := strings.Split(filePath, "/")
+	if len(parts) < 4 {
+		return "", ""
+	}
+	fileName := parts[len(parts)-1]
+	version := parts[len(parts)-2]
+	artifact := parts[len(parts)-3]
+	if !strings.HasPrefix(fileName, artifact+"-") {
+		return "", ""
+	}
+	fileType = fileExtension(fileName)
+	switch fileType {
+	case "pom", "xml", "sha1", "sha256", "sha512", "md5", "asc":
+		return "", ""
+	}
+	return strings.Join(parts[:len(parts)-3], ".") + ":" + artifact + ":" + version, fileType
+}
+
+func fileExtension(fileName string) string {
+	for _, doubleExtension := range []string{".tar.gz", ".tar.bz2"} {
+		if strings.HasSuffix(fileName, doubleExtension) {
+			return strings.TrimPrefix(doubleExtension, ".")
+		}
+	}
+	return strings.TrimPrefix(path.Ext(fileName), ".")
+}
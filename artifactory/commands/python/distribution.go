@@ -0,0 +1,66 @@
+package python
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+var (
+	// The extensions of the distribution files, which can be deployed to a PyPI repository.
+	sdistExtensions   = []string{".tar.gz", ".tar.bz2", ".zip"}
+	nameSeparatorsExp = regexp.MustCompile(`[-_.]+`)
+)
+
+// The name and version of a Python package distribution, as extracted from its file name.
+type Distribution struct {
+	FileName string
+	Name     string
+	Version  string
+}
+
+// Returns the normalized name of the package, as defined by PEP 503.
+func (dist *Distribution) NormalizedName() string {
+	return NormalizePackageName(dist.Name)
+}
+
+func NormalizePackageName(name string) string {
+	return strings.ToLower(nameSeparatorsExp.ReplaceAllString(name, "-"))
+}
+
+// Parses the file name of a wheel (<name>-<version>(-<build>)?-<python>-<abi>-<platform>.whl),
+// an egg (<name>-<version>(-<python>)?.egg) or a source distribution (<name>-<version>.tar.gz).
+func ParseDistributionFileName(fileName string) (*Distribution, error) {
+	var name, version string
+	switch {
+	case strings.HasSuffix(fileName, ".whl"):
+		parts := strings.Split(strings.TrimSuffix(fileName, ".whl"), "-")
+		if len(parts) < 5 {
+			return nil, errorutils.CheckError(errors.New("invalid wheel file name: " + fileName))
+		}
+		name, version = parts[0], parts[1]
+	case strings.HasSuffix(fileName, ".egg"):
+		parts := strings.Split(strings.TrimSuffix(fileName, ".egg"), "-")
+		if len(parts) < 2 {
+			return nil, errorutils.CheckError(errors.New("invalid egg file name: " + fileName))
+		}
+		name, version = parts[0], parts[1]
+	default:
+		baseName := ""
+		for _, extension := range sdistExtensions {
+			if strings.HasSuffix(fileName, extension) {
+				baseName = strings.TrimSuffix(fileName, extension)
+				break
+			}
+		}
+		// The name of a source distribution may contain dashes, so the version is taken after the last one.
+		index := strings.LastIndex(baseName, "-")
+		if index <= 0 || index == len(baseName)-1 {
+			return nil, errorutils.CheckError(errors.New("not a Python distribution file: " + fileName))
+		}
+		name, version = baseName[:index], baseName[index+1:]
+	}
+	return &Distribution{FileName: fileName, Name: name, Version: version}, nil
+}
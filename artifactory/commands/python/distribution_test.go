@@ -0,0 +1,39 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDistributionFileName(t *testing.T) {
+	tests := []struct {
+		fileName        string
+		expectedName    string
+		expectedVersion string
+	}{
+		{"requests-2.25.1-py2.py3-none-any.whl", "requests", "2.25.1"},
+		{"my_package-1.0.0-1-cp39-cp39-manylinux1_x86_64.whl", "my_package", "1.0.0"},
+		{"my-package-1.0.0.tar.gz", "my-package", "1.0.0"},
+		{"package-0.1.zip", "package", "0.1"},
+		{"package-0.1-py3.8.egg", "package", "0.1"},
+	}
+	for _, test := range tests {
+		t.Run(test.fileName, func(t *testing.T) {
+			dist, err := ParseDistributionFileName(test.fileName)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedName, dist.Name)
+			assert.Equal(t, test.expectedVersion, dist.Version)
+		})
+	}
+	for _, fileName := range []string{"README.md", "package.whl", "package.tar.gz", "package-.tar.gz"} {
+		_, err := ParseDistributionFileName(fileName)
+		assert.Error(t, err, fileName)
+	}
+}
+
+func TestNormalizePackageName(t *testing.T) {
+	assert.Equal(t, "my-package", NormalizePackageName("My_Package"))
+	assert.Equal(t, "zope-interface", NormalizePackageName("zope.interface"))
+	assert.Equal(t, "a-b", NormalizePackageName("a-_.b"))
+}
@@ -0,0 +1,325 @@
+package python
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corepip "github.com/jfrog/jfrog-cli-core/artifactory/commands/pip"
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	piputils "github.com/jfrog/jfrog-cli-core/artifactory/utils/pip"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The first pip version, which supports the '--report' option of 'pip install'.
+const (
+	minReportPipMajor = 22
+	minReportPipMinor = 2
+)
+
+var pipVersionRegexp = regexp.MustCompile(`^pip (\d+)\.(\d+)`)
+
+// Runs pip install with the dependencies resolved from Artifactory. If the build name and number are passed,
+// all of the installed distributions, including the transitive ones, are recorded in the build-info,
+// as listed in the install report of pip. With pip versions that don't support the report, the command of jfrog-cli-core,
+// which parses the output of pip, is used instead.
+// In a dry run, the distributions are resolved and recorded without installing them.
+type PipInstallCommand struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	args          []string
+	dryRun        bool
+}
+
+func NewPipInstallCommand() *PipInstallCommand {
+	return &PipInstallCommand{}
+}
+
+func (pic *PipInstallCommand) SetServerDetails(serverDetails *config.ServerDetails) *PipInstallCommand {
+	pic.serverDetails = serverDetails
+	return pic
+}
+
+func (pic *PipInstallCommand) SetRepo(repo string) *PipInstallCommand {
+	pic.repo = repo
+	return pic
+}
+
+func (pic *PipInstallCommand) SetArgs(args []string) *PipInstallCommand {
+	pic.args = args
+	return pic
+}
+
+func (pic *PipInstallCommand) SetDryRun(dryRun bool) *PipInstallCommand {
+	pic.dryRun = dryRun
+	return pic
+}
+
+func (pic *PipInstallCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pic.serverDetails, nil
+}
+
+func (pic *PipInstallCommand) CommandName() string {
+	return "rt_pip_install"
+}
+
+func (pic *PipInstallCommand) Run() error {
+	args, buildConfiguration, err := utils.ExtractBuildDetailsFromArgs(pic.args)
+	if err != nil {
+		return err
+	}
+	if buildConfiguration.BuildName == "" || buildConfiguration.BuildNumber == "" {
+		if pic.dryRun {
+			return errorutils.CheckError(errors.New("a dry run of pip install requires the build name and number, to which the distributions are recorded"))
+		}
+		return pic.runCorePipInstall()
+	}
+	pipPath, err := piputils.GetExecutablePath("pip")
+	if err != nil {
+		return err
+	}
+	supported, err := isInstallReportSupported(pipPath)
+	if err != nil {
+		return err
+	}
+	if !supported && pic.dryRun {
+		return errorutils.CheckError(fmt.Errorf("pip %d.%d or above is required for resolving the dependencies without installing them", minReportPipMajor, minReportPipMinor))
+	}
+	if !supported {
+		log.Warn(fmt.Sprintf("pip %d.%d or above is required for recording the checksums and the requestedBy paths of the dependencies. "+
+			"Falling back to parsing the output of pip.", minReportPipMajor, minReportPipMinor))
+		return pic.runCorePipInstall()
+	}
+
+	log.Info("Running pip Install.")
+	if err = utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project); err != nil {
+		return err
+	}
+	reportDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer fileutils.RemoveTempDir(reportDir)
+	var report *InstallReport
+	if pic.dryRun {
+		report, err = pic.runPipDryRun(pipPath, args, reportDir)
+	} else {
+		report, err = pic.runPipInstall(pipPath, args, reportDir)
+	}
+	if err != nil {
+		return err
+	}
+	if err = pic.saveBuildInfo(report, buildConfiguration); err != nil {
+		return err
+	}
+	log.Info("pip install finished successfully.")
+	return nil
+}
+
+func (pic *PipInstallCommand) runCorePipInstall() error {
+	pipCmd := corepip.NewPipInstallCommand()
+	pipCmd.SetServerDetails(pic.serverDetails).SetRepo(pic.repo).SetArgs(pic.args)
+	return pipCmd.Run()
+}
+
+func isInstallReportSupported(pipPath string) (bool, error) {
+	output, err := exec.Command(pipPath, "--version").Output()
+	if err != nil {
+		return false, errorutils.CheckError(err)
+	}
+	match := pipVersionRegexp.FindStringSubmatch(string(output))
+	if match == nil {
+		log.Debug("Couldn't parse the pip version from:", string(output))
+		return false, nil
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return major > minReportPipMajor || (major == minReportPipMajor && minor >= minReportPipMinor), nil
+}
+
+// Runs pip install, and returns the required distributions with their installed versions.
+// The report of the install itself lists only the distributions, which weren't installed already, so the required
+// distributions are listed by a dry run, which ignores the installed distributions. Since the dry run resolves the
+// requirements again, the versions are taken from the report of the install and from the installed distributions.
+func (pic *PipInstallCommand) runPipInstall(pipPath string, args []string, reportDir string) (*InstallReport, error) {
+	indexUrl, err := pic.indexUrl()
+	if err != nil {
+		return nil, err
+	}
+	installReportPath := filepath.Join(reportDir, "install.json")
+	cmd := exec.Command(pipPath, append(createPipInstallArgs(args, indexUrl), "--report", installReportPath)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err = cmd.Run(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	installReport, err := ReadInstallReport(installReportPath)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Writing the pip install report of the requirements...")
+	requiredReportPath := filepath.Join(reportDir, "required.json")
+	cmd = exec.Command(pipPath, createPipReportArgs(args, indexUrl, requiredReportPath)...)
+	cmd.Stderr = os.Stderr
+	if err = cmd.Run(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	requiredReport, err := ReadInstallReport(requiredReportPath)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("Listing the installed distributions...")
+	cmd = exec.Command(pipPath, "inspect")
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	inspectReport, err := ParseInspectReport(output)
+	if err != nil {
+		return nil, err
+	}
+	return requiredReport.WithInstalledVersions(installReport, inspectReport), nil
+}
+
+// Runs pip install in a dry run, which resolves the requirements without installing them, and returns its report.
+func (pic *PipInstallCommand) runPipDryRun(pipPath string, args []string, reportDir string) (*InstallReport, error) {
+	indexUrl, err := pic.indexUrl()
+	if err != nil {
+		return nil, err
+	}
+	reportPath := filepath.Join(reportDir, "required.json")
+	cmd := exec.Command(pipPath, createPipReportArgs(args, indexUrl, reportPath)...)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err = cmd.Run(); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return ReadInstallReport(reportPath)
+}
+
+func createPipInstallArgs(args []string, indexUrl string) []string {
+	return append(append([]string{"install"}, args...), "-i", indexUrl)
+}
+
+func createPipReportArgs(args []string, indexUrl, reportPath string) []string {
+	return append(createPipInstallArgs(args, indexUrl), "--dry-run", "--ignore-installed", "--quiet", "--report", reportPath)
+}
+
+func (pic *PipInstallCommand) indexUrl() (string, error) {
+	rtUrl, err := url.Parse(pic.serverDetails.GetArtifactoryUrl())
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	username, password, err := projectutils.GetBasicAuthCredentials(pic.serverDetails)
+	if err != nil {
+		return "", err
+	}
+	if username != "" && password != "" {
+		rtUrl.User = url.UserPassword(username, password)
+	}
+	rtUrl.Path += "api/pypi/" + pic.repo + "/simple"
+	return rtUrl.String(), nil
+}
+
+func (pic *PipInstallCommand) saveBuildInfo(report *InstallReport, buildConfiguration *utils.BuildConfiguration) error {
+	servicesManager, err := utils.CreateServiceManager(pic.serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	searchRepo, err := utils.GetRepoNameForDependenciesSearch(pic.repo, servicesManager)
+	if err != nil {
+		return err
+	}
+	var sha256s []string
+	for _, dist := range report.Install {
+		if sha256 := dist.Sha256(); sha256 != "" {
+			sha256s = append(sha256s, sha256)
+		}
+	}
+	foundFiles, err := projectutils.FindFilesBySha256(servicesManager, searchRepo, sha256s)
+	if err != nil {
+		return err
+	}
+	moduleId, err := getModuleId(buildConfiguration)
+	if err != nil {
+		return err
+	}
+	module, missing := createPipModule(moduleId, report, foundFiles)
+	if len(missing) > 0 {
+		log.Warn("The following distributions were not found in " + searchRepo + ", so their sha1 and md5 checksums are missing from the build-info:\n" +
+			strings.Join(missing, "\n"))
+	}
+	log.Info("Adding", strconv.Itoa(len(module.Dependencies)), "dependencies to module", moduleId, "of the build-info.")
+	return utils.SaveBuildInfo(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project, &buildinfo.BuildInfo{Modules: []buildinfo.Module{*module}})
+}
+
+// Creates the build-info module of the installed distributions, with the sha256 checksums reported by pip as module hashes.
+// The names of the distributions, which weren't found in Artifactory, are returned.
+func createPipModule(moduleId string, report *InstallReport, foundFiles map[string]*projectutils.FoundFile) (module *buildinfo.Module, missing []string) {
+	module = &buildinfo.Module{Id: moduleId, Type: buildinfo.Pip}
+	requestedBy := report.RequestedBy(moduleId)
+	sha256s := make(projectutils.ModuleHashes)
+	for _, dist := range report.sortedInstall() {
+		dependency := buildinfo.Dependency{Id: dist.FileName(), RequestedBy: requestedBy[dist.FileName()]}
+		if sha256 := dist.Sha256(); sha256 != "" {
+			sha256s.Add("sha256", dependency.Id, sha256)
+			if file, exists := foundFiles[sha256]; exists {
+				dependency.Checksum = file.Checksum
+			}
+		}
+		if dependency.Checksum == nil {
+			missing = append(missing, dependency.Id)
+		}
+		module.Dependencies = append(module.Dependencies, dependency)
+	}
+	sha256s.SetProperties(module)
+	return
+}
+
+// Returns the module ID passed with the --module option, or the name of the package in setup.py or pyproject.toml.
+// If none of them is set, the build name is returned.
+func getModuleId(buildConfiguration *utils.BuildConfiguration) (string, error) {
+	if buildConfiguration.Module != "" {
+		return buildConfiguration.Module, nil
+	}
+	name, err := readSetupPyName()
+	if err != nil || name != "" {
+		return name, err
+	}
+	exists, err := fileutils.IsFileExists(pyprojectFile, false)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		if name, err = ReadPyprojectName(pyprojectFile); err != nil || name != "" {
+			return name, err
+		}
+	}
+	return buildConfiguration.BuildName, nil
+}
+
+func readSetupPyName() (string, error) {
+	setupPyPath, err := filepath.Abs("setup.py")
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	exists, err := fileutils.IsFileExists(setupPyPath, false)
+	if err != nil || !exists {
+		return "", err
+	}
+	pythonPath, err := piputils.GetExecutablePath("python")
+	if err != nil {
+		return "", err
+	}
+	return piputils.ExtractPackageNameFromSetupPy(setupPyPath, pythonPath)
+}
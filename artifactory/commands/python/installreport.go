@@ -0,0 +1,167 @@
+package python
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+// The name at the beginning of a requirement specifier, such as 'requests[socks] (>=2.0) ; extra == "http"'.
+var requirementNameRegexp = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+// The JSON report written by 'pip install --report', which is supported since pip 22.2.
+type InstallReport struct {
+	Install []*InstalledDistribution `json:"install"`
+}
+
+type InstalledDistribution struct {
+	DownloadInfo struct {
+		Url         string `json:"url"`
+		ArchiveInfo struct {
+			Hash   string            `json:"hash"`
+			Hashes map[string]string `json:"hashes"`
+		} `json:"archive_info"`
+	} `json:"download_info"`
+	// True if the distribution was requested by the user, rather than by another distribution.
+	Requested bool `json:"requested"`
+	Metadata  struct {
+		Name         string   `json:"name"`
+		Version      string   `json:"version"`
+		RequiresDist []string `json:"requires_dist"`
+	} `json:"metadata"`
+}
+
+func ReadInstallReport(reportPath string) (*InstallReport, error) {
+	content, err := ioutil.ReadFile(reportPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	report := &InstallReport{}
+	if err = json.Unmarshal(content, report); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return report, nil
+}
+
+// The JSON output of 'pip inspect', which lists the distributions installed in the environment.
+// It's supported since pip 22.2, like the install report.
+type InspectReport struct {
+	Installed []*InstalledDistribution `json:"installed"`
+}
+
+func ParseInspectReport(content []byte) (*InspectReport, error) {
+	report := &InspectReport{}
+	if err := json.Unmarshal(content, report); err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return report, nil
+}
+
+// Returns the required distributions with the versions installed in the environment. The report of the required
+// distributions is written by a dry run, which resolves the requirements again, so it may list other versions than
+// the installed ones. A distribution is taken from the report of the install if it was installed by it, or from
+// the report of the required distributions if the installed version is the same. Otherwise, the installed
+// distribution is taken, without the file it was installed from and its checksum.
+func (report *InstallReport) WithInstalledVersions(installReport *InstallReport, inspectReport *InspectReport) *InstallReport {
+	newlyInstalled := make(map[string]*InstalledDistribution, len(installReport.Install))
+	for _, dist := range installReport.Install {
+		newlyInstalled[NormalizePackageName(dist.Metadata.Name)] = dist
+	}
+	installed := make(map[string]*InstalledDistribution, len(inspectReport.Installed))
+	for _, dist := range inspectReport.Installed {
+		installed[NormalizePackageName(dist.Metadata.Name)] = dist
+	}
+	result := &InstallReport{}
+	for _, required := range report.Install {
+		name := NormalizePackageName(required.Metadata.Name)
+		dist, exists := newlyInstalled[name]
+		if !exists {
+			if dist, exists = installed[name]; !exists {
+				log.Debug("The required distribution", required.Metadata.Name, "is not installed.")
+				continue
+			}
+			if dist.Metadata.Version == required.Metadata.Version {
+				dist = required
+			}
+		}
+		// The distributions listed by 'pip inspect' are marked as requested if they were ever requested by the user.
+		selected := *dist
+		selected.Requested = required.Requested
+		result.Install = append(result.Install, &selected)
+	}
+	return result
+}
+
+// Returns the name of the file the distribution was installed from.
+func (dist *InstalledDistribution) FileName() string {
+	if parsedUrl, err := url.Parse(dist.DownloadInfo.Url); err == nil && parsedUrl.Path != "" {
+		return path.Base(parsedUrl.Path)
+	}
+	return dist.Metadata.Name + ":" + dist.Metadata.Version
+}
+
+// Returns the sha256 checksum of the installed file, or an empty string if pip didn't report it.
+func (dist *InstalledDistribution) Sha256() string {
+	if sha256 := dist.DownloadInfo.ArchiveInfo.Hashes["sha256"]; sha256 != "" {
+		return sha256
+	}
+	if strings.HasPrefix(dist.DownloadInfo.ArchiveInfo.Hash, "sha256=") {
+		return strings.TrimPrefix(dist.DownloadInfo.ArchiveInfo.Hash, "sha256=")
+	}
+	return ""
+}
+
+// Returns the paths from each of the installed distributions to the root module, as expected by the requestedBy
+// field of the build-info dependencies. A path starts with the file name of the direct dependent and ends with the module ID.
+// The dependencies between the distributions are taken from their Requires-Dist metadata, so a requirement is
+// considered only if the distribution it refers to was installed too.
+func (report *InstallReport) RequestedBy(moduleId string) map[string][][]string {
+	byName := make(map[string]*InstalledDistribution, len(report.Install))
+	byFileName := make(map[string]*InstalledDistribution, len(report.Install))
+	for _, dist := range report.Install {
+		byName[NormalizePackageName(dist.Metadata.Name)] = dist
+		byFileName[dist.FileName()] = dist
+	}
+	var direct []string
+	for _, dist := range report.sortedInstall() {
+		if dist.Requested {
+			direct = append(direct, dist.FileName())
+		}
+	}
+	return projectutils.RequestedBy(moduleId, direct, func(fileName string) []string {
+		var children []string
+		for _, requirement := range byFileName[fileName].requirementNames() {
+			if child, exists := byName[requirement]; exists {
+				children = append(children, child.FileName())
+			}
+		}
+		return children
+	})
+}
+
+// Returns the normalized names of the required distributions.
+func (dist *InstalledDistribution) requirementNames() []string {
+	var names []string
+	for _, requirement := range dist.Metadata.RequiresDist {
+		if match := requirementNameRegexp.FindStringSubmatch(requirement); match != nil {
+			names = append(names, NormalizePackageName(match[1]))
+		}
+	}
+	return names
+}
+
+func (report *InstallReport) sortedInstall() []*InstalledDistribution {
+	sorted := append([]*InstalledDistribution{}, report.Install...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].FileName() < sorted[j].FileName()
+	})
+	return sorted
+}
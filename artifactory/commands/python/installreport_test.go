@@ -0,0 +1,109 @@
+package python
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadInstallReport(t *testing.T) {
+	report, err := ReadInstallReport(filepath.Join("testdata", "pip-report.json"))
+	assert.NoError(t, err)
+	if assert.Len(t, report.Install, 3) {
+		assert.Equal(t, "requests-2.31.0-py3-none-any.whl", report.Install[0].FileName())
+		assert.Equal(t, "aaa", report.Install[0].Sha256())
+		assert.Equal(t, "bbb", report.Install[1].Sha256())
+		assert.Equal(t, "ccc", report.Install[2].Sha256())
+	}
+}
+
+func TestInstallReportRequestedBy(t *testing.T) {
+	report, err := ReadInstallReport(filepath.Join("testdata", "pip-report.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][][]string{
+		"requests-2.31.0-py3-none-any.whl":          {{"my-app"}},
+		"charset_normalizer-3.1.0-py3-none-any.whl": {{"requests-2.31.0-py3-none-any.whl", "my-app"}},
+		"urllib3-2.0.2-py3-none-any.whl":            {{"requests-2.31.0-py3-none-any.whl", "my-app"}, {"my-app"}},
+	}, report.RequestedBy("my-app"))
+}
+
+func TestInstallReportRequestedByDiamonds(t *testing.T) {
+	// Each distribution requires both distributions of the next layer, so there are 2^30 paths to the last layer.
+	const layers = 30
+	report := &InstallReport{}
+	for layer := 0; layer < layers; layer++ {
+		for _, suffix := range []string{"a", "b"} {
+			dist := &InstalledDistribution{Requested: layer == 0}
+			dist.Metadata.Name = fmt.Sprintf("pkg%d%s", layer, suffix)
+			dist.Metadata.Version = "1.0"
+			if layer < layers-1 {
+				dist.Metadata.RequiresDist = []string{fmt.Sprintf("pkg%da", layer+1), fmt.Sprintf("pkg%db (>=1.0)", layer+1)}
+			}
+			report.Install = append(report.Install, dist)
+		}
+	}
+	requestedBy := report.RequestedBy("my-app")
+	assert.Len(t, requestedBy, 2*layers)
+	// Each distribution is expanded once, so it's reached through each of the distributions of the previous layer.
+	for _, paths := range requestedBy {
+		assert.LessOrEqual(t, len(paths), 2)
+	}
+	assert.Len(t, requestedBy[fmt.Sprintf("pkg%da:1.0", layers-1)], 2)
+}
+
+func TestCreatePipReportArgs(t *testing.T) {
+	// The report lists the distributions, which are installed already, as well.
+	assert.Equal(t, []string{"install", "-r", "requirements.txt", "-i", "https://rt/simple", "--dry-run", "--ignore-installed", "--quiet", "--report", "report.json"},
+		createPipReportArgs([]string{"-r", "requirements.txt"}, "https://rt/simple", "report.json"))
+}
+
+func TestCreatePipModule(t *testing.T) {
+	report, err := ReadInstallReport(filepath.Join("testdata", "pip-report.json"))
+	assert.NoError(t, err)
+	found := map[string]*projectutils.FoundFile{"aaa": {Name: "requests-2.31.0-py3-none-any.whl", Checksum: &buildinfo.Checksum{Sha1: "1", Md5: "2"}}}
+	module, missing := createPipModule("my-app", report, found)
+	assert.Equal(t, "my-app", module.Id)
+	if assert.Len(t, module.Dependencies, 3) {
+		assert.Equal(t, "charset_normalizer-3.1.0-py3-none-any.whl", module.Dependencies[0].Id)
+		assert.Equal(t, &buildinfo.Checksum{Sha1: "1", Md5: "2"}, module.Dependencies[1].Checksum)
+	}
+	assert.Equal(t, map[string]string{
+		"sha256.requests-2.31.0-py3-none-any.whl":          "aaa",
+		"sha256.charset_normalizer-3.1.0-py3-none-any.whl": "bbb",
+		"sha256.urllib3-2.0.2-py3-none-any.whl":            "ccc",
+	}, module.Properties)
+	assert.Equal(t, []string{"charset_normalizer-3.1.0-py3-none-any.whl", "urllib3-2.0.2-py3-none-any.whl"}, missing)
+}
+
+func TestInstallReportWithInstalledVersions(t *testing.T) {
+	required, err := ReadInstallReport(filepath.Join("testdata", "pip-report.json"))
+	assert.NoError(t, err)
+	// urllib3 was installed by the command, charset-normalizer was installed already with the version of the dry run,
+	// and requests was installed already with another version.
+	newlyInstalled := &InstalledDistribution{}
+	newlyInstalled.Metadata.Name = "urllib3"
+	newlyInstalled.Metadata.Version = "1.26.16"
+	newlyInstalled.DownloadInfo.Url = "https://rt/urllib3-1.26.16-py2.py3-none-any.whl"
+	newlyInstalled.DownloadInfo.ArchiveInfo.Hash = "sha256=ddd"
+	inspect, err := ParseInspectReport([]byte(`{"installed": [
+		{"metadata": {"name": "requests", "version": "2.28.0"}, "requested": false},
+		{"metadata": {"name": "charset-normalizer", "version": "3.1.0"}, "requested": true},
+		{"metadata": {"name": "urllib3", "version": "1.26.16"}}]}`))
+	assert.NoError(t, err)
+
+	report := required.WithInstalledVersions(&InstallReport{Install: []*InstalledDistribution{newlyInstalled}}, inspect)
+	if assert.Len(t, report.Install, 3) {
+		assert.Equal(t, "requests:2.28.0", report.Install[0].FileName())
+		assert.Empty(t, report.Install[0].Sha256())
+		assert.True(t, report.Install[0].Requested)
+		assert.Equal(t, "charset_normalizer-3.1.0-py3-none-any.whl", report.Install[1].FileName())
+		assert.Equal(t, "bbb", report.Install[1].Sha256())
+		assert.False(t, report.Install[1].Requested)
+		assert.Equal(t, "urllib3-1.26.16-py2.py3-none-any.whl", report.Install[2].FileName())
+		assert.Equal(t, "ddd", report.Install[2].Sha256())
+	}
+}
@@ -0,0 +1,219 @@
+package python
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const (
+	// The name of the package source, through which Poetry resolves the dependencies from Artifactory.
+	poetrySourceName = "artifactory"
+	poetryLockFile   = "poetry.lock"
+	pyprojectFile    = "pyproject.toml"
+)
+
+// Runs Poetry with the dependencies resolved from Artifactory. If the build name and number are passed,
+// the dependencies locked in poetry.lock are recorded in the build-info.
+type PoetryCommand struct {
+	serverDetails *config.ServerDetails
+	repo          string
+	args          []string
+}
+
+func NewPoetryCommand() *PoetryCommand {
+	return &PoetryCommand{}
+}
+
+func (pc *PoetryCommand) SetServerDetails(serverDetails *config.ServerDetails) *PoetryCommand {
+	pc.serverDetails = serverDetails
+	return pc
+}
+
+func (pc *PoetryCommand) SetRepo(repo string) *PoetryCommand {
+	pc.repo = repo
+	return pc
+}
+
+func (pc *PoetryCommand) SetArgs(args []string) *PoetryCommand {
+	pc.args = args
+	return pc
+}
+
+func (pc *PoetryCommand) ServerDetails() (*config.ServerDetails, error) {
+	return pc.serverDetails, nil
+}
+
+func (pc *PoetryCommand) CommandName() string {
+	return "rt_poetry"
+}
+
+func (pc *PoetryCommand) Run() error {
+	args, buildConfiguration, err := utils.ExtractBuildDetailsFromArgs(pc.args)
+	if err != nil {
+		return err
+	}
+	collectBuildInfo := buildConfiguration.BuildName != "" && buildConfiguration.BuildNumber != ""
+	if collectBuildInfo {
+		if err = utils.SaveBuildGeneralDetails(buildConfiguration.BuildName, buildConfiguration.BuildNumber, buildConfiguration.Project); err != nil {
+			return err
+		}
+	}
+	poetryPath, err := exec.LookPath("poetry")
+	if err != nil {
+		return errorutils.CheckError(errors.New("could not find the 'poetry' executable in the system PATH"))
+	}
+	env, err := pc.createEnv()
+	if err != nil {
+		return err
+	}
+	if err = pc.checkSource(); err != nil {
+		return err
+	}
+
+	log.Info("Running poetry " + strings.Join(args, " "))
+	if err = runPoetry(poetryPath, args, env); err != nil || !collectBuildInfo {
+		return err
+	}
+	return pc.collectDependencies(buildConfiguration)
+}
+
+func (pc *PoetryCommand) sourceUrl() string {
+	return pc.serverDetails.GetArtifactoryUrl() + "api/pypi/" + pc.repo + "/simple"
+}
+
+// The repository and its credentials are passed to Poetry through the environment, so neither pyproject.toml
+// nor the Poetry configuration is modified, and the credentials are never written to the disk.
+func (pc *PoetryCommand) createEnv() ([]string, error) {
+	username, password, err := projectutils.GetBasicAuthCredentials(pc.serverDetails)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.ToUpper(poetrySourceName)
+	return append(os.Environ(),
+		"POETRY_REPOSITORIES_"+name+"_URL="+pc.sourceUrl(),
+		"POETRY_HTTP_BASIC_"+name+"_USERNAME="+username,
+		"POETRY_HTTP_BASIC_"+name+"_PASSWORD="+password), nil
+}
+
+// The credentials are matched to a package source of pyproject.toml by its name, so a source with this name must
+// point to the repository. Without such a source, the dependencies aren't resolved from Artifactory.
+func (pc *PoetryCommand) checkSource() error {
+	exists, err := fileutils.IsFileExists(pyprojectFile, false)
+	if err != nil || !exists {
+		// Commands like 'poetry new' run without a project.
+		return err
+	}
+	sources, err := readPoetrySources(pyprojectFile)
+	if err != nil {
+		return err
+	}
+	sourceUrl := pc.sourceUrl()
+	existingUrl, exists := sources[poetrySourceName]
+	if !exists {
+		log.Warn(fmt.Sprintf("%s has no '%s' package source, so the dependencies aren't resolved from Artifactory. To add it, run 'poetry source add %s %s'.", pyprojectFile, poetrySourceName, poetrySourceName, sourceUrl))
+		return nil
+	}
+	if strings.TrimSuffix(existingUrl, "/") != sourceUrl {
+		return errorutils.CheckError(fmt.Errorf("the '%s' package source in %s points to %s rather than to %s", poetrySourceName, pyprojectFile, existingUrl, sourceUrl))
+	}
+	return nil
+}
+
+func runPoetry(poetryPath string, args, env []string) error {
+	cmd := exec.Command(poetryPath, args...)
+	cmd.Env = env
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return errorutils.CheckError(cmd.Run())
+}
+
+func (pc *PoetryCommand) collectDependencies(buildConfiguration *utils.BuildConfiguration) error {
+	exists, err := fileutils.IsFileExists(poetryLockFile, false)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Info("No", poetryLockFile, "file was found. No dependencies are added to the build-info.")
+		return nil
+	}
+	packages, err := ReadPoetryLock(poetryLockFile)
+	if err != nil {
+		return err
+	}
+	servicesManager, err := utils.CreateServiceManager(pc.serverDetails, -1, false)
+	if err != nil {
+		return err
+	}
+	searchRepo, err := utils.GetRepoNameForDependenciesSearch(pc.repo, servicesManager)
+	if err != nil {
+		return err
+	}
+	var sha256s []string
+	for _, locked := range packages {
+		for sha256 := range locked.Files {
+			sha256s = append(sha256s, sha256)
+		}
+	}
+	sort.Strings(sha256s)
+	foundFiles, err := projectutils.FindFilesBySha256(servicesManager, searchRepo, sha256s)
+	if err != nil {
+		return err
+	}
+	dependencies, missing := createPoetryDependencies(packages, foundFiles)
+	if len(missing) > 0 {
+		log.Warn("The following packages were not found in " + searchRepo + ", and are added to the build-info without checksums:\n" + strings.Join(missing, "\n") +
+			"\nThis usually happens when the packages were installed from the local cache rather than from Artifactory.")
+	}
+
+	moduleId := buildConfiguration.Module
+	if moduleId == "" {
+		if moduleId, err = ReadPyprojectName(pyprojectFile); err != nil {
+			return err
+		}
+	}
+	if moduleId == "" {
+		moduleId = buildConfiguration.BuildName
+	}
+	log.Info("Adding", strconv.Itoa(len(dependencies)), "dependencies to module", moduleId, "of the build-info.")
+	return projectutils.SaveDependencies(buildConfiguration, buildinfo.Pip, moduleId, dependencies)
+}
+
+// Creates the build-info dependencies of the locked packages. A package is identified by the file found in Artifactory,
+// which is one of the files listed for it in the lock file. The names of the packages which weren't found are returned.
+func createPoetryDependencies(packages []*LockedPackage, foundFiles map[string]*projectutils.FoundFile) (dependencies []buildinfo.Dependency, missing []string) {
+	for _, locked := range packages {
+		dependency := buildinfo.Dependency{Id: locked.Name + ":" + locked.Version}
+		if locked.Category != "" {
+			dependency.Scopes = []string{locked.Category}
+		}
+		var sha256s []string
+		for sha256 := range locked.Files {
+			sha256s = append(sha256s, sha256)
+		}
+		sort.Strings(sha256s)
+		for _, sha256 := range sha256s {
+			if file, exists := foundFiles[sha256]; exists {
+				dependency.Id = file.Name
+				dependency.Checksum = file.Checksum
+				break
+			}
+		}
+		if dependency.Checksum == nil {
+			missing = append(missing, locked.Name+" "+locked.Version)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return
+}
@@ -0,0 +1,101 @@
+package python
+
+import (
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/pelletier/go-toml"
+)
+
+// A package locked in poetry.lock, with the sha256 checksums of all of its files.
+type LockedPackage struct {
+	Name     string
+	Version  string
+	Category string
+	// The file names by their sha256 checksum.
+	Files map[string]string
+}
+
+// Reads the packages from a poetry.lock file. Newer lock files list the files of each package under the package,
+// while older ones list them under [metadata.files].
+func ReadPoetryLock(lockFilePath string) ([]*LockedPackage, error) {
+	content, err := ioutil.ReadFile(lockFilePath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	return parsePoetryLock(content)
+}
+
+func parsePoetryLock(content []byte) ([]*LockedPackage, error) {
+	tree, err := toml.LoadBytes(content)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	var metadataFiles *toml.Tree
+	if metadata, ok := tree.Get("metadata.files").(*toml.Tree); ok {
+		metadataFiles = metadata
+	}
+	packageTrees, _ := tree.Get("package").([]*toml.Tree)
+	var packages []*LockedPackage
+	for _, packageTree := range packageTrees {
+		locked := &LockedPackage{Files: make(map[string]string)}
+		locked.Name, _ = packageTree.Get("name").(string)
+		locked.Version, _ = packageTree.Get("version").(string)
+		locked.Category, _ = packageTree.Get("category").(string)
+		addLockedFiles(locked.Files, packageTree.Get("files"))
+		if metadataFiles != nil {
+			addLockedFiles(locked.Files, metadataFiles.GetPath([]string{locked.Name}))
+		}
+		packages = append(packages, locked)
+	}
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Name < packages[j].Name
+	})
+	return packages, nil
+}
+
+func addLockedFiles(files map[string]string, value interface{}) {
+	fileTrees, ok := value.([]*toml.Tree)
+	if !ok {
+		return
+	}
+	for _, fileTree := range fileTrees {
+		fileName, _ := fileTree.Get("file").(string)
+		hash, _ := fileTree.Get("hash").(string)
+		if fileName != "" && strings.HasPrefix(hash, "sha256:") {
+			files[strings.TrimPrefix(hash, "sha256:")] = fileName
+		}
+	}
+}
+
+// Returns the name of the project from pyproject.toml, or an empty string if it's not set.
+func ReadPyprojectName(pyprojectPath string) (string, error) {
+	tree, err := toml.LoadFile(pyprojectPath)
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	for _, key := range []string{"tool.poetry.name", "project.name"} {
+		if name, ok := tree.Get(key).(string); ok && name != "" {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// Returns the URLs of the package sources configured in pyproject.toml, by their names.
+func readPoetrySources(pyprojectPath string) (map[string]string, error) {
+	tree, err := toml.LoadFile(pyprojectPath)
+	if err != nil {
+		return nil, errorutils.CheckError(err)
+	}
+	sources := make(map[string]string)
+	sourceTrees, _ := tree.Get("tool.poetry.source").([]*toml.Tree)
+	for _, sourceTree := range sourceTrees {
+		name, _ := sourceTree.Get("name").(string)
+		url, _ := sourceTree.Get("url").(string)
+		sources[name] = url
+	}
+	return sources, nil
+}
@@ -0,0 +1,47 @@
+package python
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPoetryLock(t *testing.T) {
+	for _, lockFile := range []string{"poetry.lock", "poetry-metadata-files.lock"} {
+		t.Run(lockFile, func(t *testing.T) {
+			packages, err := ReadPoetryLock(filepath.Join("testdata", lockFile))
+			assert.NoError(t, err)
+			if assert.Len(t, packages, 2) {
+				assert.Equal(t, "pytest", packages[0].Name)
+				assert.Equal(t, "dev", packages[0].Category)
+				assert.Equal(t, "requests", packages[1].Name)
+				assert.Equal(t, "2.25.1", packages[1].Version)
+				assert.Equal(t, map[string]string{"aaa": "requests-2.25.1-py2.py3-none-any.whl", "bbb": "requests-2.25.1.tar.gz"}, packages[1].Files)
+			}
+		})
+	}
+}
+
+func TestCreatePoetryDependencies(t *testing.T) {
+	packages, err := ReadPoetryLock(filepath.Join("testdata", "poetry.lock"))
+	assert.NoError(t, err)
+	found := map[string]*projectutils.FoundFile{"bbb": {Name: "requests-2.25.1.tar.gz", Checksum: &buildinfo.Checksum{Sha1: "1", Md5: "2"}}}
+	dependencies, missing := createPoetryDependencies(packages, found)
+	assert.Equal(t, []buildinfo.Dependency{
+		{Id: "pytest:6.2.4", Scopes: []string{"dev"}},
+		{Id: "requests-2.25.1.tar.gz", Scopes: []string{"main"}, Checksum: &buildinfo.Checksum{Sha1: "1", Md5: "2"}},
+	}, dependencies)
+	assert.Equal(t, []string{"pytest 6.2.4"}, missing)
+}
+
+func TestReadPyprojectName(t *testing.T) {
+	name, err := ReadPyprojectName(filepath.Join("testdata", "pyproject.toml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "my-app", name)
+	sources, err := readPoetrySources(filepath.Join("testdata", "pyproject.toml"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"artifactory": "https://acme.jfrog.io/artifactory/api/pypi/pypi/simple"}, sources)
+}
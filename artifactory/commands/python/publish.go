@@ -0,0 +1,116 @@
+package python
+
+import (
+	"errors"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const defaultDistDir = "dist"
+
+// Deploys the wheels and source distributions built into the dist directory to a PyPI repository,
+// in the <normalized name>/<version>/<file> layout and with the pypi.* properties Artifactory expects.
+type PipPublishCommand struct {
+	serverDetails      *config.ServerDetails
+	repo               string
+	distDir            string
+	buildConfiguration *utils.BuildConfiguration
+}
+
+func NewPipPublishCommand() *PipPublishCommand {
+	return &PipPublishCommand{distDir: defaultDistDir}
+}
+
+func (ppc *PipPublishCommand) SetServerDetails(serverDetails *config.ServerDetails) *PipPublishCommand {
+	ppc.serverDetails = serverDetails
+	return ppc
+}
+
+func (ppc *PipPublishCommand) SetRepo(repo string) *PipPublishCommand {
+	ppc.repo = repo
+	return ppc
+}
+
+func (ppc *PipPublishCommand) SetDistDir(distDir string) *PipPublishCommand {
+	if distDir != "" {
+		ppc.distDir = distDir
+	}
+	return ppc
+}
+
+func (ppc *PipPublishCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *PipPublishCommand {
+	ppc.buildConfiguration = buildConfiguration
+	return ppc
+}
+
+func (ppc *PipPublishCommand) ServerDetails() (*config.ServerDetails, error) {
+	return ppc.serverDetails, nil
+}
+
+func (ppc *PipPublishCommand) CommandName() string {
+	return "rt_pip_publish"
+}
+
+func (ppc *PipPublishCommand) Run() error {
+	distributions, err := findDistributions(ppc.distDir)
+	if err != nil {
+		return err
+	}
+	log.Info("Publishing", strconv.Itoa(len(distributions)), "distributions to", ppc.repo+"...")
+	var files []projectutils.DeployableFile
+	for _, dist := range distributions {
+		files = append(files, projectutils.DeployableFile{
+			LocalPath:  filepath.Join(ppc.distDir, dist.FileName),
+			TargetPath: path.Join(ppc.repo, dist.NormalizedName(), dist.Version, dist.FileName),
+			Props:      "pypi.name=" + dist.Name + ";pypi.version=" + dist.Version + ";pypi.normalized.name=" + dist.NormalizedName(),
+		})
+	}
+	artifacts, err := projectutils.DeployFiles(ppc.serverDetails, files, ppc.buildConfiguration)
+	if err != nil {
+		return err
+	}
+	if ppc.buildConfiguration.BuildName != "" && ppc.buildConfiguration.BuildNumber != "" {
+		moduleId := ppc.buildConfiguration.Module
+		if moduleId == "" {
+			moduleId = distributions[0].NormalizedName()
+		}
+		if err = projectutils.SaveArtifacts(ppc.buildConfiguration, buildinfo.Pip, moduleId, artifacts); err != nil {
+			return err
+		}
+	}
+	log.Info("pip publish finished successfully.")
+	return nil
+}
+
+// Returns the distribution files in the directory. Other files are ignored.
+func findDistributions(distDir string) ([]*Distribution, error) {
+	entries, err := ioutil.ReadDir(distDir)
+	if err != nil {
+		return nil, errorutils.CheckError(errors.New("failed to read the distributions directory: " + err.Error()))
+	}
+	var distributions []*Distribution
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		dist, err := ParseDistributionFileName(entry.Name())
+		if err != nil {
+			log.Debug("Skipping", entry.Name()+":", err.Error())
+			continue
+		}
+		distributions = append(distributions, dist)
+	}
+	if len(distributions) == 0 {
+		return nil, errorutils.CheckError(errors.New("no wheels or source distributions were found in " + distDir))
+	}
+	return distributions, nil
+}
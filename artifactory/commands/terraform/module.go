@@ -0,0 +1,140 @@
+package terraform
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+)
+
+// The files, which are never packed into the module archive: the working directory of Terraform, VCS directories and states.
+var defaultExclusions = []string{".terraform", ".git", "*.tfstate", "*.tfstate.*", ".terraform.lock.hcl"}
+
+// The registry allows letters, digits, dashes and underscores in the namespace, name and provider of a module.
+var registryNameRegExp = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z_-]*$`)
+
+// The registry address of a module: <namespace>/<name>/<provider>, and its version.
+type Module struct {
+	Namespace string
+	Name      string
+	Provider  string
+	Version   string
+}
+
+func (module *Module) Validate() error {
+	for _, part := range []struct{ name, value string }{{"namespace", module.Namespace}, {"name", module.Name}, {"provider", module.Provider}} {
+		if !registryNameRegExp.MatchString(part.value) {
+			return errorutils.CheckError(errors.New("invalid module " + part.name + " '" + part.value + "'. It may contain only letters, digits, dashes and underscores"))
+		}
+	}
+	if module.Version == "" || strings.ContainsAny(module.Version, "/\\ ") {
+		return errorutils.CheckError(errors.New("invalid module version '" + module.Version + "'"))
+	}
+	return nil
+}
+
+// Returns the path of the module archive in the repository, following the layout of the Terraform registry.
+func (module *Module) Path() string {
+	return path.Join(module.Namespace, module.Name, module.Provider, module.Version+".zip")
+}
+
+func (module *Module) Id() string {
+	return module.Namespace + "/" + module.Name + "/" + module.Provider + ":" + module.Version
+}
+
+// The properties of the module archive, by which it is found in the registry.
+func (module *Module) Props() string {
+	return "terraform.module.namespace=" + module.Namespace + ";terraform.module.name=" + module.Name +
+		";terraform.module.provider=" + module.Provider + ";terraform.module.version=" + module.Version
+}
+
+// Packs the module directory into a zip archive. The exclusions are patterns, which are matched against the paths relative
+// to the module directory and against the file names. The files excluded by default are always excluded.
+func PackModule(moduleDir, zipPath string, exclusions []string) (files int, err error) {
+	exclusions = append(append([]string{}, defaultExclusions...), exclusions...)
+	hasTfFiles, err := hasTerraformFiles(moduleDir)
+	if err != nil {
+		return 0, err
+	}
+	if !hasTfFiles {
+		return 0, errorutils.CheckError(errors.New("no .tf files were found in " + moduleDir + ". Run the command in the root directory of the module"))
+	}
+	zipFile, err := os.Create(zipPath)
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	defer func() {
+		if closeErr := zipFile.Close(); err == nil {
+			err = errorutils.CheckError(closeErr)
+		}
+	}()
+	writer := zip.NewWriter(zipFile)
+	err = filepath.Walk(moduleDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(moduleDir, filePath)
+		if err != nil || relPath == "." {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if isExcluded(relPath, exclusions) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		files++
+		return addFile(writer, filePath, relPath, info)
+	})
+	if err != nil {
+		return 0, errorutils.CheckError(err)
+	}
+	return files, errorutils.CheckError(writer.Close())
+}
+
+func addFile(writer *zip.Writer, filePath, relPath string, info os.FileInfo) error {
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = relPath
+	header.Method = zip.Deflate
+	entry, err := writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(entry, file)
+	return err
+}
+
+func isExcluded(relPath string, exclusions []string) bool {
+	for _, pattern := range exclusions {
+		if matched, _ := path.Match(pattern, relPath); matched {
+			return true
+		}
+		if matched, _ := path.Match(pattern, path.Base(relPath)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTerraformFiles(moduleDir string) (bool, error) {
+	matches, err := filepath.Glob(filepath.Join(moduleDir, "*.tf"))
+	return len(matches) > 0, errorutils.CheckError(err)
+}
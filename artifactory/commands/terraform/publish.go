@@ -0,0 +1,134 @@
+package terraform
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jfrog/jfrog-cli-core/artifactory/utils"
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-cli/artifactory/commands/projectutils"
+	"github.com/jfrog/jfrog-client-go/artifactory/buildinfo"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/jfrog/jfrog-client-go/utils/log"
+)
+
+const ModuleType buildinfo.ModuleType = "terraform"
+
+// Packs a Terraform module directory and deploys it to a Terraform repository, in the layout of the Terraform registry:
+// <namespace>/<name>/<provider>/<version>.zip. The name of the module is the name of its directory.
+// If the build name and number are set, the module archive is recorded as a build-info artifact.
+type TerraformPublishCommand struct {
+	serverDetails      *config.ServerDetails
+	repo               string
+	moduleDir          string
+	namespace          string
+	provider           string
+	tag                string
+	exclusions         []string
+	buildConfiguration *utils.BuildConfiguration
+}
+
+func NewTerraformPublishCommand() *TerraformPublishCommand {
+	return &TerraformPublishCommand{}
+}
+
+func (tpc *TerraformPublishCommand) SetServerDetails(serverDetails *config.ServerDetails) *TerraformPublishCommand {
+	tpc.serverDetails = serverDetails
+	return tpc
+}
+
+func (tpc *TerraformPublishCommand) SetRepo(repo string) *TerraformPublishCommand {
+	tpc.repo = repo
+	return tpc
+}
+
+// Sets the directory of the module, which defaults to the working directory.
+func (tpc *TerraformPublishCommand) SetModuleDir(moduleDir string) *TerraformPublishCommand {
+	tpc.moduleDir = moduleDir
+	return tpc
+}
+
+func (tpc *TerraformPublishCommand) SetNamespace(namespace string) *TerraformPublishCommand {
+	tpc.namespace = namespace
+	return tpc
+}
+
+func (tpc *TerraformPublishCommand) SetProvider(provider string) *TerraformPublishCommand {
+	tpc.provider = provider
+	return tpc
+}
+
+// Sets the version of the module.
+func (tpc *TerraformPublishCommand) SetTag(tag string) *TerraformPublishCommand {
+	tpc.tag = tag
+	return tpc
+}
+
+func (tpc *TerraformPublishCommand) SetExclusions(exclusions []string) *TerraformPublishCommand {
+	tpc.exclusions = exclusions
+	return tpc
+}
+
+func (tpc *TerraformPublishCommand) SetBuildConfiguration(buildConfiguration *utils.BuildConfiguration) *TerraformPublishCommand {
+	tpc.buildConfiguration = buildConfiguration
+	return tpc
+}
+
+func (tpc *TerraformPublishCommand) ServerDetails() (*config.ServerDetails, error) {
+	return tpc.serverDetails, nil
+}
+
+func (tpc *TerraformPublishCommand) CommandName() string {
+	return "rt_terraform_publish"
+}
+
+func (tpc *TerraformPublishCommand) Run() error {
+	if tpc.serverDetails == nil || tpc.repo == "" {
+		return errorutils.CheckError(errors.New("no deployment repository is configured. Run 'jfrog rt terraform-config' with the deployer options"))
+	}
+	moduleDir := tpc.moduleDir
+	if moduleDir == "" {
+		moduleDir = "."
+	}
+	moduleDir, err := filepath.Abs(moduleDir)
+	if err != nil {
+		return errorutils.CheckError(err)
+	}
+	module := &Module{Namespace: tpc.namespace, Name: filepath.Base(moduleDir), Provider: tpc.provider, Version: tpc.tag}
+	if err = module.Validate(); err != nil {
+		return err
+	}
+
+	tempDir, err := fileutils.CreateTempDir()
+	if err != nil {
+		return err
+	}
+	defer fileutils.RemoveTempDir(tempDir)
+	zipPath := filepath.Join(tempDir, module.Version+".zip")
+	files, err := PackModule(moduleDir, zipPath, tpc.exclusions)
+	if err != nil {
+		return err
+	}
+	log.Info("Publishing", module.Id(), "with", strconv.Itoa(files), "files to", tpc.repo+"...")
+	artifacts, err := projectutils.DeployFiles(tpc.serverDetails, []projectutils.DeployableFile{{
+		LocalPath:  zipPath,
+		TargetPath: tpc.repo + "/" + module.Path(),
+		Props:      module.Props(),
+	}}, tpc.buildConfiguration)
+	if err != nil {
+		return err
+	}
+	if tpc.buildConfiguration.BuildName != "" && tpc.buildConfiguration.BuildNumber != "" {
+		moduleId := tpc.buildConfiguration.Module
+		if moduleId == "" {
+			moduleId = module.Id()
+		}
+		if err = projectutils.SaveArtifacts(tpc.buildConfiguration, ModuleType, moduleId, artifacts); err != nil {
+			return err
+		}
+	}
+	log.Info("terraform publish finished successfully.")
+	return nil
+}
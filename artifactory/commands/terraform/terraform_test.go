@@ -0,0 +1,107 @@
+package terraform
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModule(t *testing.T) {
+	module := &Module{Namespace: "acme", Name: "vpc", Provider: "aws", Version: "1.2.0"}
+	assert.NoError(t, module.Validate())
+	assert.Equal(t, "acme/vpc/aws/1.2.0.zip", module.Path())
+	assert.Equal(t, "acme/vpc/aws:1.2.0", module.Id())
+
+	module.Namespace = "acme/infra"
+	assert.Error(t, module.Validate())
+	module.Namespace, module.Version = "acme", ""
+	assert.Error(t, module.Validate())
+}
+
+func TestPackModule(t *testing.T) {
+	tempDir, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer fileutils.RemoveTempDir(tempDir)
+	zipPath := filepath.Join(tempDir, "1.2.0.zip")
+	files, err := PackModule(filepath.Join("testdata", "vpc"), zipPath, []string{"examples"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, files)
+
+	reader, err := zip.OpenReader(zipPath)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer reader.Close()
+	var names []string
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	sort.Strings(names)
+	// The .terraform directory and the state are excluded by default.
+	assert.Equal(t, []string{"README.md", "main.tf", "modules/subnet/main.tf", "variables.tf"}, names)
+
+	_, err = PackModule(filepath.Join("testdata", "vpc", "examples", "missing"), zipPath, nil)
+	assert.Error(t, err)
+}
+
+func TestWriteTerraformrc(t *testing.T) {
+	tempDir, err := fileutils.CreateTempDir()
+	assert.NoError(t, err)
+	defer fileutils.RemoveTempDir(tempDir)
+	terraformrcPath := filepath.Join(tempDir, ".terraformrc")
+	existing := `plugin_cache_dir = "$HOME/.terraform.d/plugin-cache"
+
+credentials "acme.jfrog.io" {
+  token = "old-token"
+}
+
+credentials "app.terraform.io" {
+  token = "{other}"
+}
+`
+	assert.NoError(t, ioutil.WriteFile(terraformrcPath, []byte(existing), 0600))
+	serverDetails := &config.ServerDetails{ServerId: "acme", ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", AccessToken: "new-token"}
+	host, err := WriteTerraformrc(terraformrcPath, serverDetails)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme.jfrog.io", host)
+
+	content, err := ioutil.ReadFile(terraformrcPath)
+	assert.NoError(t, err)
+	expected := `plugin_cache_dir = "$HOME/.terraform.d/plugin-cache"
+
+credentials "app.terraform.io" {
+  token = "{other}"
+}
+
+host "acme.jfrog.io" {
+  services = {
+    "modules.v1"   = "https://acme.jfrog.io/artifactory/api/terraform/v1/modules/",
+    "providers.v1" = "https://acme.jfrog.io/artifactory/api/terraform/v1/providers/"
+  }
+}
+
+credentials "acme.jfrog.io" {
+  token = "new-token"
+}
+`
+	assert.Equal(t, expected, string(content))
+
+	// Writing again replaces the blocks of the host.
+	_, err = WriteTerraformrc(terraformrcPath, serverDetails)
+	assert.NoError(t, err)
+	rewritten, err := ioutil.ReadFile(terraformrcPath)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(rewritten))
+
+	_, err = WriteTerraformrc(terraformrcPath, &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/"})
+	assert.Error(t, err)
+	// The password is never written as the token.
+	_, err = WriteTerraformrc(terraformrcPath, &config.ServerDetails{ArtifactoryUrl: "https://acme.jfrog.io/artifactory/", User: "admin", Password: "password"})
+	assert.Error(t, err)
+}
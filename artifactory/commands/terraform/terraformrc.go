@@ -0,0 +1,126 @@
+package terraform
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/jfrog/jfrog-cli-core/utils/config"
+	"github.com/jfrog/jfrog-client-go/utils/errorutils"
+	"github.com/jfrog/jfrog-client-go/utils/io/fileutils"
+)
+
+// Returns the path of the Terraform CLI configuration file: TF_CLI_CONFIG_FILE if it's set, or the default path otherwise,
+// which is %APPDATA%\terraform.rc on Windows and ~/.terraformrc on other operating systems.
+func GetTerraformrcPath() (string, error) {
+	if configFile := os.Getenv("TF_CLI_CONFIG_FILE"); configFile != "" {
+		return configFile, nil
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "terraform.rc"), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return filepath.Join(homeDir, ".terraformrc"), nil
+}
+
+// Writes the host and credentials blocks of the Artifactory server into the Terraform CLI configuration file, so that
+// Terraform resolves modules and providers from the Terraform registry of Artifactory. Existing blocks of the host are
+// replaced, and the rest of the file is kept. Terraform sends the token of the credentials block as a bearer token, so
+// the server must have an access token. Its password is never written to the file.
+// Returns the host, by which the modules of a repository are referenced as <host>/<repo>__<namespace>/<name>/<provider>.
+func WriteTerraformrc(terraformrcPath string, serverDetails *config.ServerDetails) (host string, err error) {
+	artifactoryUrl, err := url.Parse(serverDetails.GetArtifactoryUrl())
+	if err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	host = artifactoryUrl.Host
+	token := serverDetails.GetAccessToken()
+	if token == "" {
+		return "", errorutils.CheckError(errors.New("the server '" + serverDetails.ServerId + "' has no access token, which Terraform can authenticate with. " +
+			"Create an access token in Artifactory, and configure the server with it by running 'jfrog c edit " + serverDetails.ServerId + " --access-token=<token>'"))
+	}
+
+	var content string
+	exists, err := fileutils.IsFileExists(terraformrcPath, false)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		existing, err := ioutil.ReadFile(terraformrcPath)
+		if err != nil {
+			return "", errorutils.CheckError(err)
+		}
+		content = removeHostBlocks(string(existing), host)
+	}
+	if content = strings.TrimRight(content, " \t\n"); content != "" {
+		content += "\n\n"
+	}
+	apiUrl := serverDetails.GetArtifactoryUrl() + "api/terraform/v1/"
+	content += `host "` + host + `" {
+  services = {
+    "modules.v1"   = "` + apiUrl + `modules/",
+    "providers.v1" = "` + apiUrl + `providers/"
+  }
+}
+
+credentials "` + host + `" {
+  token = "` + token + `"
+}
+`
+	if err = os.MkdirAll(filepath.Dir(terraformrcPath), 0700); err != nil {
+		return "", errorutils.CheckError(err)
+	}
+	return host, errorutils.CheckError(ioutil.WriteFile(terraformrcPath, []byte(content), 0600))
+}
+
+var blankLinesRegExp = regexp.MustCompile(`\n{3,}`)
+
+// Removes the host and credentials blocks of the host from the content of the configuration file.
+func removeHostBlocks(content, host string) string {
+	blockStart := regexp.MustCompile(`(?m)^[ \t]*(host|credentials)[ \t]+"` + regexp.QuoteMeta(host) + `"[ \t]*\{`)
+	for {
+		location := blockStart.FindStringIndex(content)
+		if location == nil {
+			return content
+		}
+		end := blockEnd(content, location[1])
+		// Remove the line break after the block too.
+		if end < len(content) && content[end] == '\n' {
+			end++
+		}
+		content = content[:location[0]] + content[end:]
+		// Leave a single blank line where the block was.
+		content = blankLinesRegExp.ReplaceAllString(content, "\n\n")
+	}
+}
+
+// Returns the index after the brace, which closes the block opened right before the start index.
+// Braces in strings are skipped.
+func blockEnd(content string, start int) int {
+	depth := 1
+	inString := false
+	for i := start; i < len(content); i++ {
+		switch c := content[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case !inString && c == '{':
+			depth++
+		case !inString && c == '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(content)
+}
@@ -1,6 +1,6 @@
 package buildaddgit
 
-const Description = "Collect VCS details from git and add them to a build."
+const Description = "Collect VCS details from git and add them to a build, including the commits since the previous published build."
 
 var Usage = []string{"jfrog rt bag [command options] <build name> <build number> [Path To .git]"}
 
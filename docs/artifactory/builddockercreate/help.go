@@ -1,6 +1,6 @@
 package builddockercreate
 
-const Description = "Add a published docker image to the build-info."
+const Description = "Add a published docker image to the build-info. A multi-arch image is added with a module per platform."
 
 var Usage = []string{"jfrog rt build-docker-create <target repo> --image-file=<Image file path>"}
 
@@ -0,0 +1,11 @@
+package buildgraph
+
+const Description = "Print the tree of a published build and the builds appended to it."
+
+var Usage = []string{"jfrog rt bg [command options] <build name> <build number>"}
+
+const Arguments string = `	build name
+		Build name.
+
+	build number
+		Build number.`
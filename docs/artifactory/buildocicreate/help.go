@@ -0,0 +1,9 @@
+package buildocicreate
+
+const Description = "Add the images of an OCI image layout, which were pushed to Artifactory, to the build-info."
+
+var Usage = []string{"jfrog rt build-oci-create <target repo> --oci-layout=<oci layout dir> --image=<name>[:<tag>]"}
+
+const Arguments string = `	target repo
+		The repository to which the images were pushed.
+`
@@ -0,0 +1,8 @@
+package buildtrace
+
+const Description = "Run a command and record the files it reads and writes as build dependencies and artifacts. Supported on Linux only."
+
+var Usage = []string{"jfrog rt bt [command options] -- <command> [command args]"}
+
+const Arguments string = `	command
+		The command to run and trace, for example 'make all'. The files the command and its child processes read from the input directories are added to the build info as dependencies, and the files they write to the output directories are added as artifacts.`
@@ -0,0 +1,9 @@
+package cargo
+
+const Description = "Run Cargo commands."
+
+var Usage = []string{`jfrog rt cargo <cargo sub-command>`}
+
+const Arguments string = `	cargo sub-command
+		Arguments and options for the cargo command. Crates are resolved from the configured repository, and 'cargo publish' publishes to the configured deployment repository.
+		The tokens are passed to Cargo through the registry.global-credential-providers key, so Cargo 1.74 or above is required.`
@@ -0,0 +1,5 @@
+package cargoconfig
+
+const Description = "Generate Cargo build configuration."
+
+var Usage = []string{"jfrog rt cargo-config", "jfrog rt cargo-config --export-cargo-config [--export-path=<path>]"}
@@ -0,0 +1,8 @@
+package conan
+
+const Description = "Run Conan commands."
+
+var Usage = []string{`jfrog rt conan <conan sub-command>`}
+
+const Arguments string = `	conan sub-command
+		Arguments and options for the conan command. 'conan install' and 'conan create' resolve from the configured repository, and 'conan upload' uploads to the configured deployment repository.`
@@ -0,0 +1,5 @@
+package conanconfig
+
+const Description = "Generate Conan build configuration."
+
+var Usage = []string{"jfrog rt conan-config"}
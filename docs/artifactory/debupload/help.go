@@ -0,0 +1,14 @@
+package debupload
+
+const Description = "Upload a Debian package to a Debian repository, with the properties of its control file."
+
+var Usage = []string{"jfrog rt deb-upload --distribution=<distribution> [command options] <package path> <target>"}
+
+const Arguments string = `	package path
+		Path to the .deb file. Its name, version and architecture are read from its control file.
+
+	target
+		The target repository and optional path, in the form of <repo>[/<path>]. If the path is omitted, the package
+		is uploaded to pool/<component>/<prefix>/<source package>/<name>_<version>_<architecture>.deb.
+		If the path ends with a slash, the package is uploaded into it with its canonical name.
+		The package isn't uploaded if the repository isn't a Debian repository, or if the target file name doesn't match the control file.`
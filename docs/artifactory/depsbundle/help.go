@@ -0,0 +1,16 @@
+package depsbundle
+
+const Description = "Bundle the dependencies of a build for an air-gapped environment, or import a bundle to an isolated Artifactory."
+
+var Usage = []string{"jfrog rt deps-bundle --build-name=<build name> --build-number=<build number> [command options] [bundle path]",
+	"jfrog rt deps-bundle import --repos=<layout>=<repository>[;<layout>=<repository>...] [command options] <bundle path>"}
+
+const Arguments string = `	bundle path
+		Path to the tar.gz bundle. When bundling, defaults to <build name>-<build number>-deps.tar.gz in the working directory.
+		The dependencies are downloaded from Artifactory by their sha1 checksums, and laid out by package type:
+		maven/ as a Maven repository, npm/ with the package tarballs, go/cache/download/ as a Go module cache,
+		pip/ as a wheelhouse, nuget/ as a local feed, and generic/<repo>/ for the other types.
+		The bundle.json file of the bundle lists its files and the dependencies, which weren't found.
+
+	import
+		Upload the files of the bundle to the repositories set by --repos, by their layouts, to the paths they had in their source repositories.`
@@ -1,6 +1,6 @@
 package dockerpromote
 
-const Description = "Promotes a Docker image from one repository to another. Supported by local repositories only."
+const Description = "Promotes a Docker image from one repository to another, including all of the platform manifests of a multi-arch image. Supported by local repositories only."
 
 var Usage = []string{"jfrog rt docker-promote <source docker image> <source repo> <target repo>"}
 
@@ -5,4 +5,9 @@ const Description = "Run .NET Core CLI"
 var Usage = []string{`jfrog rt dotnet <dotnet sub-command> [command options]`}
 
 const Arguments string = `	dotnet sub-command
-		 Arguments and options for the dotnet command.`
+		 Arguments and options for the dotnet command. When collecting build-info, the dependencies are read from
+		 packages.lock.json if it exists, and the target frameworks of the projects are recorded as the dependency scopes.
+		 Every project is recorded as a separate module, named after the project. If the --module option is set, it is
+		 used as the module name of a single project, and as a prefix of the module names of several projects, for example
+		 <module>/<project>. Unlike 'jfrog rt nuget', which records the restored packages only and uses --module as the
+		 module name of every project, the versions locked in packages.lock.json are recorded.`
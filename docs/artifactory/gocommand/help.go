@@ -5,4 +5,5 @@ const Description = "Runs go"
 var Usage = []string{`jfrog rt go <go arguments> [command options]`}
 
 const Arguments string = `	go commands
-		Arguments and options for the go command.`
+		Arguments and options for the go command. In a go.work workspace, every module of the workspace is recorded
+		as a build-info module.`
@@ -2,7 +2,8 @@ package gopublish
 
 const Description = "Publish go package and/or its dependencies to Artifactory"
 
-var Usage = []string{`jfrog rt gp [command options] <project version>`}
+var Usage = []string{`jfrog rt gp [command options] <project version>`,
+	`jfrog rt gp --workspace [command options] <project version>`}
 
 const Arguments string = `	project version
-		Package version to be published.`
+		Package version to be published. With --workspace, all the modules of the go.work workspace are published in this version.`
@@ -1,5 +1,5 @@
 package gradleconfig
 
-const Description = "Generate gradle build configuration."
+const Description = "Generate gradle build configuration, or export it to a Gradle init script."
 
-var Usage = []string{"jfrog rt gradle-config [command options]"}
+var Usage = []string{"jfrog rt gradle-config [command options]", "jfrog rt gradle-config --export-init-script [--export-path=<path>] [--include-credentials]"}
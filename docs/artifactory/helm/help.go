@@ -0,0 +1,10 @@
+package helm
+
+const Description = "Run Helm commands."
+
+var Usage = []string{`jfrog rt helm <helm sub-command>`}
+
+const Arguments string = `	helm sub-command
+		Arguments and options for the helm command. Chart dependencies are resolved from the configured repository, which charts refer to as "@artifactory".
+		'jfrog rt helm push <chart.tgz>' deploys the packaged chart to the configured deployment repository.
+		When collecting build-info, 'jfrog rt helm package' records the created .tgz file as an artifact, and the images referenced by the chart are recorded with the checksums of their manifests in Artifactory.`
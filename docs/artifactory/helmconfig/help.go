@@ -0,0 +1,5 @@
+package helmconfig
+
+const Description = "Generate Helm build configuration."
+
+var Usage = []string{"jfrog rt helm-config"}
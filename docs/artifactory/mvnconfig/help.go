@@ -1,5 +1,5 @@
 package mvnconfig
 
-const Description = "Generate maven build configuration."
+const Description = "Generate maven build configuration, or export it to a Maven settings.xml."
 
-var Usage = []string{"jfrog rt mvn-config [command options]"}
+var Usage = []string{"jfrog rt mvn-config [command options]", "jfrog rt mvn-config --export-settings [--export-path=<path>] [--include-credentials]"}
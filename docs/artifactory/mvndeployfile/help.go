@@ -0,0 +1,10 @@
+package mvndeployfile
+
+const Description = "Deploy a file to a Maven repository, with its POM and checksums, without Maven."
+
+var Usage = []string{"jfrog rt mvn-deploy-file --file=<path> --gav=<groupId>:<artifactId>:<version>[:<classifier>] [command options] <target repository>",
+	"jfrog rt mvn-deploy-file --file=<path> --pom=<path> [command options] <target repository>"}
+
+const Arguments string = `	target repository
+		The Maven repository to deploy to. The file is deployed to <groupId with slashes>/<artifactId>/<version>/<artifactId>-<version>[-<classifier>].<extension>,
+		next to its POM and their sha1 and md5 checksum files. Artifactory calculates the maven-metadata.xml of the repository.`
@@ -5,4 +5,5 @@ const Description = "Run npm ci."
 var Usage = []string{`jfrog rt npmci [npm ci args] [command options]`}
 
 const Arguments string = `	npm ci args
-		The npm ci args to run npm ci.`
+		The npm ci args to run npm ci.
+		With the --from-lockfile option, the build-info dependencies are collected from package-lock.json, yarn.lock or pnpm-lock.yaml, rather than by running 'npm ls', and every workspace of the project is added as a module of its own. The option requires the npm configuration created by 'jfrog rt npm-config'.`
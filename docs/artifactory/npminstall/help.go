@@ -5,4 +5,5 @@ const Description = "Run npm install."
 var Usage = []string{`jfrog rt npmi [npm install args] [command options]`}
 
 const Arguments string = `	npm install args
-		The npm install args to run npm install. For example, --global.`
+		The npm install args to run npm install. For example, --global.
+		With the --from-lockfile option, the build-info dependencies are collected from package-lock.json, yarn.lock or pnpm-lock.yaml, rather than by running 'npm ls', and every workspace of the project is added as a module of its own. The option requires the npm configuration created by 'jfrog rt npm-config'.`
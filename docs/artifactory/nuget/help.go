@@ -5,4 +5,5 @@ const Description = "Run NuGet."
 var Usage = []string{`jfrog rt nuget <nuget args> [command options]`}
 
 const Arguments string = `	nuget command
-		The nuget command to run. For example, restore.`
+		The nuget command to run. For example, restore. When collecting build-info, the restored packages are recorded
+		in a module per project, or in the module set by --module. To record the versions locked in packages.lock.json, use 'jfrog rt dotnet'.`
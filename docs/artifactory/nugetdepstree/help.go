@@ -1,5 +1,5 @@
 package nuget
 
-const Description = "Show solution dependency tree."
+const Description = "Show solution dependency tree, and the tree of every target framework."
 
 var Usage = []string{`jfrog rt ndt`}
@@ -0,0 +1,12 @@
+package ocipush
+
+const Description = "Push the images of an OCI image layout to Artifactory, without a Docker daemon."
+
+var Usage = []string{"jfrog rt oci-push <oci layout dir> <target repo> --image=<name>[:<tag>]"}
+
+const Arguments string = `	oci layout dir
+		Path to the OCI image layout directory, as written by buildah, skopeo, ko, bazel rules_oci and similar tools.
+
+	target repo
+		The Docker repository to which the images are pushed.
+`
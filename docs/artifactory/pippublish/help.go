@@ -0,0 +1,8 @@
+package pippublish
+
+const Description = "Publish the Python distributions built into the dist directory to Artifactory."
+
+var Usage = []string{`jfrog rt pipp [command options] [dist directory]`}
+
+const Arguments string = `	dist directory
+		[Optional] The directory containing the wheels and source distributions to publish. If not specified, the dist directory is used.`
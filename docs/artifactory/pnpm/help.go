@@ -0,0 +1,9 @@
+package pnpm
+
+const Description = "Run pnpm commands."
+
+var Usage = []string{`jfrog rt pnpm <pnpm sub-command>`}
+
+const Arguments string = `	pnpm sub-command
+		Arguments and options for the pnpm command. Packages are resolved from the configured repository, and 'pnpm publish' publishes to the configured deployment repository.
+		'pnpm publish' supports the -r, --recursive, --dry-run and --no-git-checks options. With -r, the packages of pnpm-workspace.yaml are published, and the workspace root is published only if it matches one of them.`
@@ -0,0 +1,5 @@
+package pnpmconfig
+
+const Description = "Generate pnpm build configuration."
+
+var Usage = []string{"jfrog rt pnpm-config"}
@@ -0,0 +1,9 @@
+package poetry
+
+const Description = "Run Poetry commands."
+
+var Usage = []string{`jfrog rt poetry <poetry sub-command>`}
+
+const Arguments string = `	poetry sub-command
+		Arguments and options for the poetry command. The dependencies are resolved from Artifactory through
+		the 'artifactory' package source of pyproject.toml, whose URL and credentials are passed to Poetry through the environment.`
@@ -0,0 +1,5 @@
+package poetryconfig
+
+const Description = "Generate Poetry build configuration."
+
+var Usage = []string{"jfrog rt poetry-config"}
@@ -0,0 +1,11 @@
+package proxyserve
+
+const Description = "Serve a local proxy, which forwards the requests of package managers to Artifactory with the configured credentials."
+
+var Usage = []string{"jfrog rt proxy serve [command options]"}
+
+const Arguments string = `	serve
+		Start the proxy. The proxy listens on localhost, and serves every repository set by the command options under
+		/<package type>/, where the package type is go, npm, pypi or maven. The proxy runs until it is stopped with Ctrl+C.
+		If the build name and number are set, the downloaded dependencies are recorded in the build-info when the proxy stops,
+		in a module named <module>:<package type> for every package type. The module defaults to the build name.`
@@ -0,0 +1,14 @@
+package rpmupload
+
+const Description = "Upload an RPM package to an RPM repository, with the properties of its header."
+
+var Usage = []string{"jfrog rt rpm-upload [command options] <package path> <target>"}
+
+const Arguments string = `	package path
+		Path to the .rpm file. Its name, version, release and architecture are read from its header.
+
+	target
+		The target repository and optional path, in the form of <repo>[/<path>]. If the path is omitted or ends with
+		a slash, the package is uploaded with its canonical name, <name>-<version>-<release>.<arch>.rpm.
+		The package isn't uploaded if the repository isn't an RPM repository, if the target directory is above the
+		YUM metadata folder depth of the repository, or if the target file name doesn't match the header.`
@@ -0,0 +1,5 @@
+package setup
+
+const Description = "Detect the projects in the working directory, and write their configuration without prompting."
+
+var Usage = []string{"jfrog rt setup --server-id=<server ID> [command options]"}
@@ -0,0 +1,5 @@
+package terraformconfig
+
+const Description = "Generate Terraform configuration, and write the Artifactory registry into the Terraform CLI configuration file."
+
+var Usage = []string{"jfrog rt terraform-config [command options]"}
@@ -0,0 +1,13 @@
+package terraformpublish
+
+const Description = "Publish a Terraform module to Artifactory."
+
+var Usage = []string{"jfrog rt terraform publish --namespace=<namespace> --provider=<provider> --tag=<version> [command options] [module directory]"}
+
+const Arguments string = `	publish
+		Pack the module directory, which defaults to the working directory, and deploy it to the deployment repository
+		configured by 'jfrog rt terraform-config', as <namespace>/<name>/<provider>/<version>.zip.
+		The name of the module is the name of its directory.
+
+	module directory
+		The root directory of the module, which includes its .tf files.`
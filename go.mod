@@ -14,6 +14,7 @@ require (
 	github.com/jfrog/jfrog-client-go v0.25.0
 	github.com/jszwec/csvutil v1.4.0
 	github.com/mholt/archiver v2.1.0+incompatible
+	github.com/pelletier/go-toml v1.2.0
 	github.com/pierrec/lz4 v2.6.0+incompatible // indirect
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.7.0
@@ -21,6 +21,8 @@ const (
 	Search                  = "search"
 	BuildPublish            = "build-publish"
 	BuildAppend             = "build-append"
+	BuildGraph              = "build-graph"
+	BuildTrace              = "build-trace"
 	BuildScan               = "build-scan"
 	BuildPromote            = "build-promote"
 	BuildDistribute         = "build-distribute"
@@ -37,11 +39,15 @@ const (
 	ContainerPull           = "container-pull"
 	ContainerPush           = "container-push"
 	BuildDockerCreate       = "build-docker-create"
+	OciPush                 = "oci-push"
+	BuildOciCreate          = "build-oci-create"
 	NpmConfig               = "npm-config"
 	Npm                     = "npm"
 	NpmPublish              = "npmPublish"
 	YarnConfig              = "yarn-config"
 	Yarn                    = "yarn"
+	PnpmConfig              = "pnpm-config"
+	Pnpm                    = "pnpm"
 	NugetConfig             = "nuget-config"
 	Nuget                   = "nuget"
 	Dotnet                  = "dotnet"
@@ -52,8 +58,25 @@ const (
 	GoRecursivePublish      = "go-recursive-publish"
 	PipInstall              = "pip-install"
 	PipConfig               = "pip-config"
+	PipPublish              = "pip-publish"
+	PoetryConfig            = "poetry-config"
+	Poetry                  = "poetry"
+	HelmConfig              = "helm-config"
+	Helm                    = "helm"
+	CargoConfig             = "cargo-config"
+	Cargo                   = "cargo"
+	ConanConfig             = "conan-config"
+	Conan                   = "conan"
+	TerraformConfig         = "terraform-config"
+	TerraformPublish        = "terraform-publish"
+	DebUpload               = "deb-upload"
+	RpmUpload               = "rpm-upload"
+	MvnDeployFile           = "mvn-deploy-file"
+	Setup                   = "setup"
+	DepsBundle              = "deps-bundle"
 	Ping                    = "ping"
 	RtCurl                  = "rt-curl"
+	ProxyServe              = "proxy-serve"
 	ReleaseBundleCreate     = "release-bundle-create"
 	ReleaseBundleUpdate     = "release-bundle-update"
 	ReleaseBundleSign       = "release-bundle-sign"
@@ -231,11 +254,20 @@ const (
 	buildPublishPrefix = "bp-"
 	bpDryRun           = buildPublishPrefix + dryRun
 	bpDetailedSummary  = buildPublishPrefix + detailedSummary
+	bpSchema           = buildPublishPrefix + "schema"
+	bpHooks            = buildPublishPrefix + "hooks"
 	envInclude         = "env-include"
 	envExclude         = "env-exclude"
 	buildUrl           = "build-url"
 	project            = "project"
 
+	// Unique build-graph flags
+	graphFormat = "format"
+
+	// Unique build-trace flags
+	inputDirs  = "input-dirs"
+	outputDirs = "output-dirs"
+
 	// Unique build-add-dependencies flags
 	badPrefix    = "bad-"
 	badDryRun    = badPrefix + dryRun
@@ -273,6 +305,8 @@ const (
 	// Unique build-discard flags
 	buildDiscardPrefix = "bdi-"
 	bdiAsync           = buildDiscardPrefix + async
+	bdiDryRun          = buildDiscardPrefix + dryRun
+	bdiReport          = buildDiscardPrefix + "report"
 	maxDays            = "max-days"
 	maxBuilds          = "max-builds"
 	excludeBuilds      = "exclude-builds"
@@ -311,6 +345,19 @@ const (
 	// Build tool flags
 	deploymentThreads = "deployment-threads"
 	skipLogin         = "skip-login"
+	includeModules    = "include-modules"
+	excludeModules    = "exclude-modules"
+	modulePropsFile   = "module-props-file"
+	policy            = "policy"
+
+	// Unique mvn-config and gradle-config export flags
+	exportSettings     = "export-settings"
+	exportInitScript   = "export-init-script"
+	exportPath         = "export-path"
+	includeCredentials = "include-credentials"
+
+	// Unique cargo-config flags
+	exportCargoConfig = "export-cargo-config"
 
 	// Unique docker promote flags
 	dockerPromotePrefix = "docker-promote-"
@@ -322,6 +369,48 @@ const (
 	// Unique build docker create
 	imageFile = "image-file"
 
+	// Unique OCI flags
+	ociImage  = "image"
+	ociLayout = "oci-layout"
+
+	// Unique proxy serve flags
+	proxyPort      = "port"
+	proxyGoRepo    = "go-repo"
+	proxyNpmRepo   = "npm-repo"
+	proxyPypiRepo  = "pypi-repo"
+	proxyMavenRepo = "maven-repo"
+
+	// Unique terraform flags
+	terraformNamespace = "namespace"
+	terraformProvider  = "provider"
+	terraformTag       = "tag"
+	terraformrc        = "terraformrc"
+	noTerraformrc      = "no-terraformrc"
+
+	// Unique deb-upload and rpm-upload flags
+	linuxPkgPrefix       = "linux-pkg-"
+	linuxPkgDryRun       = linuxPkgPrefix + dryRun
+	debDistribution      = "distribution"
+	debComponent         = "component"
+	linuxPkgArchitecture = "architecture"
+
+	// Unique mvn-deploy-file flags
+	mvnDeployFilePath = "file"
+	mvnDeployGav      = "gav"
+	mvnDeployPom      = "pom"
+
+	// Unique setup flags
+	setupPrefix    = "setup-"
+	setupOverwrite = setupPrefix + overwrite
+	setupDryRun    = setupPrefix + dryRun
+	setupMapping   = "mapping"
+
+	// Unique deps-bundle flags
+	depsBundlePrefix = "deps-bundle-"
+	depsBundleDryRun = depsBundlePrefix + dryRun
+	depsBundleLocal  = "local"
+	depsBundleRepos  = "repos"
+
 	// Unique npm flags
 	npmPrefix          = "npm-"
 	npmThreads         = npmPrefix + threads
@@ -341,6 +430,7 @@ const (
 	deps        = "deps"
 	noRegistry  = "no-registry"
 	publishDeps = "publish-deps"
+	workspace   = "workspace"
 	// Deprecated.
 	self = "self"
 
@@ -796,6 +886,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  detailedSummary,
 		Usage: "[Default: false] Set to true to get a command summary with details about the build info artifact.` `",
 	},
+	bpSchema: cli.StringFlag{
+		Name:  "schema",
+		Usage: "[Optional] Path to a JSON schema file. The build info is validated against the schema before it is published, and is not published if any of the schema rules is violated.` `",
+	},
+	bpHooks: cli.StringFlag{
+		Name:  "hooks",
+		Usage: "[Optional] List of executables in the form of \"hook1;hook2;...\". Each hook receives the build info JSON on its standard input and should print the patched build info JSON to its standard output. The hooks run in the given order, before the schema validation. Fields which aren't part of the build info structure are not published.` `",
+	},
 	envInclude: cli.StringFlag{
 		Name:  envInclude,
 		Usage: "[Default: *] List of patterns in the form of \"value1;value2;...\" Only environment variables match those patterns will be included.` `",
@@ -804,6 +902,18 @@ var flagsMap = map[string]cli.Flag{
 		Name:  envExclude,
 		Usage: "[Default: *password*;*psw*;*secret*;*key*;*token*] List of case insensitive patterns in the form of \"value1;value2;...\". Environment variables match those patterns will be excluded.` `",
 	},
+	graphFormat: cli.StringFlag{
+		Name:  graphFormat,
+		Usage: "[Default: dot] The output format of the build graph. Accepts 'dot', 'mermaid' or 'json'.` `",
+	},
+	inputDirs: cli.StringFlag{
+		Name:  inputDirs,
+		Usage: "[Optional] List of directories in the form of \"dir1;dir2;...\". Files read by the traced command from these directories are added to the build info as dependencies.` `",
+	},
+	outputDirs: cli.StringFlag{
+		Name:  outputDirs,
+		Usage: "[Optional] List of directories in the form of \"dir1;dir2;...\". Files written by the traced command to these directories are added to the build info as artifacts.` `",
+	},
 	badRecursive: cli.BoolTFlag{
 		Name:  recursive,
 		Usage: "[Default: true] Set to false if you do not wish to collect artifacts in sub-folders to be added to the build info.` `",
@@ -916,6 +1026,14 @@ var flagsMap = map[string]cli.Flag{
 		Name:  async,
 		Usage: "[Default: false] If set to true, build discard will run asynchronously and will not wait for response.` `",
 	},
+	bdiDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to list the build numbers which would be discarded, without discarding them. With --delete-artifacts, the number and total size of the artifacts which would be deleted are listed as well.` `",
+	},
+	bdiReport: cli.StringFlag{
+		Name:  "report",
+		Usage: "[Optional] Path to a file, to which a JSON report of the builds matching the retention parameters is written.` `",
+	},
 	refs: cli.StringFlag{
 		Name:  refs,
 		Usage: "[Default: refs/remotes/*] List of Git references in the form of \"ref1,ref2,...\" which should be preserved.` `",
@@ -1001,6 +1119,42 @@ var flagsMap = map[string]cli.Flag{
 		Name:  skipLogin,
 		Usage: "[Default: false] Set to true if you'd like the command to skip performing docker login.` `",
 	},
+	includeModules: cli.StringFlag{
+		Name:  includeModules,
+		Usage: "[Optional] Semicolon-separated list of patterns of the modules to include in the build-info, such as 'org.acme:*'. The patterns are matched against the module IDs, with or without their versions, and can include the * and ? wildcards.` `",
+	},
+	excludeModules: cli.StringFlag{
+		Name:  excludeModules,
+		Usage: "[Optional] Semicolon-separated list of patterns of the modules to exclude from the build-info. The patterns are matched against the module IDs, with or without their versions, and can include the * and ? wildcards. The artifacts of the excluded modules are still deployed.` `",
+	},
+	policy: cli.StringFlag{
+		Name:  policy,
+		Usage: "[Optional] Path to a policy file. The resolved dependencies are checked against the blocked licenses, the checksums and the banned coordinates of the policy, and the command fails with a report of the violations. The dependencies are resolved and checked before the command runs, without building the project or running install scripts, and the command doesn't run if they violate the policy.` `",
+	},
+	exportSettings: cli.BoolFlag{
+		Name:  exportSettings,
+		Usage: "[Default: false] Set to true to export the Maven configuration to a settings.xml, which resolves and deploys through the configured repositories without JFrog CLI.` `",
+	},
+	exportInitScript: cli.BoolFlag{
+		Name:  exportInitScript,
+		Usage: "[Default: false] Set to true to export the Gradle configuration to an init script, which resolves and publishes through the configured repositories without JFrog CLI.` `",
+	},
+	exportPath: cli.StringFlag{
+		Name:  exportPath,
+		Usage: "[Default: settings.xml for mvn-config, artifactory.init.gradle for gradle-config and .cargo/config.toml for cargo-config] Path of the exported file.` `",
+	},
+	includeCredentials: cli.BoolFlag{
+		Name:  includeCredentials,
+		Usage: "[Default: false] Set to true to write the credentials of the configured servers to the exported file in plain text. By default, they are read from the ARTIFACTORY_USER and ARTIFACTORY_PASSWORD environment variables.` `",
+	},
+	exportCargoConfig: cli.BoolFlag{
+		Name:  exportCargoConfig,
+		Usage: "[Default: false] Set to true to export the Cargo configuration to a Cargo configuration file, which resolves and publishes through the configured repositories without JFrog CLI. The tokens aren't exported. Cargo 1.74 or above reads them from environment variables.` `",
+	},
+	modulePropsFile: cli.StringFlag{
+		Name:  modulePropsFile,
+		Usage: "[Optional] Path to a YAML file, which maps module patterns to properties in the form of \"key1=value1;key2=value2\". The properties are set on the deployed artifacts of the matching modules. The path is stored relative to the configuration file.` `",
+	},
 	npmArgs: cli.StringFlag{
 		Name:   npmArgs,
 		Usage:  "[Deprecated] [Optional] A list of npm arguments and options in the form of \"--arg1=value1 --arg2=value2\"` `",
@@ -1039,6 +1193,82 @@ var flagsMap = map[string]cli.Flag{
 		Value: "",
 		Usage: "[Optional] List of project dependencies in the form of \"dep1-name:version,dep2-name:version...\" to be published to Artifactory. Use \"ALL\" to publish all dependencies.` `",
 	},
+	terraformNamespace: cli.StringFlag{
+		Name:  terraformNamespace,
+		Usage: "[Mandatory] The namespace of the module in the Terraform registry.` `",
+	},
+	terraformProvider: cli.StringFlag{
+		Name:  terraformProvider,
+		Usage: "[Mandatory] The provider of the module, such as aws or azurerm.` `",
+	},
+	terraformTag: cli.StringFlag{
+		Name:  terraformTag,
+		Usage: "[Mandatory] The version of the module.` `",
+	},
+	terraformrc: cli.StringFlag{
+		Name:  terraformrc,
+		Usage: "[Default: $TF_CLI_CONFIG_FILE or ~/.terraformrc] Path to the Terraform CLI configuration file, to which the Artifactory registry and its credentials are written.` `",
+	},
+	noTerraformrc: cli.BoolFlag{
+		Name:  noTerraformrc,
+		Usage: "[Default: false] Set to true to skip writing the Terraform CLI configuration file.` `",
+	},
+	linuxPkgDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to read and validate the package against the target repository, without uploading it.` `",
+	},
+	mvnDeployFilePath: cli.StringFlag{
+		Name:  mvnDeployFilePath,
+		Usage: "[Mandatory] Path to the file to deploy, such as a jar. Its extension is the type of the artifact.` `",
+	},
+	mvnDeployGav: cli.StringFlag{
+		Name:  mvnDeployGav,
+		Usage: "[Optional] The coordinates of the artifact in the form of <groupId>:<artifactId>:<version>[:<classifier>]. Mandatory if --pom isn't set.` `",
+	},
+	mvnDeployPom: cli.StringFlag{
+		Name:  mvnDeployPom,
+		Usage: "[Optional] Path to the POM to deploy with the file. If not set, a minimal POM is generated, unless the artifact has a classifier.` `",
+	},
+	setupOverwrite: cli.BoolFlag{
+		Name:  overwrite,
+		Usage: "[Default: false] Set to true to overwrite existing project configuration files.` `",
+	},
+	setupDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to print the configuration of the detected projects, without writing it.` `",
+	},
+	setupMapping: cli.StringFlag{
+		Name:  setupMapping,
+		Usage: "[Optional] Path to a YAML file, which maps project types to their resolution and deployment repositories, instead of the <package type>-virtual and <package type>-local naming convention.` `",
+	},
+	depsBundleDryRun: cli.BoolFlag{
+		Name:  dryRun,
+		Usage: "[Default: false] Set to true to verify the bundle and print the paths its files are imported to, without uploading them.` `",
+	},
+	depsBundleLocal: cli.BoolFlag{
+		Name:  depsBundleLocal,
+		Usage: "[Default: false] Set to true to read the dependencies from the local build-info, collected by the build commands, rather than from the published build-info.` `",
+	},
+	depsBundleRepos: cli.StringFlag{
+		Name:  depsBundleRepos,
+		Usage: "[Mandatory for import] The target repositories of the layouts of the bundle, in the form of \"maven=libs-release-local;npm=npm-local\". The layouts are maven, npm, go, pip, nuget and generic.` `",
+	},
+	debDistribution: cli.StringFlag{
+		Name:  debDistribution,
+		Usage: "[Mandatory] The distribution the package is indexed in, such as focal or bookworm.` `",
+	},
+	debComponent: cli.StringFlag{
+		Name:  debComponent,
+		Usage: "[Default: main] The component the package is indexed in.` `",
+	},
+	linuxPkgArchitecture: cli.StringFlag{
+		Name:  linuxPkgArchitecture,
+		Usage: "[Optional] The expected architecture of the package. The package isn't uploaded if its metadata specifies a different architecture.` `",
+	},
+	workspace: cli.BoolFlag{
+		Name:  workspace,
+		Usage: "[Default: false] Set to true to publish all the modules of the go.work workspace, in the order of their dependencies. The published modules require each other in the published version.` `",
+	},
 	self: cli.BoolTFlag{
 		Name:   self,
 		Usage:  "[Deprecated] [Default: true] Set false to skip publishing the project package zip file to Artifactory..` `",
@@ -1212,6 +1442,35 @@ var flagsMap = map[string]cli.Flag{
 		Name:  imageFile,
 		Usage: "[Mandatory] Path to a file which includes one line in the following format: <IMAGE-TAG>@sha256:<MANIFEST-SHA256>.` `",
 	},
+	ociImage: cli.StringFlag{
+		Name:  ociImage,
+		Usage: "[Mandatory] The image name in the repository, optionally followed by the tag, in the form of <name>[:<tag>]. If the tag is omitted, the images of the layout are tagged according to their org.opencontainers.image.ref.name annotations.` `",
+	},
+	proxyPort: cli.StringFlag{
+		Name:  proxyPort,
+		Value: "",
+		Usage: "[Default: 8888] The local port the proxy listens on.` `",
+	},
+	proxyGoRepo: cli.StringFlag{
+		Name:  proxyGoRepo,
+		Usage: "[Optional] Go repository, which is served under /go/ as a GOPROXY.` `",
+	},
+	proxyNpmRepo: cli.StringFlag{
+		Name:  proxyNpmRepo,
+		Usage: "[Optional] npm repository, which is served under /npm/ as an npm registry.` `",
+	},
+	proxyPypiRepo: cli.StringFlag{
+		Name:  proxyPypiRepo,
+		Usage: "[Optional] PyPI repository, which is served under /pypi/, with the simple index under /pypi/simple/.` `",
+	},
+	proxyMavenRepo: cli.StringFlag{
+		Name:  proxyMavenRepo,
+		Usage: "[Optional] Maven repository, which is served under /maven/.` `",
+	},
+	ociLayout: cli.StringFlag{
+		Name:  ociLayout,
+		Usage: "[Mandatory] Path to the OCI image layout directory of the pushed image.` `",
+	},
 	// Config commands Flags
 	configPlatformUrl: cli.StringFlag{
 		Name:  url,
@@ -1318,12 +1577,18 @@ var commandFlags = map[string][]string{
 	},
 	BuildPublish: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, buildUrl, bpDryRun,
-		envInclude, envExclude, insecureTls, project, bpDetailedSummary,
+		envInclude, envExclude, insecureTls, project, bpDetailedSummary, bpSchema, bpHooks,
 	},
 	BuildAppend: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, buildUrl, bpDryRun,
 		envInclude, envExclude, insecureTls, project,
 	},
+	BuildGraph: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, graphFormat, insecureTls, project,
+	},
+	BuildTrace: {
+		buildName, buildNumber, module, project, inputDirs, outputDirs,
+	},
 	BuildAddDependencies: {
 		spec, specVars, uploadExcludePatterns, uploadExclusions, badRecursive, badRegexp, badDryRun, project, badFromRt, serverId,
 	},
@@ -1337,6 +1602,10 @@ var commandFlags = map[string][]string{
 		buildName, buildNumber, module, url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath,
 		serverId, imageFile, project,
 	},
+	BuildOciCreate: {
+		buildName, buildNumber, module, url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath,
+		serverId, ociLayout, ociImage, project,
+	},
 	BuildScan: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, fail, insecureTls,
 		project,
@@ -1351,7 +1620,7 @@ var commandFlags = map[string][]string{
 	},
 	BuildDiscard: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, maxDays, maxBuilds,
-		excludeBuilds, deleteArtifacts, bdiAsync, insecureTls, project,
+		excludeBuilds, deleteArtifacts, bdiAsync, bdiDryRun, bdiReport, insecureTls, project,
 	},
 	GitLfsClean: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, refs, glcRepo, glcDryRun,
@@ -1359,16 +1628,19 @@ var commandFlags = map[string][]string{
 	},
 	MvnConfig: {
 		global, serverIdResolve, serverIdDeploy, repoResolveReleases, repoResolveSnapshots, repoDeployReleases, repoDeploySnapshots,
+		includeModules, excludeModules, modulePropsFile, exportSettings, exportPath, includeCredentials,
 	},
 	GradleConfig: {
 		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy, usesPlugin, useWrapper, deployMavenDesc,
-		deployIvyDesc, ivyDescPattern, ivyArtifactsPattern,
+		deployIvyDesc, ivyDescPattern, ivyArtifactsPattern, includeModules, excludeModules, modulePropsFile, exportInitScript,
+		exportPath, includeCredentials,
 	},
 	Mvn: {
-		buildName, buildNumber, deploymentThreads, insecureTls, project, detailedSummary,
+		buildName, buildNumber, deploymentThreads, insecureTls, project, detailedSummary, includeModules, excludeModules,
+		policy,
 	},
 	Gradle: {
-		buildName, buildNumber, deploymentThreads, project, detailedSummary,
+		buildName, buildNumber, deploymentThreads, project, detailedSummary, includeModules, excludeModules,
 	},
 	DockerPromote: {
 		targetDockerImage, sourceTag, targetTag, dockerPromoteCopy, url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath,
@@ -1378,6 +1650,10 @@ var commandFlags = map[string][]string{
 		buildName, buildNumber, module, url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath,
 		serverId, skipLogin, threads, project, detailedSummary,
 	},
+	OciPush: {
+		buildName, buildNumber, module, url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath,
+		serverId, ociImage, project,
+	},
 	ContainerPull: {
 		buildName, buildNumber, module, url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath,
 		serverId, skipLogin, project,
@@ -1387,7 +1663,7 @@ var commandFlags = map[string][]string{
 	},
 	Npm: {
 		npmArgs, deprecatedUrl, deprecatedUser, deprecatedPassword, deprecatedApikey, deprecatedAccessToken, buildName,
-		buildNumber, module, npmThreads, project,
+		buildNumber, module, npmThreads, project, policy,
 	},
 	NpmPublish: {
 		npmArgs, deprecatedUrl, deprecatedUser, deprecatedPassword, deprecatedApikey, deprecatedAccessToken, buildName,
@@ -1399,6 +1675,12 @@ var commandFlags = map[string][]string{
 	Yarn: {
 		buildName, buildNumber, module, project,
 	},
+	PnpmConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Pnpm: {
+		buildName, buildNumber, module, project,
+	},
 	NugetConfig: {
 		global, serverIdResolve, repoResolve, nugetV2,
 	},
@@ -1410,21 +1692,26 @@ var commandFlags = map[string][]string{
 		global, serverIdResolve, repoResolve, nugetV2,
 	},
 	Dotnet: {
-		buildName, buildNumber, module, project,
+		buildName, buildNumber, module, project, policy,
 	},
 	GoConfig: {
 		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
 	},
 	GoPublish: {
 		deps, self, url, user, password, apikey, accessToken, deprecatedserverId, buildName, buildNumber, module, project, detailedSummary,
+		workspace,
 	},
 	Go: {
 		noRegistry, publishDeps, deprecatedUrl, deprecatedUser, deprecatedPassword, deprecatedApikey,
-		deprecatedAccessToken, buildName, buildNumber, module, project,
+		deprecatedAccessToken, buildName, buildNumber, module, project, policy,
 	},
 	GoRecursivePublish: {
 		url, user, password, apikey, accessToken, serverId,
 	},
+	ProxyServe: {
+		proxyPort, proxyGoRepo, proxyNpmRepo, proxyPypiRepo, proxyMavenRepo, url, user, password, apikey, accessToken,
+		sshPassPhrase, sshKeyPath, serverId, buildName, buildNumber, module, project,
+	},
 	Ping: {
 		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath,
 		clientCertKeyPath, insecureTls,
@@ -1433,11 +1720,63 @@ var commandFlags = map[string][]string{
 		serverId,
 	},
 	PipConfig: {
-		global, serverIdResolve, repoResolve,
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
 	},
 	PipInstall: {
+		buildName, buildNumber, module, project, policy,
+	},
+	PipPublish: {
+		buildName, buildNumber, module, project,
+	},
+	PoetryConfig: {
+		global, serverIdResolve, repoResolve,
+	},
+	Poetry: {
+		buildName, buildNumber, module, project,
+	},
+	HelmConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Helm: {
+		buildName, buildNumber, module, project,
+	},
+	CargoConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy, exportCargoConfig, exportPath,
+	},
+	Cargo: {
+		buildName, buildNumber, module, project,
+	},
+	ConanConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy,
+	},
+	Conan: {
 		buildName, buildNumber, module, project,
 	},
+	TerraformConfig: {
+		global, serverIdResolve, serverIdDeploy, repoResolve, repoDeploy, terraformrc, noTerraformrc,
+	},
+	TerraformPublish: {
+		terraformNamespace, terraformProvider, terraformTag, exclusions, buildName, buildNumber, module, project,
+	},
+	DebUpload: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath, clientCertKeyPath,
+		insecureTls, debDistribution, debComponent, linuxPkgArchitecture, linuxPkgDryRun, buildName, buildNumber, module, project,
+	},
+	RpmUpload: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath, clientCertKeyPath,
+		insecureTls, linuxPkgArchitecture, linuxPkgDryRun, buildName, buildNumber, module, project,
+	},
+	MvnDeployFile: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath, clientCertKeyPath,
+		insecureTls, mvnDeployFilePath, mvnDeployGav, mvnDeployPom, buildName, buildNumber, module, project,
+	},
+	Setup: {
+		serverId, setupMapping, setupOverwrite, setupDryRun,
+	},
+	DepsBundle: {
+		url, user, password, apikey, accessToken, sshPassPhrase, sshKeyPath, serverId, clientCertPath, clientCertKeyPath,
+		insecureTls, buildName, buildNumber, project, depsBundleLocal, depsBundleRepos, depsBundleDryRun,
+	},
 	ReleaseBundleCreate: {
 		url, distUrl, user, password, apikey, accessToken, sshKeyPath, sshPassPhrase, serverId, spec, specVars, targetProps,
 		rbDryRun, sign, desc, exclusions, releaseNotesPath, releaseNotesSyntax, rbPassphrase, rbRepo, insecureTls, distTarget, rbDetailedSummary,